@@ -1,7 +1,11 @@
 package main
 
-import "github.com/Yalaouf/gostman/pkg/tui"
+import (
+	"os"
+
+	"github.com/Yalaouf/gostman/pkg/cli"
+)
 
 func main() {
-	tui.Gostman()
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
 }
@@ -0,0 +1,235 @@
+package assertion
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/jsonpath"
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+func Evaluate(assertions []storage.Assertion, res *request.Response) []Result {
+	results := make([]Result, 0, len(assertions))
+
+	for _, a := range assertions {
+		err := evaluate(a, res)
+
+		result := Result{Assertion: a, Passed: err == nil}
+		if err != nil {
+			result.Message = err.Error()
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func Passed(results []Result) bool {
+	for _, r := range results {
+		if !r.Passed {
+			return false
+		}
+	}
+
+	return true
+}
+
+func Count(results []Result) int {
+	passed := 0
+	for _, r := range results {
+		if r.Passed {
+			passed++
+		}
+	}
+
+	return passed
+}
+
+func evaluate(a storage.Assertion, res *request.Response) error {
+	if res == nil {
+		return fmt.Errorf("no response")
+	}
+
+	switch a.Type {
+	case TypeStatus:
+		return evaluateStatus(a, res.StatusCode)
+	case TypeHeader:
+		return evaluateHeader(a, res.Headers)
+	case TypeJSON:
+		return evaluateJSON(a, res.Body)
+	case TypeTime:
+		return evaluateTime(a, res.TimeTaken)
+	case TypeSchema:
+		return evaluateSchema(a, res.Body)
+	}
+
+	return fmt.Errorf("%w: unknown type %q", ErrInvalidAssertion, a.Type)
+}
+
+func evaluateStatus(a storage.Assertion, status int) error {
+	switch a.Operator {
+	case OpEquals:
+		expected, err := strconv.Atoi(a.Value)
+		if err != nil {
+			return fmt.Errorf("%w: invalid status %q", ErrInvalidAssertion, a.Value)
+		}
+
+		if status != expected {
+			return fmt.Errorf("expected status %d, got %d", expected, status)
+		}
+	case OpRange:
+		low, high, err := parseRange(a.Value)
+		if err != nil {
+			return err
+		}
+
+		if status < low || status > high {
+			return fmt.Errorf("expected status in %d-%d, got %d", low, high, status)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported status operator %q", ErrInvalidAssertion, a.Operator)
+	}
+
+	return nil
+}
+
+func evaluateHeader(a storage.Assertion, headers map[string][]string) error {
+	values := http.Header(headers).Values(a.Target)
+	if len(values) == 0 {
+		return fmt.Errorf("header %s is missing", a.Target)
+	}
+
+	switch a.Operator {
+	case OpExists:
+		return nil
+	case OpEquals:
+		for _, v := range values {
+			if v == a.Value {
+				return nil
+			}
+		}
+		return fmt.Errorf("expected header %s to equal %q, got %q", a.Target, a.Value, strings.Join(values, ", "))
+	case OpMatches:
+		re, err := regexp.Compile(a.Value)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidAssertion, err)
+		}
+
+		for _, v := range values {
+			if re.MatchString(v) {
+				return nil
+			}
+		}
+		return fmt.Errorf("expected header %s to match %s, got %q", a.Target, a.Value, strings.Join(values, ", "))
+	}
+
+	return fmt.Errorf("%w: unsupported header operator %q", ErrInvalidAssertion, a.Operator)
+}
+
+func evaluateJSON(a storage.Assertion, body string) error {
+	actual, err := jsonpath.Lookup(body, a.Target)
+	if err != nil {
+		return err
+	}
+
+	switch a.Operator {
+	case OpExists:
+		return nil
+	case OpEquals:
+		if !matches(actual, a.Value) {
+			return fmt.Errorf("expected %s to equal %s, got %s", a.Target, a.Value, jsonpath.String(actual))
+		}
+		return nil
+	case OpContains:
+		if !contains(actual, a.Value) {
+			return fmt.Errorf("expected %s to contain %s, got %s", a.Target, a.Value, jsonpath.String(actual))
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: unsupported json operator %q", ErrInvalidAssertion, a.Operator)
+}
+
+func evaluateTime(a storage.Assertion, timeTaken int64) error {
+	limit, err := strconv.ParseInt(a.Value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid duration %q", ErrInvalidAssertion, a.Value)
+	}
+
+	if timeTaken >= limit {
+		return fmt.Errorf("expected response under %dms, took %dms", limit, timeTaken)
+	}
+
+	return nil
+}
+
+func evaluateSchema(a storage.Assertion, body string) error {
+	schema, err := compileSchema(a.Value)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAssertion, err)
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(strings.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("response is not valid JSON: %w", err)
+	}
+
+	if err := schema.Validate(instance); err != nil {
+		return fmt.Errorf("schema validation failed: %s", strings.Join(strings.Fields(err.Error()), " "))
+	}
+
+	return nil
+}
+
+func compileSchema(document string) (*jsonschema.Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(document))
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("schema.json", doc); err != nil {
+		return nil, err
+	}
+
+	return compiler.Compile("schema.json")
+}
+
+func matches(actual any, expected string) bool {
+	if jsonpath.String(actual) == expected {
+		return true
+	}
+
+	var value any
+	if err := json.Unmarshal([]byte(expected), &value); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(actual, value)
+}
+
+func contains(actual any, expected string) bool {
+	switch v := actual.(type) {
+	case string:
+		return strings.Contains(v, expected)
+	case []any:
+		for _, item := range v {
+			if matches(item, expected) {
+				return true
+			}
+		}
+	case map[string]any:
+		_, ok := v[expected]
+		return ok
+	}
+
+	return false
+}
@@ -0,0 +1,104 @@
+package assertion
+
+import (
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testResponse = &request.Response{
+	StatusCode: 201,
+	TimeTaken:  120,
+	Headers: map[string][]string{
+		"Content-Type": {"application/json; charset=utf-8"},
+		"Set-Cookie":   {"a=1", "b=2"},
+	},
+	Body: `{"id": 42, "name": "bob", "admin": true, "tags": ["x", "y"], "meta": {"page": 1}}`,
+}
+
+func mustParse(t *testing.T, line string) storage.Assertion {
+	a, err := Parse(line)
+	require.NoError(t, err)
+	return a
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		line    string
+		passed  bool
+		message string
+	}{
+		{"status == 201", true, ""},
+		{"status == 200", false, "expected status 200, got 201"},
+		{"status in 2xx", true, ""},
+		{"status in 400-599", false, "expected status in 400-599, got 201"},
+		{"header content-type exists", true, ""},
+		{"header X-Missing exists", false, "header X-Missing is missing"},
+		{"header Set-Cookie == b=2", true, ""},
+		{"header Content-Type matches ^application/json", true, ""},
+		{"header Content-Type matches ^text/", false, "to match ^text/"},
+		{"json $.id exists", true, ""},
+		{"json $.missing exists", false, "JSON path not found"},
+		{"json $.id == 42", true, ""},
+		{`json $.name == "bob"`, true, ""},
+		{"json $.name == bob", true, ""},
+		{"json $.admin == true", true, ""},
+		{"json $.meta == {\"page\": 1}", true, ""},
+		{"json $.id == 43", false, "expected $.id to equal 43, got 42"},
+		{"json $.tags contains y", true, ""},
+		{"json $.name contains ob", true, ""},
+		{"json $.meta contains page", true, ""},
+		{"json $.tags contains z", false, "to contain z"},
+		{"time < 500", true, ""},
+		{"time < 100", false, "expected response under 100ms, took 120ms"},
+		{`schema {"type": "object", "required": ["id", "name"]}`, true, ""},
+		{`schema {"type": "object", "properties": {"id": {"type": "string"}}}`, false, "schema validation failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			results := Evaluate([]storage.Assertion{mustParse(t, tt.line)}, testResponse)
+			require.Len(t, results, 1)
+
+			assert.Equal(t, tt.passed, results[0].Passed)
+			if tt.message != "" {
+				assert.Contains(t, results[0].Message, tt.message)
+			} else {
+				assert.Empty(t, results[0].Message)
+			}
+		})
+	}
+
+	t.Run("should fail json assertions on non-JSON bodies", func(t *testing.T) {
+		res := &request.Response{StatusCode: 200, Body: "plain"}
+
+		results := Evaluate([]storage.Assertion{mustParse(t, "json $.id exists")}, res)
+
+		assert.False(t, results[0].Passed)
+		assert.Contains(t, results[0].Message, "not valid JSON")
+	})
+
+	t.Run("should fail without a response", func(t *testing.T) {
+		results := Evaluate([]storage.Assertion{mustParse(t, "status == 200")}, nil)
+
+		assert.False(t, results[0].Passed)
+	})
+
+	t.Run("should fail on unknown assertion types", func(t *testing.T) {
+		results := Evaluate([]storage.Assertion{{Type: "body"}}, testResponse)
+
+		assert.False(t, results[0].Passed)
+		assert.Contains(t, results[0].Message, "unknown type")
+	})
+}
+
+func TestPassedAndCount(t *testing.T) {
+	results := []Result{{Passed: true}, {Passed: false}, {Passed: true}}
+
+	assert.False(t, Passed(results))
+	assert.Equal(t, 2, Count(results))
+	assert.True(t, Passed(nil))
+}
@@ -0,0 +1,187 @@
+package assertion
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/jsonpath"
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+func Parse(line string) (storage.Assertion, error) {
+	kind, rest := cutWord(line)
+
+	switch strings.ToLower(kind) {
+	case TypeStatus:
+		return parseStatus(rest)
+	case TypeHeader:
+		return parseTarget(TypeHeader, rest, OpExists, OpEquals, OpMatches)
+	case TypeJSON:
+		return parseTarget(TypeJSON, rest, OpExists, OpEquals, OpContains)
+	case TypeTime:
+		return parseTime(rest)
+	case TypeSchema:
+		return parseSchema(rest)
+	}
+
+	return storage.Assertion{}, fmt.Errorf("%w: unknown type %q", ErrInvalidAssertion, kind)
+}
+
+func parseStatus(s string) (storage.Assertion, error) {
+	op, value := parseOperator(s)
+	a := storage.Assertion{Type: TypeStatus, Operator: op, Value: value}
+
+	switch op {
+	case OpEquals:
+		if _, err := strconv.Atoi(value); err != nil {
+			return a, fmt.Errorf("%w: invalid status %q", ErrInvalidAssertion, value)
+		}
+	case OpRange:
+		if len(value) == 3 && strings.HasSuffix(strings.ToLower(value), "xx") {
+			value = value[:1] + "00-" + value[:1] + "99"
+		}
+
+		if _, _, err := parseRange(value); err != nil {
+			return a, err
+		}
+		a.Value = value
+	default:
+		return a, fmt.Errorf("%w: status expects == or in", ErrInvalidAssertion)
+	}
+
+	return a, nil
+}
+
+func parseTarget(kind, s string, allowed ...string) (storage.Assertion, error) {
+	target, rest := cutWord(s)
+	op, value := parseOperator(rest)
+
+	a := storage.Assertion{Type: kind, Target: target, Operator: op, Value: value}
+
+	if target == "" {
+		return a, fmt.Errorf("%w: %s expects a target", ErrInvalidAssertion, kind)
+	}
+
+	if kind == TypeJSON {
+		if _, err := jsonpath.Parse(target); err != nil {
+			return a, fmt.Errorf("%w: %v", ErrInvalidAssertion, err)
+		}
+	}
+
+	valid := false
+	for _, allowedOp := range allowed {
+		valid = valid || op == allowedOp
+	}
+	if !valid {
+		return a, fmt.Errorf("%w: unsupported operator for %s", ErrInvalidAssertion, kind)
+	}
+
+	if op == OpExists {
+		if value != "" {
+			return a, fmt.Errorf("%w: exists takes no value", ErrInvalidAssertion)
+		}
+		return a, nil
+	}
+
+	if op == OpMatches {
+		if _, err := regexp.Compile(value); err != nil {
+			return a, fmt.Errorf("%w: %v", ErrInvalidAssertion, err)
+		}
+	}
+
+	return a, nil
+}
+
+func parseTime(s string) (storage.Assertion, error) {
+	op, value := parseOperator(s)
+	value = strings.TrimSuffix(value, "ms")
+
+	a := storage.Assertion{Type: TypeTime, Operator: op, Value: value}
+
+	if op != OpBelow {
+		return a, fmt.Errorf("%w: time expects <", ErrInvalidAssertion)
+	}
+
+	if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+		return a, fmt.Errorf("%w: invalid duration %q", ErrInvalidAssertion, value)
+	}
+
+	return a, nil
+}
+
+func parseSchema(s string) (storage.Assertion, error) {
+	a := storage.Assertion{Type: TypeSchema, Value: strings.TrimSpace(s)}
+
+	if !json.Valid([]byte(a.Value)) {
+		return a, fmt.Errorf("%w: schema must be a JSON document", ErrInvalidAssertion)
+	}
+
+	if _, err := compileSchema(a.Value); err != nil {
+		return a, fmt.Errorf("%w: %v", ErrInvalidAssertion, err)
+	}
+
+	return a, nil
+}
+
+func parseOperator(s string) (string, string) {
+	word, rest := cutWord(s)
+
+	op, ok := operators[strings.ToLower(word)]
+	if !ok {
+		return "", strings.TrimSpace(s)
+	}
+
+	return op, rest
+}
+
+func parseRange(value string) (int, int, error) {
+	low, high, ok := strings.Cut(value, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: invalid range %q", ErrInvalidAssertion, value)
+	}
+
+	lowValue, err := strconv.Atoi(strings.TrimSpace(low))
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: invalid range %q", ErrInvalidAssertion, value)
+	}
+
+	highValue, err := strconv.Atoi(strings.TrimSpace(high))
+	if err != nil || highValue < lowValue {
+		return 0, 0, fmt.Errorf("%w: invalid range %q", ErrInvalidAssertion, value)
+	}
+
+	return lowValue, highValue, nil
+}
+
+func cutWord(s string) (string, string) {
+	s = strings.TrimSpace(s)
+
+	i := strings.IndexAny(s, " \t")
+	if i == -1 {
+		return s, ""
+	}
+
+	return s[:i], strings.TrimSpace(s[i+1:])
+}
+
+func Format(a storage.Assertion) string {
+	parts := []string{a.Type}
+	if a.Target != "" {
+		parts = append(parts, a.Target)
+	}
+	if symbol, ok := symbols[a.Operator]; ok {
+		parts = append(parts, symbol)
+	}
+	if a.Value != "" {
+		value := a.Value
+		if a.Type == TypeTime {
+			value += "ms"
+		}
+		parts = append(parts, value)
+	}
+
+	return strings.Join(parts, " ")
+}
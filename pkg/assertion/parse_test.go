@@ -0,0 +1,93 @@
+package assertion
+
+import (
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected storage.Assertion
+		format   string
+	}{
+		{
+			"status == 200",
+			storage.Assertion{Type: TypeStatus, Operator: OpEquals, Value: "200"},
+			"status == 200",
+		},
+		{
+			"status in 2xx",
+			storage.Assertion{Type: TypeStatus, Operator: OpRange, Value: "200-299"},
+			"status in 200-299",
+		},
+		{
+			"header Content-Type exists",
+			storage.Assertion{Type: TypeHeader, Target: "Content-Type", Operator: OpExists},
+			"header Content-Type exists",
+		},
+		{
+			"header Content-Type matches ^application/json",
+			storage.Assertion{Type: TypeHeader, Target: "Content-Type", Operator: OpMatches, Value: "^application/json"},
+			"header Content-Type matches ^application/json",
+		},
+		{
+			`json $.user.name == "bob smith"`,
+			storage.Assertion{Type: TypeJSON, Target: "$.user.name", Operator: OpEquals, Value: `"bob smith"`},
+			`json $.user.name == "bob smith"`,
+		},
+		{
+			"JSON $.tags contains admin",
+			storage.Assertion{Type: TypeJSON, Target: "$.tags", Operator: OpContains, Value: "admin"},
+			"json $.tags contains admin",
+		},
+		{
+			"time < 500ms",
+			storage.Assertion{Type: TypeTime, Operator: OpBelow, Value: "500"},
+			"time < 500ms",
+		},
+		{
+			`schema {"type": "object"}`,
+			storage.Assertion{Type: TypeSchema, Value: `{"type": "object"}`},
+			`schema {"type": "object"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			a, err := Parse(tt.line)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, a)
+			assert.Equal(t, tt.format, Format(a))
+		})
+	}
+
+	t.Run("should reject invalid assertions", func(t *testing.T) {
+		lines := []string{
+			"",
+			"body == 1",
+			"status == ok",
+			"status in 300-200",
+			"status exists",
+			"header",
+			"header X contains a",
+			"header X matches [",
+			"header X exists now",
+			"json $.a[ exists",
+			"json $.a matches x",
+			"time < soon",
+			"time == 5",
+			"schema {",
+			`schema {"type": 5}`,
+		}
+
+		for _, line := range lines {
+			_, err := Parse(line)
+			assert.ErrorIs(t, err, ErrInvalidAssertion, line)
+		}
+	})
+}
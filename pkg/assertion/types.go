@@ -0,0 +1,54 @@
+package assertion
+
+import (
+	"errors"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+var ErrInvalidAssertion = errors.New("invalid assertion")
+
+const (
+	TypeStatus = "status"
+	TypeHeader = "header"
+	TypeJSON   = "json"
+	TypeTime   = "time"
+	TypeSchema = "schema"
+)
+
+const (
+	OpEquals   = "equals"
+	OpRange    = "range"
+	OpExists   = "exists"
+	OpMatches  = "matches"
+	OpContains = "contains"
+	OpBelow    = "below"
+)
+
+var operators = map[string]string{
+	"==":       OpEquals,
+	"eq":       OpEquals,
+	"equals":   OpEquals,
+	"in":       OpRange,
+	"exists":   OpExists,
+	"=~":       OpMatches,
+	"matches":  OpMatches,
+	"contains": OpContains,
+	"<":        OpBelow,
+	"below":    OpBelow,
+}
+
+var symbols = map[string]string{
+	OpEquals:   "==",
+	OpRange:    "in",
+	OpExists:   "exists",
+	OpMatches:  "matches",
+	OpContains: "contains",
+	OpBelow:    "<",
+}
+
+type Result struct {
+	Assertion storage.Assertion
+	Passed    bool
+	Message   string
+}
@@ -0,0 +1,152 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+)
+
+func NewClient(concurrency int) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = max(concurrency, transport.MaxIdleConns)
+	transport.MaxIdleConnsPerHost = concurrency
+
+	return &http.Client{Transport: transport}
+}
+
+func (o Options) Validate() error {
+	switch {
+	case o.Requests < 0:
+		return fmt.Errorf("%w: requests must not be negative", ErrInvalidOptions)
+	case o.Duration < 0:
+		return fmt.Errorf("%w: duration must not be negative", ErrInvalidOptions)
+	case o.Concurrency < 0:
+		return fmt.Errorf("%w: concurrency must not be negative", ErrInvalidOptions)
+	case o.RPS < 0:
+		return fmt.Errorf("%w: rps must not be negative", ErrInvalidOptions)
+	}
+
+	return nil
+}
+
+func Run(ctx context.Context, model *request.Model, opts Options, onSample func(Sample)) (*Report, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	if opts.Requests == 0 && opts.Duration == 0 {
+		opts.Requests = DefaultRequests
+	}
+
+	if opts.Concurrency == 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+
+	if opts.Requests > 0 {
+		opts.Concurrency = min(opts.Concurrency, opts.Requests)
+	}
+
+	shared := *model
+	shared.SetRetry(request.RetryPolicy{})
+
+	client := opts.Client
+	if client == nil {
+		client = NewClient(opts.Concurrency)
+		defer client.CloseIdleConnections()
+
+		model.ConfigureTransport(client.Transport.(*http.Transport))
+		shared.SetHTTPVersion(request.HTTPVersionAuto).SetDisableKeepAlives(false).SetMaxIdleConns(0)
+	}
+
+	if opts.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Duration)
+		defer cancel()
+	}
+
+	shared.SetContext(ctx)
+	shared.SetClient(client)
+
+	jobs := make(chan struct{})
+	samples := make(chan Sample)
+
+	go dispatch(ctx, jobs, opts)
+
+	var wg sync.WaitGroup
+	for range opts.Concurrency {
+		wg.Go(func() {
+			for range jobs {
+				samples <- send(&shared)
+			}
+		})
+	}
+
+	go func() {
+		wg.Wait()
+		close(samples)
+	}()
+
+	report := &Report{Statuses: map[int]int{}, ErrorsBy: map[string]int{}}
+	start := time.Now()
+
+	for sample := range samples {
+		if ctx.Err() != nil && sample.Err != nil {
+			continue
+		}
+
+		report.add(sample)
+
+		if onSample != nil {
+			onSample(sample)
+		}
+	}
+
+	report.Duration = time.Since(start)
+	report.sort()
+
+	return report, nil
+}
+
+func dispatch(ctx context.Context, jobs chan<- struct{}, opts Options) {
+	defer close(jobs)
+
+	var tick <-chan time.Time
+	if opts.RPS > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / opts.RPS))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for sent := 0; opts.Requests == 0 || sent < opts.Requests; sent++ {
+		if tick != nil && sent > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick:
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case jobs <- struct{}{}:
+		}
+	}
+}
+
+func send(model *request.Model) Sample {
+	start := time.Now()
+	res, err := request.SendRequest(model)
+	latency := time.Since(start)
+
+	if err != nil {
+		return Sample{Latency: latency, Err: err}
+	}
+	defer res.Remove()
+
+	return Sample{Latency: latency, Status: res.StatusCode}
+}
@@ -0,0 +1,234 @@
+package bench
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testServer struct {
+	*httptest.Server
+	requests    atomic.Int64
+	connections atomic.Int64
+	inFlight    atomic.Int64
+	maxInFlight atomic.Int64
+}
+
+func newTestServer(t *testing.T, delay time.Duration) *testServer {
+	ts := &testServer{}
+
+	ts.Server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := ts.requests.Add(1)
+
+		current := ts.inFlight.Add(1)
+		defer ts.inFlight.Add(-1)
+		for {
+			peak := ts.maxInFlight.Load()
+			if current <= peak || ts.maxInFlight.CompareAndSwap(peak, current) {
+				break
+			}
+		}
+
+		time.Sleep(delay)
+
+		if r.URL.Path == "/flaky" && n%4 == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	ts.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			ts.connections.Add(1)
+		}
+	}
+	ts.Start()
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func newModel(url string) *request.Model {
+	return request.NewModel().
+		SetMethod(request.GET).
+		SetURL(url).
+		SetTimeout(request.DefaultTimeout)
+}
+
+func TestRun(t *testing.T) {
+	t.Run("should send the requested number of requests", func(t *testing.T) {
+		server := newTestServer(t, 5*time.Millisecond)
+
+		var samples atomic.Int64
+		report, err := Run(context.Background(), newModel(server.URL), Options{Requests: 50, Concurrency: 5},
+			func(Sample) { samples.Add(1) })
+		require.NoError(t, err)
+
+		assert.Equal(t, 50, report.Requests)
+		assert.Equal(t, int64(50), server.requests.Load())
+		assert.Equal(t, int64(50), samples.Load())
+		assert.Equal(t, map[int]int{200: 50}, report.Statuses)
+		assert.Zero(t, report.ErrorRate())
+		assert.Positive(t, report.Throughput())
+		assert.LessOrEqual(t, server.maxInFlight.Load(), int64(5))
+	})
+
+	t.Run("should reuse keep-alive connections", func(t *testing.T) {
+		server := newTestServer(t, time.Millisecond)
+
+		_, err := Run(context.Background(), newModel(server.URL), Options{Requests: 40, Concurrency: 4}, nil)
+		require.NoError(t, err)
+
+		assert.LessOrEqual(t, server.connections.Load(), int64(4))
+	})
+
+	t.Run("should reuse connections with per-request connection settings", func(t *testing.T) {
+		server := newTestServer(t, time.Millisecond)
+
+		model := newModel(server.URL).SetHTTPVersion(request.HTTPVersion11).SetMaxIdleConns(4)
+		for range 3 {
+			_, err := Run(context.Background(), model, Options{Requests: 20, Concurrency: 4}, nil)
+			require.NoError(t, err)
+		}
+
+		assert.LessOrEqual(t, server.connections.Load(), int64(12))
+	})
+
+	t.Run("should limit the request rate", func(t *testing.T) {
+		server := newTestServer(t, 0)
+
+		start := time.Now()
+		report, err := Run(context.Background(), newModel(server.URL), Options{Requests: 10, Concurrency: 10, RPS: 50}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, 10, report.Requests)
+		assert.GreaterOrEqual(t, time.Since(start), 170*time.Millisecond)
+	})
+
+	t.Run("should run for a duration", func(t *testing.T) {
+		server := newTestServer(t, 2*time.Millisecond)
+
+		start := time.Now()
+		report, err := Run(context.Background(), newModel(server.URL), Options{Duration: 100 * time.Millisecond, Concurrency: 2}, nil)
+		require.NoError(t, err)
+
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Positive(t, report.Requests)
+		assert.Zero(t, report.Errors)
+	})
+
+	t.Run("should record status distribution and errors", func(t *testing.T) {
+		server := newTestServer(t, 0)
+
+		report, err := Run(context.Background(), newModel(server.URL+"/flaky"), Options{Requests: 20, Concurrency: 1}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[int]int{200: 15, 503: 5}, report.Statuses)
+		assert.Equal(t, 5, report.Failures)
+		assert.InDelta(t, 0.25, report.ErrorRate(), 0.001)
+
+		report, err = Run(context.Background(), newModel("http://127.0.0.1:0"), Options{Requests: 3}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, report.Errors)
+		assert.Len(t, report.ErrorsBy, 1)
+		assert.Equal(t, 1.0, report.ErrorRate())
+	})
+
+	t.Run("should remove spilled response bodies", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("TMPDIR", dir)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(strings.Repeat("x", 1000)))
+		}))
+		defer server.Close()
+
+		report, err := Run(context.Background(), newModel(server.URL).SetMaxBodySize(100), Options{Requests: 10, Concurrency: 2}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 10, report.Requests)
+
+		files, err := filepath.Glob(filepath.Join(dir, "gostman-body-*"))
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+
+	t.Run("should count retryable statuses as failures without retrying", func(t *testing.T) {
+		server := newTestServer(t, 0)
+
+		model := newModel(server.URL + "/flaky").SetRetry(request.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond})
+		report, err := Run(context.Background(), model, Options{Requests: 8, Concurrency: 1}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(8), server.requests.Load())
+		assert.Equal(t, map[int]int{200: 6, 503: 2}, report.Statuses)
+		assert.Equal(t, 2, report.Failures)
+		assert.Equal(t, 3, model.Retry.MaxAttempts)
+	})
+
+	t.Run("should reject invalid options", func(t *testing.T) {
+		_, err := Run(context.Background(), newModel("http://localhost"), Options{Concurrency: -1}, nil)
+		assert.ErrorIs(t, err, ErrInvalidOptions)
+	})
+}
+
+func TestReport(t *testing.T) {
+	report := &Report{Statuses: map[int]int{}, ErrorsBy: map[string]int{}, Duration: 2 * time.Second}
+	for i := 100; i >= 1; i-- {
+		report.add(Sample{Latency: time.Duration(i) * time.Millisecond, Status: 200})
+	}
+	report.sort()
+
+	assert.Equal(t, 50.0, report.Throughput())
+	assert.Equal(t, time.Millisecond, report.Min())
+	assert.Equal(t, 100*time.Millisecond, report.Max())
+	assert.Equal(t, 50*time.Millisecond, report.Percentile(50))
+	assert.Equal(t, 90*time.Millisecond, report.Percentile(90))
+	assert.Equal(t, 99*time.Millisecond, report.Percentile(99))
+	assert.Equal(t, 50500*time.Microsecond, report.Mean())
+
+	buckets := report.Histogram()
+	require.Len(t, buckets, histogramBuckets)
+
+	total := 0
+	for _, bucket := range buckets {
+		total += bucket.Count
+	}
+	assert.Equal(t, 100, total)
+	assert.Equal(t, time.Millisecond, buckets[0].From)
+	assert.Equal(t, 100*time.Millisecond, buckets[len(buckets)-1].To)
+
+	empty := &Report{}
+	assert.Zero(t, empty.Percentile(99))
+	assert.Nil(t, empty.Histogram())
+}
+
+func TestFormat(t *testing.T) {
+	report := &Report{Statuses: map[int]int{}, ErrorsBy: map[string]int{}, Duration: time.Second}
+	report.add(Sample{Latency: 10 * time.Millisecond, Status: 200})
+	report.add(Sample{Latency: 30 * time.Millisecond, Status: 503})
+	report.add(Sample{Latency: 5 * time.Millisecond, Err: context.DeadlineExceeded})
+	report.sort()
+
+	var b strings.Builder
+	require.NoError(t, Format(&b, report))
+
+	out := b.String()
+	assert.Contains(t, out, "Requests:    3 in 1s")
+	assert.Contains(t, out, "Throughput:  3.0 req/s")
+	assert.Contains(t, out, "Error rate:  66.7% (1 errors, 1 failed statuses)")
+	assert.Contains(t, out, "p99 30ms")
+	assert.Contains(t, out, "  200  1\n  503  1\n")
+	assert.Contains(t, out, "  1  context deadline exceeded\n")
+	assert.Contains(t, out, "Histogram:")
+}
@@ -0,0 +1,77 @@
+package bench
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+const barWidth = 40
+
+func Format(w io.Writer, r *Report) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Requests:    %d in %s\n", r.Requests, r.Duration.Round(time.Millisecond))
+	fmt.Fprintf(&b, "Throughput:  %.1f req/s\n", r.Throughput())
+	fmt.Fprintf(&b, "Error rate:  %.1f%% (%d errors, %d failed statuses)\n", r.ErrorRate()*100, r.Errors, r.Failures)
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "Latency:     min %s  mean %s  max %s\n",
+		round(r.Min()), round(r.Mean()), round(r.Max()))
+	fmt.Fprintf(&b, "             p50 %s  p90 %s  p99 %s\n",
+		round(r.Percentile(50)), round(r.Percentile(90)), round(r.Percentile(99)))
+
+	if codes := r.StatusCodes(); len(codes) > 0 {
+		b.WriteString("\nStatus codes:\n")
+		for _, code := range codes {
+			fmt.Fprintf(&b, "  %d  %d\n", code, r.Statuses[code])
+		}
+	}
+
+	if len(r.ErrorsBy) > 0 {
+		b.WriteString("\nErrors:\n")
+		for _, msg := range sortedKeys(r.ErrorsBy) {
+			fmt.Fprintf(&b, "  %d  %s\n", r.ErrorsBy[msg], msg)
+		}
+	}
+
+	if buckets := r.Histogram(); len(buckets) > 0 {
+		b.WriteString("\nHistogram:\n")
+
+		peak := 0
+		for _, bucket := range buckets {
+			peak = max(peak, bucket.Count)
+		}
+
+		for _, bucket := range buckets {
+			bar := strings.Repeat("█", bucket.Count*barWidth/max(peak, 1))
+			fmt.Fprintf(&b, "  %10s  %-*s %d\n", round(bucket.To), barWidth, bar, bucket.Count)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func round(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	}
+
+	return d.Round(time.Microsecond)
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,117 @@
+package bench
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+func (r *Report) add(sample Sample) {
+	r.Requests++
+	r.latencies = append(r.latencies, sample.Latency)
+
+	switch {
+	case sample.Err != nil:
+		r.Errors++
+		r.ErrorsBy[sample.Err.Error()]++
+	default:
+		r.Statuses[sample.Status]++
+		if sample.Status >= 400 {
+			r.Failures++
+		}
+	}
+}
+
+func (r *Report) sort() {
+	slices.Sort(r.latencies)
+}
+
+func (r *Report) Throughput() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+
+	return float64(r.Requests) / r.Duration.Seconds()
+}
+
+func (r *Report) ErrorRate() float64 {
+	if r.Requests == 0 {
+		return 0
+	}
+
+	return float64(r.Errors+r.Failures) / float64(r.Requests)
+}
+
+func (r *Report) Percentile(p float64) time.Duration {
+	if len(r.latencies) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p/100*float64(len(r.latencies)))) - 1
+	return r.latencies[min(max(rank, 0), len(r.latencies)-1)]
+}
+
+func (r *Report) Min() time.Duration {
+	if len(r.latencies) == 0 {
+		return 0
+	}
+
+	return r.latencies[0]
+}
+
+func (r *Report) Max() time.Duration {
+	if len(r.latencies) == 0 {
+		return 0
+	}
+
+	return r.latencies[len(r.latencies)-1]
+}
+
+func (r *Report) Mean() time.Duration {
+	if len(r.latencies) == 0 {
+		return 0
+	}
+
+	var total time.Duration
+	for _, latency := range r.latencies {
+		total += latency
+	}
+
+	return total / time.Duration(len(r.latencies))
+}
+
+func (r *Report) Histogram() []Bucket {
+	if len(r.latencies) == 0 {
+		return nil
+	}
+
+	lowest, highest := r.Min(), r.Max()
+	width := (highest - lowest) / histogramBuckets
+	if width <= 0 {
+		return []Bucket{{From: lowest, To: highest, Count: len(r.latencies)}}
+	}
+
+	buckets := make([]Bucket, histogramBuckets)
+	for i := range buckets {
+		buckets[i].From = lowest + time.Duration(i)*width
+		buckets[i].To = buckets[i].From + width
+	}
+	buckets[len(buckets)-1].To = highest
+
+	for _, latency := range r.latencies {
+		i := min(int((latency-lowest)/width), len(buckets)-1)
+		buckets[i].Count++
+	}
+
+	return buckets
+}
+
+func (r *Report) StatusCodes() []int {
+	codes := make([]int, 0, len(r.Statuses))
+	for code := range r.Statuses {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+
+	return codes
+}
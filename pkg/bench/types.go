@@ -0,0 +1,45 @@
+package bench
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+var ErrInvalidOptions = errors.New("invalid bench options")
+
+const (
+	DefaultRequests    = 100
+	DefaultConcurrency = 10
+	histogramBuckets   = 10
+)
+
+type Options struct {
+	Requests    int
+	Duration    time.Duration
+	Concurrency int
+	RPS         float64
+	Client      *http.Client
+}
+
+type Sample struct {
+	Latency time.Duration
+	Status  int
+	Err     error
+}
+
+type Bucket struct {
+	From  time.Duration
+	To    time.Duration
+	Count int
+}
+
+type Report struct {
+	Requests  int
+	Errors    int
+	Failures  int
+	Duration  time.Duration
+	Statuses  map[int]int
+	ErrorsBy  map[string]int
+	latencies []time.Duration
+}
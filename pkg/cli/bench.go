@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/Yalaouf/gostman/pkg/bench"
+	"github.com/Yalaouf/gostman/pkg/runner"
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+func runBench(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("bench", stderr)
+	envName := fs.String("env", "", "environment whose variables are used")
+	requests := fs.Int("n", 0, "number of requests to send (default 100 unless --duration is set)")
+	duration := fs.Duration("duration", 0, "keep sending requests for this long, e.g. 30s")
+	concurrency := fs.Int("c", bench.DefaultConcurrency, "number of concurrent workers")
+	rps := fs.Float64("rps", 0, "target requests per second across all workers (0 for unlimited)")
+	timeout := fs.Int64("timeout", 0, "request timeout in milliseconds")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return usageError("expected exactly one request")
+	}
+
+	opts := bench.Options{
+		Requests:    *requests,
+		Duration:    *duration,
+		Concurrency: *concurrency,
+		RPS:         *rps,
+	}
+
+	if err := opts.Validate(); err != nil {
+		return usageError("%v", err)
+	}
+
+	s, err := storage.New()
+	if err != nil {
+		return err
+	}
+
+	req, err := s.FindRequest(positional[0])
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, positional[0])
+	}
+
+	if req.IsWebSocket() || req.IsGRPC() {
+		return fmt.Errorf("%w: %s", runner.ErrUnsupportedProtocol, req.Protocol)
+	}
+
+	var environment *storage.Environment
+	if *envName != "" {
+		environment, err = s.FindEnvironment(*envName)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, *envName)
+		}
+	}
+
+	collection, _ := s.GetCollection(req.CollectionID)
+
+	model := runner.Build(req, runner.Variables(collection, environment, nil))
+	model.SetTimeout(*timeout)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Fprintf(stdout, "%s %s %s\n\n", req.Name, model.MethodString(), model.URL)
+
+	report, err := bench.Run(ctx, model, opts, nil)
+	if err != nil {
+		return err
+	}
+
+	return bench.Format(stdout, report)
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBench(t *testing.T) {
+	t.Run("should benchmark a saved request", func(t *testing.T) {
+		setupRunCollection(t, "/a", "/fail")
+
+		var stdout, stderr bytes.Buffer
+		err := runBench([]string{"smoke/GET /a", "-n", "20", "-c", "4"}, &stdout, &stderr)
+		require.NoError(t, err)
+
+		out := stdout.String()
+		assert.Contains(t, out, "GET /a GET http://127.0.0.1")
+		assert.Contains(t, out, "Requests:    20 in")
+		assert.Contains(t, out, "  200  20\n")
+		assert.Contains(t, out, "p99")
+		assert.Contains(t, out, "Histogram:")
+	})
+
+	t.Run("should report failed statuses", func(t *testing.T) {
+		setupRunCollection(t, "/fail")
+
+		var stdout, stderr bytes.Buffer
+		err := runBench([]string{"GET /fail", "-n", "5"}, &stdout, &stderr)
+		require.NoError(t, err)
+
+		assert.Contains(t, stdout.String(), "Error rate:  100.0% (0 errors, 5 failed statuses)")
+		assert.Contains(t, stdout.String(), "  503  5\n")
+	})
+
+	t.Run("should reject unknown requests and invalid options", func(t *testing.T) {
+		setupRunCollection(t, "/a")
+
+		var stdout, stderr bytes.Buffer
+
+		err := runBench([]string{"Smoke/missing"}, &stdout, &stderr)
+		assert.ErrorIs(t, err, storage.ErrRequestNotFound)
+
+		err = runBench([]string{"-c", "-1", "GET /a"}, &stdout, &stderr)
+		assert.ErrorIs(t, err, ErrUsage)
+
+		err = runBench(nil, &stdout, &stderr)
+		assert.ErrorIs(t, err, ErrUsage)
+	})
+}
@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/tui"
+)
+
+func commands() []command {
+	return []command{
+		{
+			name:    "import",
+			usage:   "import [--name NAME] [--dedupe] FILE",
+			summary: "Import a HAR or .http/.rest file into a new collection",
+			run:     runImport,
+		},
+		{
+			name:    "export",
+			usage:   "export [--format har|http] [--collection NAME | --history] [-o FILE]",
+			summary: "Export a collection or the request history",
+			run:     runExport,
+		},
+		{
+			name:    "open",
+			usage:   "open FILE",
+			summary: "Open a .http/.rest file as a collection in the TUI",
+			run:     runOpen,
+		},
+		{
+			name:    "run",
+			usage:   "run [--env NAME] [--bail] [--delay DURATION] [--timeout MS] [--data FILE] [--junit|--json|--tap FILE] [--secret NAME] COLLECTION",
+			summary: "Send every request of a collection and report the results",
+			run:     runRun,
+		},
+		{
+			name:    "send",
+			usage:   "send [--env NAME] [--var KEY=VALUE] [--output body|headers|status|json] [--timeout MS] [COLLECTION/]REQUEST",
+			summary: "Send a saved request and print its response",
+			run:     runSend,
+		},
+		{
+			name:    "bench",
+			usage:   "bench [--env NAME] [-n N | --duration DURATION] [-c N] [--rps N] [--timeout MS] [COLLECTION/]REQUEST",
+			summary: "Load-test a saved request and report latency percentiles",
+			run:     runBench,
+		},
+		{
+			name:    "env",
+			usage:   "env [--delete] [NAME [KEY=VALUE...]]",
+			summary: "List, show, update or delete environments",
+			run:     runEnv,
+		},
+		{
+			name:    "init",
+			usage:   "init [--layout json|directory] [DIR]",
+			summary: "Create a project-local .gostman workspace",
+			run:     runInit,
+		},
+		{
+			name:    "layout",
+			usage:   "layout [json|directory]",
+			summary: "Show or change how collections are stored on disk",
+			run:     runLayout,
+		},
+	}
+}
+
+func Run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		tui.Gostman()
+		return 0
+	}
+
+	switch args[0] {
+	case "help", "-h", "--help":
+		printUsage(stdout)
+		return 0
+	}
+
+	for _, cmd := range commands() {
+		if cmd.name != args[0] {
+			continue
+		}
+
+		err := cmd.run(args[1:], stdout, stderr)
+		switch {
+		case err == nil, errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, ErrUsage):
+			fmt.Fprintf(stderr, "gostman %s: %v\nusage: gostman %s\n", cmd.name, err, cmd.usage)
+			return 2
+		}
+
+		fmt.Fprintf(stderr, "gostman %s: %v\n", cmd.name, err)
+
+		var exit *exitError
+		if errors.As(err, &exit) {
+			return exit.code
+		}
+
+		return 1
+	}
+
+	fmt.Fprintf(stderr, "gostman: unknown command %q\n\n", args[0])
+	printUsage(stderr)
+	return 2
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage:")
+	fmt.Fprintln(w, "  gostman                 Start the TUI")
+
+	for _, cmd := range commands() {
+		fmt.Fprintf(w, "  gostman %s\n      %s\n", cmd.usage, cmd.summary)
+	}
+}
+
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("gostman "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		if args[0] == "--" {
+			return append(positional, args[1:]...), nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func usageError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrUsage, fmt.Sprintf(format, args...))
+}
+
+func fileFormat(path, override string) string {
+	if override != "" {
+		return strings.ToLower(override)
+	}
+
+	i := strings.LastIndex(path, ".")
+	if i == -1 {
+		return ""
+	}
+
+	return strings.ToLower(path[i+1:])
+}
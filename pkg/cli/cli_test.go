@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	t.Run("should print usage on help", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		code := Run([]string{"help"}, &stdout, &stderr)
+
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout.String(), "gostman import")
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("should return 2 on unknown command", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		code := Run([]string{"nope"}, &stdout, &stderr)
+
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr.String(), `unknown command "nope"`)
+	})
+
+	t.Run("should return 2 and the usage on invalid usage", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		code := Run([]string{"import"}, &stdout, &stderr)
+
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr.String(), "usage: gostman import")
+	})
+
+	t.Run("should return 1 on command failure", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		var stdout, stderr bytes.Buffer
+
+		code := Run([]string{"import", "missing.har"}, &stdout, &stderr)
+
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr.String(), "no such file or directory")
+	})
+}
+
+func TestParseArgs(t *testing.T) {
+	t.Run("should accept flags after positional arguments", func(t *testing.T) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		verbose := fs.Bool("v", false, "")
+
+		positional, err := parseArgs(fs, []string{"a", "-v", "b"})
+
+		require.NoError(t, err)
+		assert.True(t, *verbose)
+		assert.Equal(t, []string{"a", "b"}, positional)
+	})
+
+	t.Run("should stop parsing flags after --", func(t *testing.T) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		verbose := fs.Bool("v", false, "")
+
+		positional, err := parseArgs(fs, []string{"--", "-v"})
+
+		require.NoError(t, err)
+		assert.False(t, *verbose)
+		assert.Equal(t, []string{"-v"}, positional)
+	})
+
+	t.Run("should return flag errors", func(t *testing.T) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(&bytes.Buffer{})
+
+		_, err := parseArgs(fs, []string{"-unknown"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestFileFormat(t *testing.T) {
+	t.Run("should use the extension", func(t *testing.T) {
+		assert.Equal(t, "har", fileFormat("dir.v2/capture.HAR", ""))
+	})
+
+	t.Run("should prefer the override", func(t *testing.T) {
+		assert.Equal(t, "har", fileFormat("capture.json", "HAR"))
+	})
+
+	t.Run("should return empty without extension", func(t *testing.T) {
+		assert.Empty(t, fileFormat("capture", ""))
+	})
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+func runEnv(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("env", stderr)
+	remove := fs.Bool("delete", false, "delete the environment")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	s, err := storage.New()
+	if err != nil {
+		return err
+	}
+
+	if len(positional) == 0 {
+		if *remove {
+			return usageError("expected an environment to delete")
+		}
+
+		for _, env := range s.ListEnvironments() {
+			fmt.Fprintf(stdout, "%s (%d variables)\n", env.Name, len(env.Variables))
+		}
+		return nil
+	}
+
+	name, assignments := positional[0], positional[1:]
+
+	if *remove {
+		if len(assignments) > 0 {
+			return usageError("unexpected variables with --delete")
+		}
+
+		env, err := s.FindEnvironment(name)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, name)
+		}
+
+		return s.DeleteEnvironment(env.ID)
+	}
+
+	if len(assignments) == 0 {
+		env, err := s.FindEnvironment(name)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, name)
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(env.Variables)) {
+			fmt.Fprintf(stdout, "%s=%s\n", key, env.Variables[key])
+		}
+		return nil
+	}
+
+	vars := map[string]string{}
+	if env, err := s.FindEnvironment(name); err == nil {
+		vars = env.Variables
+		name = env.Name
+	}
+
+	for _, assignment := range assignments {
+		key, value, ok := strings.Cut(assignment, "=")
+		if !ok || key == "" {
+			return usageError("expected KEY=VALUE, got %q", assignment)
+		}
+
+		if vars == nil {
+			vars = map[string]string{}
+		}
+		vars[key] = value
+	}
+
+	_, err = s.SaveEnvironment(name, vars)
+	return err
+}
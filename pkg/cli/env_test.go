@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunEnv(t *testing.T) {
+	t.Run("should create, update, show and list environments", func(t *testing.T) {
+		setupTestStorage(t)
+
+		var stdout, stderr bytes.Buffer
+		require.NoError(t, runEnv([]string{"staging", "host=staging.local", "token=a=b"}, &stdout, &stderr))
+		require.NoError(t, runEnv([]string{"Staging", "user=bob"}, &stdout, &stderr))
+
+		stdout.Reset()
+		require.NoError(t, runEnv([]string{"staging"}, &stdout, &stderr))
+		assert.Equal(t, "host=staging.local\ntoken=a=b\nuser=bob\n", stdout.String())
+
+		stdout.Reset()
+		require.NoError(t, runEnv(nil, &stdout, &stderr))
+		assert.Equal(t, "staging (3 variables)\n", stdout.String())
+	})
+
+	t.Run("should delete an environment", func(t *testing.T) {
+		setupTestStorage(t)
+
+		var stdout, stderr bytes.Buffer
+		require.NoError(t, runEnv([]string{"dev", "a=1"}, &stdout, &stderr))
+		require.NoError(t, runEnv([]string{"--delete", "dev"}, &stdout, &stderr))
+
+		err := runEnv([]string{"dev"}, &stdout, &stderr)
+		assert.ErrorIs(t, err, storage.ErrEnvironmentNotFound)
+	})
+
+	t.Run("should reject invalid assignments", func(t *testing.T) {
+		setupTestStorage(t)
+
+		var stdout, stderr bytes.Buffer
+		err := runEnv([]string{"dev", "novalue"}, &stdout, &stderr)
+
+		assert.ErrorIs(t, err, ErrUsage)
+	})
+}
@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/har"
+	"github.com/Yalaouf/gostman/pkg/httpfile"
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/Yalaouf/gostman/pkg/tui"
+)
+
+func runImport(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("import", stderr)
+	name := fs.String("name", "", "collection name (defaults to the file name)")
+	format := fs.String("format", "", "input format, guessed from the extension by default")
+	dedupe := fs.Bool("dedupe", false, "skip HAR entries with an already seen method and URL")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return usageError("expected exactly one file")
+	}
+
+	path := positional[0]
+
+	vars, requests, err := readRequests(path, fileFormat(path, *format), *dedupe)
+	if err != nil {
+		return err
+	}
+
+	if *name == "" {
+		*name = collectionName(path)
+	}
+
+	s, err := storage.New()
+	if err != nil {
+		return err
+	}
+
+	c, err := s.ImportCollection(*name, vars, requests)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Imported %d requests into collection %q\n", len(requests), c.Name)
+	return nil
+}
+
+func collectionName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+func readRequests(
+	path, format string,
+	dedupe bool,
+) (map[string]string, []*storage.Request, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	switch format {
+	case "har":
+		h, err := har.Decode(f)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, har.ToRequests(h, dedupe), nil
+	case "http", "rest":
+		file, err := httpfile.Parse(f)
+		if err != nil {
+			return nil, nil, err
+		}
+		return file.Variables, file.Requests, nil
+	}
+
+	return nil, nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+}
+
+func runExport(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("export", stderr)
+	format := fs.String("format", "", "output format, guessed from the output file by default (har, http)")
+	collection := fs.String("collection", "", "name or ID of the collection to export")
+	history := fs.Bool("history", false, "export the request history instead of a collection")
+	output := fs.String("o", "", "output file (defaults to stdout)")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 0 {
+		return usageError("unexpected argument %q", positional[0])
+	}
+
+	if (*collection == "") == !*history {
+		return usageError("expected either --collection or --history")
+	}
+
+	outFormat := fileFormat(*output, *format)
+	if outFormat == "" {
+		outFormat = "har"
+	}
+
+	if outFormat != "har" && outFormat != "http" && outFormat != "rest" {
+		return fmt.Errorf("%w: %q", ErrUnsupportedFormat, outFormat)
+	}
+
+	if *history && outFormat != "har" {
+		return fmt.Errorf("%w: history can only be exported as har", ErrUnsupportedFormat)
+	}
+
+	s, err := storage.New()
+	if err != nil {
+		return err
+	}
+
+	var write func(io.Writer) error
+
+	if *history {
+		entries := s.ListHistory()
+		write = func(w io.Writer) error {
+			return har.Encode(w, har.FromHistory(entries))
+		}
+	} else {
+		c, err := s.FindCollection(*collection)
+		if err != nil {
+			return err
+		}
+
+		requests := s.ListRequestsByCollection(c.ID)
+		write = func(w io.Writer) error {
+			if outFormat == "har" {
+				return har.Encode(w, har.FromRequests(requests))
+			}
+			return httpfile.Write(w, c.Variables, requests)
+		}
+	}
+
+	if *output == "" {
+		return write(stdout)
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func runOpen(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("open", stderr)
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return usageError("expected exactly one file")
+	}
+
+	path := positional[0]
+
+	vars, requests, err := readRequests(path, fileFormat(path, ""), false)
+	if err != nil {
+		return err
+	}
+
+	s, err := storage.New()
+	if err != nil {
+		return err
+	}
+
+	c, err := s.SyncCollection(collectionName(path), vars, requests)
+	if err != nil {
+		return err
+	}
+
+	return tui.Open(s, c.ID)
+}
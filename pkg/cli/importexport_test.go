@@ -0,0 +1,190 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/har"
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleHAR = `{
+  "log": {
+    "version": "1.2",
+    "creator": {"name": "test", "version": "1"},
+    "entries": [
+      {"request": {"method": "GET", "url": "http://localhost/a"}, "response": {"status": 200}},
+      {"request": {"method": "GET", "url": "http://localhost/a"}, "response": {"status": 200}},
+      {"request": {"method": "DELETE", "url": "http://localhost/b"}, "response": {"status": 204}}
+    ]
+  }
+}`
+
+func setupTestStorage(t *testing.T) *storage.Storage {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	s, err := storage.New()
+	require.NoError(t, err)
+
+	return s
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestRunImport(t *testing.T) {
+	t.Run("should import a HAR file into a collection named after the file", func(t *testing.T) {
+		setupTestStorage(t)
+		path := writeFile(t, "capture.har", sampleHAR)
+		var stdout bytes.Buffer
+
+		err := runImport([]string{path}, &stdout, &bytes.Buffer{})
+		require.NoError(t, err)
+
+		s, err := storage.New()
+		require.NoError(t, err)
+
+		c, err := s.FindCollection("capture")
+		require.NoError(t, err)
+		assert.Len(t, s.ListRequestsByCollection(c.ID), 3)
+		assert.Contains(t, stdout.String(), `Imported 3 requests into collection "capture"`)
+	})
+
+	t.Run("should dedupe and use the given name", func(t *testing.T) {
+		setupTestStorage(t)
+		path := writeFile(t, "capture.har", sampleHAR)
+
+		err := runImport([]string{path, "--dedupe", "--name", "Smoke"}, &bytes.Buffer{}, &bytes.Buffer{})
+		require.NoError(t, err)
+
+		s, err := storage.New()
+		require.NoError(t, err)
+
+		c, err := s.FindCollection("Smoke")
+		require.NoError(t, err)
+		assert.Len(t, s.ListRequestsByCollection(c.ID), 2)
+	})
+
+	t.Run("should import a .http file with its variables", func(t *testing.T) {
+		setupTestStorage(t)
+		path := writeFile(t, "api.http", "@host = http://localhost\n\n### Ping\nGET {{host}}/ping\n")
+
+		err := runImport([]string{path}, &bytes.Buffer{}, &bytes.Buffer{})
+		require.NoError(t, err)
+
+		s, err := storage.New()
+		require.NoError(t, err)
+
+		c, err := s.FindCollection("api")
+		require.NoError(t, err)
+		assert.Equal(t, "http://localhost", c.Variables["host"])
+
+		requests := s.ListRequestsByCollection(c.ID)
+		require.Len(t, requests, 1)
+		assert.Equal(t, "Ping", requests[0].Name)
+	})
+
+	t.Run("should return ErrUnsupportedFormat on unknown extension", func(t *testing.T) {
+		setupTestStorage(t)
+		path := writeFile(t, "capture.txt", sampleHAR)
+
+		err := runImport([]string{path}, &bytes.Buffer{}, &bytes.Buffer{})
+
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+}
+
+func TestRunExport(t *testing.T) {
+	t.Run("should export a collection to a file", func(t *testing.T) {
+		s := setupTestStorage(t)
+		_, err := s.ImportCollection("API", nil, []*storage.Request{
+			{Name: "List", Method: "GET", URL: "http://localhost/items"},
+		})
+		require.NoError(t, err)
+
+		output := filepath.Join(t.TempDir(), "api.har")
+
+		err = runExport([]string{"--collection", "api", "-o", output}, &bytes.Buffer{}, &bytes.Buffer{})
+		require.NoError(t, err)
+
+		f, err := os.Open(output)
+		require.NoError(t, err)
+		defer f.Close()
+
+		h, err := har.Decode(f)
+		require.NoError(t, err)
+		require.Len(t, h.Log.Entries, 1)
+		assert.Equal(t, "http://localhost/items", h.Log.Entries[0].Request.URL)
+	})
+
+	t.Run("should export the history to stdout", func(t *testing.T) {
+		s := setupTestStorage(t)
+		require.NoError(t, s.AddHistory(&storage.HistoryEntry{
+			Request:  &storage.Request{Method: "GET", URL: "http://localhost"},
+			Response: &storage.HistoryResponse{StatusCode: 200, Body: "ok", TimeTaken: 7},
+		}))
+		var stdout bytes.Buffer
+
+		err := runExport([]string{"--history"}, &stdout, &bytes.Buffer{})
+		require.NoError(t, err)
+
+		h, err := har.Decode(&stdout)
+		require.NoError(t, err)
+		require.Len(t, h.Log.Entries, 1)
+		assert.Equal(t, "ok", h.Log.Entries[0].Response.Content.Text)
+		assert.Equal(t, 7.0, h.Log.Entries[0].Time)
+	})
+
+	t.Run("should export a collection as a .http file", func(t *testing.T) {
+		s := setupTestStorage(t)
+		_, err := s.ImportCollection("API", map[string]string{"host": "http://localhost"}, []*storage.Request{
+			{Name: "List", Method: "GET", URL: "{{host}}/items"},
+		})
+		require.NoError(t, err)
+		var stdout bytes.Buffer
+
+		err = runExport([]string{"--collection", "API", "--format", "http"}, &stdout, &bytes.Buffer{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "@host = http://localhost\n\n### List\nGET {{host}}/items\n", stdout.String())
+	})
+
+	t.Run("should only export the history as HAR", func(t *testing.T) {
+		setupTestStorage(t)
+
+		err := runExport([]string{"--history", "-o", "history.http"}, &bytes.Buffer{}, &bytes.Buffer{})
+
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+
+	t.Run("should require exactly one source", func(t *testing.T) {
+		setupTestStorage(t)
+
+		err := runExport([]string{"--history", "--collection", "api"}, &bytes.Buffer{}, &bytes.Buffer{})
+		assert.ErrorIs(t, err, ErrUsage)
+
+		err = runExport(nil, &bytes.Buffer{}, &bytes.Buffer{})
+		assert.ErrorIs(t, err, ErrUsage)
+	})
+
+	t.Run("should return ErrCollectionNotFound on unknown collection", func(t *testing.T) {
+		setupTestStorage(t)
+
+		output := writeFile(t, "keep.har", "previous export")
+
+		err := runExport([]string{"--collection", "missing", "-o", output}, &bytes.Buffer{}, &bytes.Buffer{})
+
+		assert.ErrorIs(t, err, storage.ErrCollectionNotFound)
+
+		data, err := os.ReadFile(output)
+		require.NoError(t, err)
+		assert.Equal(t, "previous export", string(data))
+	})
+}
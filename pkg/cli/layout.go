@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+func runLayout(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("layout", stderr)
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) > 1 {
+		return usageError("expected at most one layout")
+	}
+
+	s, err := storage.New()
+	if err != nil {
+		return err
+	}
+
+	if len(positional) == 0 {
+		fmt.Fprintln(stdout, s.Layout())
+		return nil
+	}
+
+	layout, err := storage.ParseLayout(positional[0])
+	if err != nil {
+		return usageError("%v: %s", err, positional[0])
+	}
+
+	if err := s.SetLayout(layout); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Storage layout set to %s\n", layout)
+	return nil
+}
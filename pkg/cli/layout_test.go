@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunLayout(t *testing.T) {
+	t.Run("should print the current layout", func(t *testing.T) {
+		setupTestStorage(t)
+
+		var stdout, stderr bytes.Buffer
+		err := runLayout(nil, &stdout, &stderr)
+
+		require.NoError(t, err)
+		assert.Equal(t, "json\n", stdout.String())
+	})
+
+	t.Run("should switch the layout", func(t *testing.T) {
+		setupTestStorage(t)
+
+		var stdout, stderr bytes.Buffer
+		err := runLayout([]string{"directory"}, &stdout, &stderr)
+		require.NoError(t, err)
+
+		s, err := storage.New()
+		require.NoError(t, err)
+		assert.Equal(t, storage.LayoutDirectory, s.Layout())
+	})
+
+	t.Run("should return a usage error for unknown layouts", func(t *testing.T) {
+		setupTestStorage(t)
+
+		var stdout, stderr bytes.Buffer
+		err := runLayout([]string{"toml"}, &stdout, &stderr)
+
+		assert.ErrorIs(t, err, ErrUsage)
+	})
+}
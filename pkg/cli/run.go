@@ -0,0 +1,241 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Yalaouf/gostman/pkg/assertion"
+	"github.com/Yalaouf/gostman/pkg/report"
+	"github.com/Yalaouf/gostman/pkg/runner"
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+)
+
+func runRun(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("run", stderr)
+	envName := fs.String("env", "", "environment whose variables are used")
+	bail := fs.Bool("bail", false, "stop after the first failed request")
+	delay := fs.Duration("delay", 0, "delay between requests, e.g. 500ms")
+	timeout := fs.Int64("timeout", 0, "request timeout in milliseconds")
+	data := fs.String("data", "", "CSV or JSON `FILE` with one row of variables per iteration")
+	junit := fs.String("junit", "", "write a JUnit XML report to `FILE`")
+	jsonReport := fs.String("json", "", "write a JSON report to `FILE`")
+	tap := fs.String("tap", "", "write a TAP report to `FILE`")
+
+	var secrets stringList
+	fs.Var(&secrets, "secret", "variable `NAME` whose value is redacted from reports (repeatable)")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return usageError("expected exactly one collection")
+	}
+
+	s, err := storage.New()
+	if err != nil {
+		return err
+	}
+
+	collection, err := s.FindCollection(positional[0])
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, positional[0])
+	}
+
+	opts := runner.Options{
+		StopOnFailure: *bail,
+		Delay:         *delay,
+		Timeout:       *timeout,
+		MaxBodySize:   s.MaxBodySize(),
+	}
+
+	if *envName != "" {
+		opts.Environment, err = s.FindEnvironment(*envName)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, *envName)
+		}
+	}
+
+	if *data != "" {
+		opts.Data, err = runner.LoadData(*data)
+		if err != nil {
+			return err
+		}
+	}
+
+	requests := s.ListRequestsByCollection(collection.ID)
+	total := len(requests) * max(len(opts.Data), 1)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	summary := runner.Run(ctx, collection, requests, opts, nil)
+
+	printSummary(stdout, summary)
+
+	reports := map[report.Format]string{
+		report.FormatJUnit: *junit,
+		report.FormatJSON:  *jsonReport,
+		report.FormatTAP:   *tap,
+	}
+
+	if err := writeReports(summary, reports, secrets); err != nil {
+		return err
+	}
+
+	if !summary.OK() || len(summary.Results) < total {
+		return fmt.Errorf(
+			"%w: %d of %d requests failed",
+			ErrRunFailed,
+			summary.Failed()+total-len(summary.Results),
+			total,
+		)
+	}
+
+	return nil
+}
+
+func writeReports(summary *runner.Summary, paths map[report.Format]string, secrets []string) error {
+	r := report.New(summary, report.SummarySecrets(summary, secrets))
+
+	for _, format := range []report.Format{report.FormatJUnit, report.FormatJSON, report.FormatTAP} {
+		path := paths[format]
+		if path == "" {
+			continue
+		}
+
+		if err := writeReport(path, format, r); err != nil {
+			return fmt.Errorf("writing %s report: %w", format, err)
+		}
+	}
+
+	return nil
+}
+
+func writeReport(path string, format report.Format, r *report.Report) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := report.Write(f, format, r); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func printSummary(w io.Writer, summary *runner.Summary) {
+	r := lipgloss.NewRenderer(w)
+
+	pass := r.NewStyle().Foreground(style.ColorGreen)
+	fail := r.NewStyle().Foreground(style.ColorRed)
+	muted := r.NewStyle().Foreground(style.ColorGray)
+
+	headers := []string{"#", "NAME", "METHOD", "URL", "STATUS", "TIME", "TESTS", "RESULT"}
+	iterations := summary.Iterations > 1
+	if iterations {
+		headers = slices.Insert(headers, 1, "ITER")
+	}
+
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(muted).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return r.NewStyle().Bold(true).Padding(0, 1)
+			}
+			return r.NewStyle().Padding(0, 1)
+		}).
+		Headers(headers...)
+
+	var failures, logs []string
+
+	for i, result := range summary.Results {
+		status, duration := "ERR", "-"
+		if result.Response != nil {
+			status = strconv.Itoa(result.Response.StatusCode)
+			duration = fmt.Sprintf("%dms", result.Response.TimeTaken)
+		}
+
+		verdict := pass.Render("PASS")
+		statusStyle := pass
+		if result.Failed() {
+			verdict = fail.Render("FAIL")
+			statusStyle = fail
+		}
+
+		name := result.Request.Name
+		if iterations {
+			name = fmt.Sprintf("%s (iteration %d)", name, result.Iteration)
+		}
+
+		if result.Err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", name, result.Err))
+		}
+
+		for _, line := range result.Logs {
+			logs = append(logs, name+" "+line)
+		}
+
+		tests := "-"
+		if len(result.Assertions) > 0 {
+			tests = fmt.Sprintf("%d/%d", assertion.Count(result.Assertions), len(result.Assertions))
+		}
+
+		for _, a := range result.Assertions {
+			if !a.Passed {
+				failures = append(failures, fmt.Sprintf(
+					"%s: %s: %s", name, assertion.Format(a.Assertion), a.Message,
+				))
+			}
+		}
+
+		row := []string{
+			strconv.Itoa(i + 1),
+			result.Request.Name,
+			result.Request.Method,
+			result.URL,
+			statusStyle.Render(status),
+			duration,
+			tests,
+			verdict,
+		}
+		if iterations {
+			row = slices.Insert(row, 1, strconv.Itoa(result.Iteration))
+		}
+
+		t.Row(row...)
+	}
+
+	fmt.Fprintf(w, "%s\n", r.NewStyle().Bold(true).Render(summary.Collection.Name))
+	fmt.Fprintln(w, t.Render())
+
+	for _, line := range logs {
+		fmt.Fprintln(w, muted.Render(line))
+	}
+
+	for _, failure := range failures {
+		fmt.Fprintln(w, fail.Render("✗ "+failure))
+	}
+
+	counts := fmt.Sprintf("%d passed, %d failed", summary.Passed(), summary.Failed())
+	if summary.OK() {
+		counts = pass.Render(counts)
+	} else {
+		counts = fail.Render(counts)
+	}
+
+	fmt.Fprintf(w, "%s %s\n", counts, muted.Render("in "+summary.Duration.Round(time.Millisecond).String()))
+}
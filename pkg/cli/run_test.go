@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/runner"
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRunCollection(t *testing.T, paths ...string) *storage.Storage {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(r.Header.Get("X-Env")))
+	}))
+	t.Cleanup(server.Close)
+
+	s := setupTestStorage(t)
+
+	var requests []*storage.Request
+	for _, path := range paths {
+		requests = append(requests, &storage.Request{
+			Name:    "GET " + path,
+			Method:  "GET",
+			URL:     "{{host}}" + path,
+			Headers: storage.Headers{{Key: "X-Env", Value: "{{env}}"}},
+		})
+	}
+
+	_, err := s.ImportCollection("Smoke", map[string]string{"host": server.URL}, requests)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestRunRun(t *testing.T) {
+	t.Run("should run a collection and print a summary", func(t *testing.T) {
+		s := setupRunCollection(t, "/a", "/b")
+		_, err := s.SaveEnvironment("ci", map[string]string{"env": "ci"})
+		require.NoError(t, err)
+
+		var stdout, stderr bytes.Buffer
+		err = runRun([]string{"smoke", "--env", "ci"}, &stdout, &stderr)
+		require.NoError(t, err)
+
+		out := stdout.String()
+		assert.Contains(t, out, "Smoke")
+		assert.Contains(t, out, "GET /a")
+		assert.Contains(t, out, "GET /b")
+		assert.Contains(t, out, "PASS")
+		assert.Contains(t, out, "2 passed, 0 failed")
+	})
+
+	t.Run("should fail when a request fails", func(t *testing.T) {
+		setupRunCollection(t, "/a", "/fail", "/b")
+
+		var stdout, stderr bytes.Buffer
+		err := runRun([]string{"Smoke"}, &stdout, &stderr)
+
+		assert.ErrorIs(t, err, ErrRunFailed)
+		assert.ErrorContains(t, err, "1 of 3 requests failed")
+		assert.Contains(t, stdout.String(), "503")
+		assert.Contains(t, stdout.String(), "FAIL")
+	})
+
+	t.Run("should report assertion results", func(t *testing.T) {
+		s := setupRunCollection(t, "/a")
+
+		collection, err := s.FindCollection("Smoke")
+		require.NoError(t, err)
+
+		req := s.ListRequestsByCollection(collection.ID)[0]
+		req.Assertions = []storage.Assertion{
+			{Type: "status", Operator: "equals", Value: "200"},
+			{Type: "header", Target: "X-Missing", Operator: "exists"},
+		}
+		require.NoError(t, s.SaveRequest(req))
+
+		var stdout, stderr bytes.Buffer
+		err = runRun([]string{"Smoke"}, &stdout, &stderr)
+
+		assert.ErrorIs(t, err, ErrRunFailed)
+		assert.Contains(t, stdout.String(), "1/2")
+		assert.Contains(t, stdout.String(), "header X-Missing exists: header X-Missing is missing")
+	})
+
+	t.Run("should count skipped requests as failed when bailing", func(t *testing.T) {
+		setupRunCollection(t, "/fail", "/a", "/b")
+
+		var stdout, stderr bytes.Buffer
+		err := runRun([]string{"--bail", "Smoke"}, &stdout, &stderr)
+
+		assert.ErrorContains(t, err, "3 of 3 requests failed")
+		assert.NotContains(t, stdout.String(), "GET /a")
+	})
+
+	t.Run("should write redacted reports", func(t *testing.T) {
+		setupRunCollection(t, "/a", "/fail")
+		dir := t.TempDir()
+		junit := filepath.Join(dir, "report.xml")
+		jsonReport := filepath.Join(dir, "report.json")
+		tap := filepath.Join(dir, "report.tap")
+
+		var stdout, stderr bytes.Buffer
+		err := runRun([]string{
+			"Smoke", "--junit", junit, "--json", jsonReport, "--tap", tap, "--secret", "host",
+		}, &stdout, &stderr)
+		assert.ErrorIs(t, err, ErrRunFailed)
+
+		data, err := os.ReadFile(junit)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `<testsuite name="Smoke" tests="2" failures="1"`)
+		assert.Contains(t, string(data), "[REDACTED]/fail")
+		assert.NotContains(t, string(data), "127.0.0.1")
+
+		data, err = os.ReadFile(jsonReport)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"url": "[REDACTED]/a"`)
+
+		data, err = os.ReadFile(tap)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "not ok 2 - GET /fail")
+	})
+
+	t.Run("should iterate over data rows", func(t *testing.T) {
+		s := setupRunCollection(t, "/a", "/b")
+		_, err := s.SaveEnvironment("ci", map[string]string{"env": "ci"})
+		require.NoError(t, err)
+
+		data := filepath.Join(t.TempDir(), "rows.csv")
+		require.NoError(t, os.WriteFile(data, []byte("env\nfirst\nsecond\nthird\n"), 0o644))
+
+		var stdout, stderr bytes.Buffer
+		err = runRun([]string{"Smoke", "--env", "ci", "--data", data}, &stdout, &stderr)
+		require.NoError(t, err)
+
+		out := stdout.String()
+		assert.Contains(t, out, "ITER")
+		assert.Contains(t, out, "6 passed, 0 failed")
+	})
+
+	t.Run("should count every iteration when bailing", func(t *testing.T) {
+		setupRunCollection(t, "/fail", "/a")
+
+		data := filepath.Join(t.TempDir(), "rows.json")
+		require.NoError(t, os.WriteFile(data, []byte(`[{"env": "1"}, {"env": "2"}]`), 0o644))
+
+		var stdout, stderr bytes.Buffer
+		err := runRun([]string{"Smoke", "--bail", "--data", data}, &stdout, &stderr)
+
+		assert.ErrorContains(t, err, "4 of 4 requests failed")
+	})
+
+	t.Run("should reject invalid data files", func(t *testing.T) {
+		setupRunCollection(t, "/a")
+
+		data := filepath.Join(t.TempDir(), "rows.txt")
+		require.NoError(t, os.WriteFile(data, []byte("env"), 0o644))
+
+		var stdout, stderr bytes.Buffer
+		err := runRun([]string{"Smoke", "--data", data}, &stdout, &stderr)
+
+		assert.ErrorIs(t, err, runner.ErrInvalidData)
+	})
+
+	t.Run("should fail on unknown collections and environments", func(t *testing.T) {
+		setupRunCollection(t, "/a")
+
+		var stdout, stderr bytes.Buffer
+
+		err := runRun([]string{"missing"}, &stdout, &stderr)
+		assert.ErrorIs(t, err, storage.ErrCollectionNotFound)
+
+		err = runRun([]string{"--env", "missing", "Smoke"}, &stdout, &stderr)
+		assert.ErrorIs(t, err, storage.ErrEnvironmentNotFound)
+	})
+
+	t.Run("should require a collection", func(t *testing.T) {
+		setupTestStorage(t)
+
+		var stdout, stderr bytes.Buffer
+		err := runRun(nil, &stdout, &stderr)
+
+		assert.ErrorIs(t, err, ErrUsage)
+	})
+}
@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/assertion"
+	"github.com/Yalaouf/gostman/pkg/extraction"
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/runner"
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+const (
+	outputBody    = "body"
+	outputHeaders = "headers"
+	outputStatus  = "status"
+	outputJSON    = "json"
+)
+
+type envelope struct {
+	Method     string              `json:"method"`
+	URL        string              `json:"url"`
+	Status     int                 `json:"status"`
+	StatusText string              `json:"status_text"`
+	Headers    map[string][]string `json:"headers"`
+	Body       string              `json:"body"`
+	Size       int64               `json:"size"`
+	Truncated  bool                `json:"truncated,omitempty"`
+	TimeTaken  int64               `json:"time_ms"`
+	Tests      []envelopeTest      `json:"tests,omitempty"`
+	Console    []string            `json:"console,omitempty"`
+}
+
+type envelopeTest struct {
+	Assertion string `json:"assertion"`
+	Passed    bool   `json:"passed"`
+	Message   string `json:"message,omitempty"`
+}
+
+func runSend(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("send", stderr)
+	envName := fs.String("env", "", "environment whose variables are used")
+	output := fs.String("output", outputBody, "what to print: body, headers (headers and body), status or json")
+	timeout := fs.Int64("timeout", 0, "request timeout in milliseconds")
+	maxBody := fs.Int64("max-body", 0, "keep at most `BYTES` of the body in memory for tests and scripts")
+
+	var overrides stringList
+	fs.Var(&overrides, "var", "override a variable with `KEY=VALUE` (repeatable)")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return usageError("expected exactly one request")
+	}
+
+	if !slices.Contains([]string{outputBody, outputHeaders, outputStatus, outputJSON}, *output) {
+		return usageError("unknown output %q", *output)
+	}
+
+	vars := map[string]string{}
+	for _, assignment := range overrides {
+		key, value, ok := strings.Cut(assignment, "=")
+		if !ok || key == "" {
+			return usageError("expected KEY=VALUE, got %q", assignment)
+		}
+		vars[key] = value
+	}
+
+	s, err := storage.New()
+	if err != nil {
+		return err
+	}
+
+	req, err := s.FindRequest(positional[0])
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, positional[0])
+	}
+
+	var environment *storage.Environment
+	if *envName != "" {
+		environment, err = s.FindEnvironment(*envName)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, *envName)
+		}
+	}
+
+	collection, _ := s.GetCollection(req.CollectionID)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	model, sc, err := runner.Prepare(collection, req, runner.Variables(collection, environment, vars))
+	if err != nil {
+		return err
+	}
+
+	model.SetContext(ctx)
+	model.SetTimeout(*timeout)
+	model.SetMaxBodySize(*maxBody)
+	if *maxBody <= 0 {
+		model.SetMaxBodySize(s.MaxBodySize())
+	}
+
+	res, err := request.SendRequest(model)
+	if err != nil {
+		return err
+	}
+	defer res.Remove()
+
+	scriptErr := runner.Finish(collection, req, sc, res)
+	tests := assertion.Evaluate(req.Assertions, res)
+
+	var results []extraction.Result
+	if scriptErr == nil && res.StatusCode < 400 && assertion.Passed(tests) {
+		results = extraction.Extract(req.Extractions, res)
+	}
+
+	for _, scope := range runner.Scopes {
+		vars := runner.ScriptVariables(sc.Updates, scope)
+		maps.Copy(vars, extraction.Variables(results, scope))
+
+		if _, err := runner.SaveVariables(s, collection, environment, scope, vars); err != nil {
+			return err
+		}
+	}
+
+	if err := printResponse(stdout, *output, model, res, tests, sc.Logs); err != nil {
+		return err
+	}
+
+	if scriptErr != nil {
+		return scriptErr
+	}
+
+	if code := statusExitCode(res.StatusCode); code != 0 {
+		return &exitError{code: code, err: fmt.Errorf("%w: %d %s", ErrStatus, res.StatusCode, http.StatusText(res.StatusCode))}
+	}
+
+	return nil
+}
+
+func printResponse(
+	w io.Writer,
+	output string,
+	model *request.Model,
+	res *request.Response,
+	tests []assertion.Result,
+	console []string,
+) error {
+	switch output {
+	case outputStatus:
+		_, err := fmt.Fprintln(w, res.StatusCode)
+		return err
+	case outputHeaders:
+		fmt.Fprintf(w, "%d %s\n", res.StatusCode, http.StatusText(res.StatusCode))
+		for _, key := range slices.Sorted(maps.Keys(res.Headers)) {
+			for _, value := range res.Headers[key] {
+				fmt.Fprintf(w, "%s: %s\n", key, value)
+			}
+		}
+		fmt.Fprintln(w)
+	case outputJSON:
+		env := envelope{
+			Method:     model.MethodString(),
+			URL:        model.URL,
+			Status:     res.StatusCode,
+			StatusText: http.StatusText(res.StatusCode),
+			Headers:    res.Headers,
+			Body:       res.Body,
+			Size:       res.Size,
+			Truncated:  res.Truncated(),
+			TimeTaken:  res.TimeTaken,
+			Console:    console,
+		}
+
+		for _, t := range tests {
+			env.Tests = append(env.Tests, envelopeTest{
+				Assertion: assertion.Format(t.Assertion),
+				Passed:    t.Passed,
+				Message:   t.Message,
+			})
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(env)
+	}
+
+	body, err := res.Open()
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	_, err = io.Copy(w, body)
+	return err
+}
+
+func statusExitCode(status int) int {
+	switch {
+	case status >= 500:
+		return 5
+	case status >= 400:
+		return 4
+	case status >= 300:
+		return 3
+	}
+
+	return 0
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSend(t *testing.T) {
+	t.Run("should print the body using environment and overridden variables", func(t *testing.T) {
+		s := setupRunCollection(t, "/a")
+		_, err := s.SaveEnvironment("ci", map[string]string{"env": "ci"})
+		require.NoError(t, err)
+
+		var stdout, stderr bytes.Buffer
+		require.NoError(t, runSend([]string{"Smoke/GET /a", "--env", "ci"}, &stdout, &stderr))
+		assert.Equal(t, "ci", stdout.String())
+
+		stdout.Reset()
+		require.NoError(t, runSend([]string{"--var", "env=local", "--env", "ci", "GET /a"}, &stdout, &stderr))
+		assert.Equal(t, "local", stdout.String())
+	})
+
+	t.Run("should print headers, status or a JSON envelope", func(t *testing.T) {
+		setupRunCollection(t, "/a")
+
+		var stdout, stderr bytes.Buffer
+		require.NoError(t, runSend([]string{"GET /a", "--var", "env=x", "--output", "headers"}, &stdout, &stderr))
+		assert.Regexp(t, `^200 OK\nContent-Length: 1\nContent-Type: text/plain; charset=utf-8\nDate: .+\n\nx$`, stdout.String())
+
+		stdout.Reset()
+		require.NoError(t, runSend([]string{"GET /a", "--output", "status"}, &stdout, &stderr))
+		assert.Equal(t, "200\n", stdout.String())
+
+		stdout.Reset()
+		require.NoError(t, runSend([]string{"GET /a", "--var", "env=x", "--output", "json"}, &stdout, &stderr))
+
+		var env envelope
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &env))
+		assert.Equal(t, "GET", env.Method)
+		assert.Equal(t, 200, env.Status)
+		assert.Equal(t, "OK", env.StatusText)
+		assert.Equal(t, "x", env.Body)
+		assert.Contains(t, env.URL, "/a")
+	})
+
+	t.Run("should print the full body when it exceeds --max-body", func(t *testing.T) {
+		setupRunCollection(t, "/a")
+
+		var stdout, stderr bytes.Buffer
+		require.NoError(t, runSend([]string{"GET /a", "--var", "env=abcdefghij", "--max-body", "4"}, &stdout, &stderr))
+		assert.Equal(t, "abcdefghij", stdout.String())
+
+		stdout.Reset()
+		require.NoError(t, runSend([]string{"GET /a", "--var", "env=abcdefghij", "--max-body", "4", "--output", "json"}, &stdout, &stderr))
+
+		var env envelope
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &env))
+		assert.Equal(t, "abcd", env.Body)
+		assert.Equal(t, int64(10), env.Size)
+		assert.True(t, env.Truncated)
+	})
+
+	t.Run("should map status classes to exit codes", func(t *testing.T) {
+		setupRunCollection(t, "/fail")
+
+		var stdout, stderr bytes.Buffer
+		code := Run([]string{"send", "--output", "status", "GET /fail"}, &stdout, &stderr)
+
+		assert.Equal(t, 5, code)
+		assert.Equal(t, "503\n", stdout.String())
+		assert.Contains(t, stderr.String(), "unsuccessful status: 503 Service Unavailable")
+
+		for status, expected := range map[int]int{200: 0, 204: 0, 301: 3, 404: 4, 500: 5} {
+			assert.Equal(t, expected, statusExitCode(status))
+		}
+	})
+
+	t.Run("should save extracted and scripted variables", func(t *testing.T) {
+		s := setupRunCollection(t, "/a")
+		env, err := s.SaveEnvironment("ci", map[string]string{"env": "token-1"})
+		require.NoError(t, err)
+
+		req, err := s.FindRequest("GET /a")
+		require.NoError(t, err)
+		req.Extractions = []storage.Extraction{{Source: "regex", Expression: `token-(\d+)`, Variable: "token"}}
+		req.Scripts.PostResponse = `vars.set("seen", response.body, scope = "collection")`
+		require.NoError(t, s.SaveRequest(req))
+
+		var stdout, stderr bytes.Buffer
+		require.NoError(t, runSend([]string{"GET /a", "--env", "ci"}, &stdout, &stderr))
+
+		s2, err := storage.New()
+		require.NoError(t, err)
+
+		env, err = s2.GetEnvironment(env.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "1", env.Variables["token"])
+
+		collection, err := s2.FindCollection("Smoke")
+		require.NoError(t, err)
+		assert.Equal(t, "token-1", collection.Variables["seen"])
+	})
+
+	t.Run("should reject bad arguments", func(t *testing.T) {
+		setupRunCollection(t, "/a")
+
+		var stdout, stderr bytes.Buffer
+
+		assert.ErrorIs(t, runSend(nil, &stdout, &stderr), ErrUsage)
+		assert.ErrorIs(t, runSend([]string{"GET /a", "--output", "xml"}, &stdout, &stderr), ErrUsage)
+		assert.ErrorIs(t, runSend([]string{"GET /a", "--var", "novalue"}, &stdout, &stderr), ErrUsage)
+		assert.ErrorIs(t, runSend([]string{"missing"}, &stdout, &stderr), storage.ErrRequestNotFound)
+	})
+}
@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	ErrUsage             = errors.New("invalid usage")
+	ErrUnsupportedFormat = errors.New("unsupported format")
+	ErrRunFailed         = errors.New("collection run failed")
+	ErrStatus            = errors.New("unsuccessful status")
+)
+
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) error
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+func runInit(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("init", stderr)
+	layoutName := fs.String("layout", string(storage.LayoutDirectory), "storage layout (json or directory)")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) > 1 {
+		return usageError("expected at most one directory")
+	}
+
+	root := "."
+	if len(positional) == 1 {
+		root = positional[0]
+	}
+
+	layout, err := storage.ParseLayout(*layoutName)
+	if err != nil {
+		return usageError("%v: %s", err, *layoutName)
+	}
+
+	dir, err := storage.InitWorkspace(root, layout)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Initialized gostman workspace in %s\n", dir)
+	return nil
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunInit(t *testing.T) {
+	t.Run("should create a workspace used by later commands", func(t *testing.T) {
+		setupTestStorage(t)
+		root := t.TempDir()
+
+		var stdout, stderr bytes.Buffer
+		err := runInit([]string{root}, &stdout, &stderr)
+		require.NoError(t, err)
+
+		assert.Contains(t, stdout.String(), filepath.Join(root, ".gostman"))
+
+		t.Chdir(root)
+
+		s, err := storage.New()
+		require.NoError(t, err)
+		assert.False(t, s.Workspace().Global)
+		assert.Equal(t, storage.LayoutDirectory, s.Layout())
+	})
+
+	t.Run("should fail when a workspace already exists", func(t *testing.T) {
+		setupTestStorage(t)
+		root := t.TempDir()
+
+		var stdout, stderr bytes.Buffer
+		require.NoError(t, runInit([]string{root}, &stdout, &stderr))
+
+		err := runInit([]string{root}, &stdout, &stderr)
+		assert.ErrorIs(t, err, storage.ErrWorkspaceExists)
+	})
+
+	t.Run("should return a usage error for unknown layouts", func(t *testing.T) {
+		setupTestStorage(t)
+
+		var stdout, stderr bytes.Buffer
+		err := runInit([]string{"--layout", "toml", t.TempDir()}, &stdout, &stderr)
+
+		assert.ErrorIs(t, err, ErrUsage)
+	})
+}
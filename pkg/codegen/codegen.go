@@ -0,0 +1,146 @@
+package codegen
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+)
+
+func Generate(model *request.Model, lang Language) (string, error) {
+	s, err := newSnippet(model)
+	if err != nil {
+		return "", err
+	}
+
+	switch lang {
+	case LanguageCurl:
+		return s.curl(), nil
+	case LanguageHTTPie:
+		return s.httpie(), nil
+	case LanguageGo:
+		return s.golang(), nil
+	case LanguagePython:
+		return s.python(), nil
+	case LanguageJavaScript:
+		return s.javascript(), nil
+	}
+
+	return "", fmt.Errorf("%w: %d", ErrUnknownLanguage, lang)
+}
+
+func newSnippet(model *request.Model) (*snippet, error) {
+	s := &snippet{
+		method:  model.MethodString(),
+		url:     model.URL,
+		version: model.HTTPVersion,
+	}
+
+	if s.method == "" {
+		s.method = http.MethodGet
+	}
+
+	if (model.Body == "" && len(model.Form) == 0) || model.BodyType == request.BodyTypeNone {
+		for _, h := range model.Headers {
+			s.headers = append(s.headers, header{key: h.Key, value: h.Value})
+		}
+		return s, nil
+	}
+
+	if model.BodyType == request.BodyTypeFormData {
+		fields, err := model.FormFields()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode body: %w", err)
+		}
+
+		s.form = true
+		s.fields = fields
+
+		for _, h := range model.Headers {
+			if strings.EqualFold(h.Key, "Content-Type") {
+				continue
+			}
+			s.headers = append(s.headers, header{key: h.Key, value: h.Value})
+		}
+
+		return s, nil
+	}
+
+	contentType, err := s.encodeBody(model)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, exists := model.HeaderValue("Content-Type"); contentType != "" && !exists {
+		s.headers = append(s.headers, header{key: "Content-Type", value: contentType})
+	}
+
+	for _, h := range model.Headers {
+		s.headers = append(s.headers, header{key: h.Key, value: h.Value})
+	}
+
+	return s, nil
+}
+
+func (s *snippet) encodeBody(model *request.Model) (string, error) {
+	if model.BodyType == request.BodyTypeBinary {
+		s.file = strings.TrimSpace(model.Body)
+		return model.BodyType.ContentType(), nil
+	}
+
+	bodyReader, contentType, err := model.EncodeBody()
+	if err != nil {
+		return "", fmt.Errorf("failed to encode body: %w", err)
+	}
+
+	if bodyReader != nil {
+		data, err := io.ReadAll(bodyReader)
+		if err != nil {
+			return "", err
+		}
+		s.body = string(data)
+	}
+
+	return contentType, nil
+}
+
+func (s *snippet) hasBody() bool {
+	return s.form || s.body != "" || s.file != ""
+}
+
+func (s *snippet) hasFiles() bool {
+	if s.file != "" {
+		return true
+	}
+
+	for _, f := range s.fields {
+		if f.File {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *snippet) mergedHeaders() []header {
+	merged := make([]header, 0, len(s.headers))
+
+	for _, h := range s.headers {
+		i := slices.IndexFunc(merged, func(m header) bool { return strings.EqualFold(m.key, h.key) })
+		if i < 0 {
+			merged = append(merged, h)
+			continue
+		}
+
+		separator := ", "
+		if strings.EqualFold(h.key, "Cookie") {
+			separator = "; "
+		}
+		merged[i].value += separator + h.value
+	}
+
+	return merged
+}
@@ -0,0 +1,156 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("should generate a snippet for every language", func(t *testing.T) {
+		req := request.NewModel().SetMethod(request.GET).SetURL("http://localhost")
+
+		for _, lang := range AllLanguages {
+			code, err := Generate(req, lang)
+
+			assert.NoError(t, err)
+			assert.Contains(t, code, "http://localhost")
+		}
+	})
+
+	t.Run("should return an error on unknown language", func(t *testing.T) {
+		req := request.NewModel().SetURL("http://localhost")
+
+		code, err := Generate(req, Language(42))
+
+		assert.Empty(t, code)
+		assert.ErrorIs(t, err, ErrUnknownLanguage)
+	})
+
+	t.Run("should return an error on invalid body", func(t *testing.T) {
+		req := request.NewModel().
+			SetURL("http://localhost").
+			SetBody("not a valid JSON").
+			SetBodyType(request.BodyTypeURLEncoded)
+
+		code, err := Generate(req, LanguageCurl)
+
+		assert.Empty(t, code)
+		assert.ErrorContains(t, err, "failed to encode body")
+	})
+}
+
+func TestNewSnippet(t *testing.T) {
+	t.Run("should default to GET and keep the header order", func(t *testing.T) {
+		req := request.NewModel().
+			SetURL("http://localhost").
+			AddHeader("X-B", "2").
+			AddHeader("X-A", "1").
+			AddHeader("X-B", "3")
+
+		s, err := newSnippet(req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "GET", s.method)
+		assert.Equal(t, []header{{"X-B", "2"}, {"X-A", "1"}, {"X-B", "3"}}, s.headers)
+		assert.False(t, s.hasBody())
+	})
+
+	t.Run("should add the content type from EncodeBody", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetBody(`{"a":"b c"}`).
+			SetBodyType(request.BodyTypeURLEncoded)
+
+		s, err := newSnippet(req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "a=b+c", s.body)
+		assert.Equal(t, []header{{"Content-Type", "application/x-www-form-urlencoded"}}, s.headers)
+	})
+
+	t.Run("should encode url-encoded form fields in order", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetForm([]request.FormField{{Key: "b", Value: "2"}, {Key: "a", Value: "1"}, {Key: "b", Value: "3"}}).
+			SetBodyType(request.BodyTypeURLEncoded)
+
+		s, err := newSnippet(req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "b=2&a=1&b=3", s.body)
+	})
+
+	t.Run("should keep an explicit content type", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetBody(`{}`).
+			SetBodyType(request.BodyTypeJSON).
+			AddHeader("Content-Type", "application/vnd.api+json")
+
+		s, err := newSnippet(req)
+
+		require.NoError(t, err)
+		assert.Equal(t, []header{{"Content-Type", "application/vnd.api+json"}}, s.headers)
+	})
+
+	t.Run("should reference binary bodies without reading them", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.PUT).
+			SetURL("http://localhost").
+			SetBody(" ./missing.bin\n").
+			SetBodyType(request.BodyTypeBinary)
+
+		s, err := newSnippet(req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "./missing.bin", s.file)
+		assert.Empty(t, s.body)
+		assert.Equal(t, []header{{"Content-Type", "application/octet-stream"}}, s.headers)
+	})
+
+	t.Run("should reject invalid XML", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetBody("<a>").
+			SetBodyType(request.BodyTypeXML)
+
+		_, err := newSnippet(req)
+
+		assert.ErrorIs(t, err, request.ErrInvalidXML)
+	})
+
+	t.Run("should ignore the body on BodyTypeNone", func(t *testing.T) {
+		req := request.NewModel().
+			SetURL("http://localhost").
+			SetBody("ignored").
+			SetBodyType(request.BodyTypeNone)
+
+		s, err := newSnippet(req)
+
+		require.NoError(t, err)
+		assert.False(t, s.hasBody())
+	})
+
+	t.Run("should split form-data into fields and drop the content type", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetBody(`{"name":"gostman"}`).
+			SetBodyType(request.BodyTypeFormData).
+			AddHeader("Content-Type", "multipart/form-data")
+
+		s, err := newSnippet(req)
+
+		require.NoError(t, err)
+		assert.True(t, s.form)
+		assert.Empty(t, s.headers)
+		assert.Equal(t, []request.FormField{{Key: "name", Value: "gostman"}}, s.fields)
+	})
+}
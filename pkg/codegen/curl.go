@@ -0,0 +1,64 @@
+package codegen
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+)
+
+func (s *snippet) curl() string {
+	var parts []string
+
+	switch {
+	case s.method == http.MethodHead:
+		parts = append(parts, "curl --head "+shellQuote(s.url))
+	case s.method == http.MethodGet && !s.hasBody():
+		parts = append(parts, "curl "+shellQuote(s.url))
+	default:
+		parts = append(parts, "curl -X "+s.method+" "+shellQuote(s.url))
+	}
+
+	switch s.version {
+	case request.HTTPVersion11:
+		parts = append(parts, "--http1.1")
+	case request.HTTPVersion2:
+		parts = append(parts, "--http2")
+	case request.HTTPVersionH2C:
+		parts = append(parts, "--http2-prior-knowledge")
+	}
+
+	for _, h := range s.headers {
+		parts = append(parts, "-H "+shellQuote(h.key+": "+h.value))
+	}
+
+	for _, f := range s.fields {
+		if f.File {
+			parts = append(parts, "-F "+shellQuote(curlFile(f)))
+			continue
+		}
+		parts = append(parts, "--form-string "+shellQuote(f.Key+"="+f.Value))
+	}
+
+	if s.body != "" {
+		parts = append(parts, "--data-raw "+shellQuote(s.body))
+	}
+
+	if s.file != "" {
+		parts = append(parts, "--data-binary "+shellQuote("@"+s.file))
+	}
+
+	return strings.Join(parts, " \\\n  ")
+}
+
+func curlFile(f request.FormField) string {
+	value := f.Key + "=@" + f.Value
+	if f.Filename != "" {
+		value += ";filename=" + f.Filename
+	}
+	if f.ContentType != "" {
+		value += ";type=" + f.ContentType
+	}
+
+	return value
+}
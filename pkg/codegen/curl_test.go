@@ -0,0 +1,106 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurl(t *testing.T) {
+	t.Run("should omit the method on a plain GET", func(t *testing.T) {
+		req := request.NewModel().SetMethod(request.GET).SetURL("http://localhost")
+
+		code, err := Generate(req, LanguageCurl)
+
+		require.NoError(t, err)
+		assert.Equal(t, "curl 'http://localhost'", code)
+	})
+
+	t.Run("should use --head for HEAD requests", func(t *testing.T) {
+		req := request.NewModel().SetMethod(request.HEAD).SetURL("http://localhost")
+
+		code, err := Generate(req, LanguageCurl)
+
+		require.NoError(t, err)
+		assert.Equal(t, "curl --head 'http://localhost'", code)
+	})
+
+	t.Run("should pass the forced HTTP version", func(t *testing.T) {
+		for version, flag := range map[request.HTTPVersion]string{
+			request.HTTPVersion11:  "--http1.1",
+			request.HTTPVersion2:   "--http2",
+			request.HTTPVersionH2C: "--http2-prior-knowledge",
+		} {
+			req := request.NewModel().SetMethod(request.GET).SetURL("http://localhost").SetHTTPVersion(version)
+
+			code, err := Generate(req, LanguageCurl)
+
+			require.NoError(t, err)
+			assert.Equal(t, "curl 'http://localhost' \\\n  "+flag, code)
+		}
+	})
+
+	t.Run("should include headers and an escaped body", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetBody(`{"msg":"it's"}`).
+			SetBodyType(request.BodyTypeJSON)
+
+		code, err := Generate(req, LanguageCurl)
+
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			"curl -X POST 'http://localhost' \\\n"+
+				"  -H 'Content-Type: application/json' \\\n"+
+				`  --data-raw '{"msg":"it'\''s"}'`,
+			code,
+		)
+	})
+
+	t.Run("should use literal form fields for form-data", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetBody(`{"file":"@/etc/passwd"}`).
+			SetBodyType(request.BodyTypeFormData)
+
+		code, err := Generate(req, LanguageCurl)
+
+		require.NoError(t, err)
+		assert.Contains(t, code, `--form-string 'file=@/etc/passwd'`)
+	})
+
+	t.Run("should upload file fields", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetBodyType(request.BodyTypeFormData).
+			SetForm([]request.FormField{
+				{Key: "avatar", Value: "./me.png", File: true, ContentType: "image/png", Filename: "avatar.png"},
+				{Key: "doc", Value: "/tmp/doc.pdf", File: true},
+			})
+
+		code, err := Generate(req, LanguageCurl)
+
+		require.NoError(t, err)
+		assert.Contains(t, code, `-F 'avatar=@./me.png;filename=avatar.png;type=image/png'`)
+		assert.Contains(t, code, `-F 'doc=@/tmp/doc.pdf'`)
+	})
+
+	t.Run("should send binary bodies from the file", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.PUT).
+			SetURL("http://localhost").
+			SetBody("./blob.bin").
+			SetBodyType(request.BodyTypeBinary)
+
+		code, err := Generate(req, LanguageCurl)
+
+		require.NoError(t, err)
+		assert.Contains(t, code, "--data-binary '@./blob.bin'")
+	})
+}
@@ -0,0 +1,29 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func jsonQuote(s string) string {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(s); err != nil {
+		return strconv.Quote(s)
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func httpieKey(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ":", `\:`, "=", `\=`, "@", `\@`)
+	return replacer.Replace(s)
+}
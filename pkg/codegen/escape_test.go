@@ -0,0 +1,33 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShellQuote(t *testing.T) {
+	t.Run("should wrap the string in single quotes", func(t *testing.T) {
+		assert.Equal(t, `'a $b "c"'`, shellQuote(`a $b "c"`))
+	})
+
+	t.Run("should escape single quotes", func(t *testing.T) {
+		assert.Equal(t, `'it'\''s'`, shellQuote("it's"))
+	})
+}
+
+func TestJSONQuote(t *testing.T) {
+	t.Run("should escape quotes and control characters", func(t *testing.T) {
+		assert.Equal(t, `"a\"b\n\\"`, jsonQuote("a\"b\n\\"))
+	})
+
+	t.Run("should not escape HTML characters", func(t *testing.T) {
+		assert.Equal(t, `"<a&b>"`, jsonQuote("<a&b>"))
+	})
+}
+
+func TestHTTPieKey(t *testing.T) {
+	t.Run("should escape item separators", func(t *testing.T) {
+		assert.Equal(t, `a\:b\=c\@d\\e`, httpieKey(`a:b=c@d\e`))
+	})
+}
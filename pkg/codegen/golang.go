@@ -0,0 +1,125 @@
+package codegen
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+)
+
+func (s *snippet) golang() string {
+	imports := []string{"fmt", "io", "net/http"}
+
+	var body strings.Builder
+	bodyArg := "nil"
+
+	switch {
+	case s.form:
+		imports = append(imports, "bytes", "mime/multipart")
+		bodyArg = "&body"
+
+		body.WriteString("\tvar body bytes.Buffer\n")
+		body.WriteString("\twriter := multipart.NewWriter(&body)\n")
+		for _, f := range s.fields {
+			if f.File {
+				body.WriteString(goFilePart(f))
+				continue
+			}
+			fmt.Fprintf(&body, "\twriter.WriteField(%s, %s)\n", strconv.Quote(f.Key), strconv.Quote(f.Value))
+		}
+		body.WriteString("\twriter.Close()\n\n")
+
+		if s.hasFiles() {
+			imports = append(imports, "os")
+		}
+		if slices.ContainsFunc(s.fields, func(f request.FormField) bool { return f.File && f.ContentType != "" }) {
+			imports = append(imports, "net/textproto")
+		}
+	case s.body != "":
+		imports = append(imports, "strings")
+		bodyArg = "body"
+
+		fmt.Fprintf(&body, "\tbody := strings.NewReader(%s)\n\n", strconv.Quote(s.body))
+	case s.file != "":
+		imports = append(imports, "os")
+		bodyArg = "body"
+
+		fmt.Fprintf(&body, "\tbody, err := os.Open(%s)\n", strconv.Quote(s.file))
+		body.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+		body.WriteString("\tdefer body.Close()\n\n")
+	}
+
+	sort.Strings(imports)
+
+	var b strings.Builder
+
+	b.WriteString("package main\n\nimport (\n")
+	for _, imp := range imports {
+		fmt.Fprintf(&b, "\t%q\n", imp)
+	}
+	b.WriteString(")\n\nfunc main() {\n")
+	b.WriteString(body.String())
+
+	fmt.Fprintf(
+		&b,
+		"\treq, err := http.NewRequest(%s, %s, %s)\n",
+		strconv.Quote(s.method),
+		strconv.Quote(s.url),
+		bodyArg,
+	)
+	b.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n\n")
+
+	for _, h := range s.headers {
+		fmt.Fprintf(&b, "\treq.Header.Add(%s, %s)\n", strconv.Quote(h.key), strconv.Quote(h.value))
+	}
+	if s.form {
+		b.WriteString("\treq.Header.Set(\"Content-Type\", writer.FormDataContentType())\n")
+	}
+	if len(s.headers) > 0 || s.form {
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\tresp, err := http.DefaultClient.Do(req)\n")
+	b.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+	b.WriteString("\tdefer resp.Body.Close()\n\n")
+	b.WriteString("\tdata, err := io.ReadAll(resp.Body)\n")
+	b.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n\n")
+	b.WriteString("\tfmt.Println(resp.Status)\n")
+	b.WriteString("\tfmt.Println(string(data))\n")
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func goFilePart(f request.FormField) string {
+	var b strings.Builder
+
+	b.WriteString("\t{\n")
+	fmt.Fprintf(&b, "\t\tfile, err := os.Open(%s)\n", strconv.Quote(f.Value))
+	b.WriteString("\t\tif err != nil {\n\t\t\tpanic(err)\n\t\t}\n")
+
+	if f.ContentType == "" {
+		fmt.Fprintf(
+			&b,
+			"\t\tpart, err := writer.CreateFormFile(%s, %s)\n",
+			strconv.Quote(f.Key),
+			strconv.Quote(f.FileName()),
+		)
+	} else {
+		disposition := fmt.Sprintf("form-data; name=%q; filename=%q", f.Key, f.FileName())
+		b.WriteString("\t\theader := make(textproto.MIMEHeader)\n")
+		fmt.Fprintf(&b, "\t\theader.Set(\"Content-Disposition\", %s)\n", strconv.Quote(disposition))
+		fmt.Fprintf(&b, "\t\theader.Set(\"Content-Type\", %s)\n", strconv.Quote(f.ContentType))
+		b.WriteString("\t\tpart, err := writer.CreatePart(header)\n")
+	}
+
+	b.WriteString("\t\tif err != nil {\n\t\t\tpanic(err)\n\t\t}\n")
+	b.WriteString("\t\tif _, err := io.Copy(part, file); err != nil {\n\t\t\tpanic(err)\n\t\t}\n")
+	b.WriteString("\t\tfile.Close()\n")
+	b.WriteString("\t}\n")
+
+	return b.String()
+}
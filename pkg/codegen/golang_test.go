@@ -0,0 +1,102 @@
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGolang(t *testing.T) {
+	t.Run("should generate gofmt-ed code without body", func(t *testing.T) {
+		req := request.NewModel().SetMethod(request.GET).SetURL("http://localhost")
+
+		code, err := Generate(req, LanguageGo)
+		require.NoError(t, err)
+
+		formatted, err := format.Source([]byte(code))
+
+		assert.NoError(t, err)
+		assert.Equal(t, string(formatted), code)
+		assert.Contains(t, code, `http.NewRequest("GET", "http://localhost", nil)`)
+		assert.NotContains(t, code, `"strings"`)
+	})
+
+	t.Run("should generate gofmt-ed code with a quoted body", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetBody("{\"a\":\"line\\nbreak\"}").
+			SetBodyType(request.BodyTypeJSON)
+
+		code, err := Generate(req, LanguageGo)
+		require.NoError(t, err)
+
+		formatted, err := format.Source([]byte(code))
+
+		assert.NoError(t, err)
+		assert.Equal(t, string(formatted), code)
+		assert.Contains(t, code, `strings.NewReader("{\"a\":\"line\\nbreak\"}")`)
+		assert.Contains(t, code, `req.Header.Add("Content-Type", "application/json")`)
+	})
+
+	t.Run("should generate a multipart writer for form-data", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetBody(`{"key":"value"}`).
+			SetBodyType(request.BodyTypeFormData)
+
+		code, err := Generate(req, LanguageGo)
+		require.NoError(t, err)
+
+		formatted, err := format.Source([]byte(code))
+
+		assert.NoError(t, err)
+		assert.Equal(t, string(formatted), code)
+		assert.Contains(t, code, `writer.WriteField("key", "value")`)
+		assert.Contains(t, code, "writer.FormDataContentType()")
+	})
+
+	t.Run("should copy file fields into the multipart writer", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetBodyType(request.BodyTypeFormData).
+			SetForm([]request.FormField{
+				{Key: "avatar", Value: "./me.png", File: true, ContentType: "image/png", Filename: "avatar.png"},
+				{Key: "doc", Value: "/tmp/doc.pdf", File: true},
+			})
+
+		code, err := Generate(req, LanguageGo)
+		require.NoError(t, err)
+
+		formatted, err := format.Source([]byte(code))
+
+		assert.NoError(t, err)
+		assert.Equal(t, string(formatted), code)
+		assert.Contains(t, code, `os.Open("./me.png")`)
+		assert.Contains(t, code, `header.Set("Content-Type", "image/png")`)
+		assert.Contains(t, code, `writer.CreateFormFile("doc", "doc.pdf")`)
+		assert.Contains(t, code, `"net/textproto"`)
+	})
+
+	t.Run("should stream binary bodies from the file", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.PUT).
+			SetURL("http://localhost").
+			SetBody("./blob.bin").
+			SetBodyType(request.BodyTypeBinary)
+
+		code, err := Generate(req, LanguageGo)
+		require.NoError(t, err)
+
+		formatted, err := format.Source([]byte(code))
+
+		assert.NoError(t, err)
+		assert.Equal(t, string(formatted), code)
+		assert.Contains(t, code, `body, err := os.Open("./blob.bin")`)
+	})
+}
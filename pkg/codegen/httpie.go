@@ -0,0 +1,47 @@
+package codegen
+
+import (
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+)
+
+func (s *snippet) httpie() string {
+	command := "http --ignore-stdin"
+	if s.form {
+		command += " --multipart"
+	}
+
+	parts := []string{command + " " + s.method + " " + shellQuote(s.url)}
+
+	for _, h := range s.headers {
+		parts = append(parts, shellQuote(httpieKey(h.key)+":"+h.value))
+	}
+
+	for _, f := range s.fields {
+		if f.File {
+			parts = append(parts, shellQuote(httpieFile(f)))
+			continue
+		}
+		parts = append(parts, shellQuote(httpieKey(f.Key)+"="+f.Value))
+	}
+
+	if s.body != "" {
+		parts = append(parts, "--raw "+shellQuote(s.body))
+	}
+
+	if s.file != "" {
+		parts = append(parts, shellQuote("@"+s.file))
+	}
+
+	return strings.Join(parts, " \\\n  ")
+}
+
+func httpieFile(f request.FormField) string {
+	value := httpieKey(f.Key) + "@" + f.Value
+	if f.ContentType != "" {
+		value += ";type=" + f.ContentType
+	}
+
+	return value
+}
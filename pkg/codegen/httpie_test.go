@@ -0,0 +1,76 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPie(t *testing.T) {
+	t.Run("should send the raw encoded body", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.PUT).
+			SetURL("http://localhost").
+			SetBody(`{"a":1}`).
+			SetBodyType(request.BodyTypeJSON).
+			AddHeader("X-Token", "secret")
+
+		code, err := Generate(req, LanguageHTTPie)
+
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			"http --ignore-stdin PUT 'http://localhost' \\\n"+
+				"  'Content-Type:application/json' \\\n"+
+				"  'X-Token:secret' \\\n"+
+				`  --raw '{"a":1}'`,
+			code,
+		)
+	})
+
+	t.Run("should use multipart items for form-data", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetBody(`{"a:b":"c"}`).
+			SetBodyType(request.BodyTypeFormData)
+
+		code, err := Generate(req, LanguageHTTPie)
+
+		require.NoError(t, err)
+		assert.Contains(t, code, "http --ignore-stdin --multipart POST")
+		assert.Contains(t, code, `'a\:b=c'`)
+	})
+
+	t.Run("should upload file fields", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetBodyType(request.BodyTypeFormData).
+			SetForm([]request.FormField{
+				{Key: "avatar", Value: "./me.png", File: true, ContentType: "image/png", Filename: "avatar.png"},
+				{Key: "doc", Value: "/tmp/doc.pdf", File: true},
+			})
+
+		code, err := Generate(req, LanguageHTTPie)
+
+		require.NoError(t, err)
+		assert.Contains(t, code, `'avatar@./me.png;type=image/png'`)
+		assert.Contains(t, code, `'doc@/tmp/doc.pdf'`)
+	})
+
+	t.Run("should send binary bodies from the file", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.PUT).
+			SetURL("http://localhost").
+			SetBody("./blob.bin").
+			SetBodyType(request.BodyTypeBinary)
+
+		code, err := Generate(req, LanguageHTTPie)
+
+		require.NoError(t, err)
+		assert.Contains(t, code, "'@./blob.bin'")
+	})
+}
@@ -0,0 +1,70 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (s *snippet) javascript() string {
+	var b strings.Builder
+
+	if s.hasFiles() {
+		b.WriteString("import { openAsBlob } from \"node:fs\";\n\n")
+	}
+
+	options := []string{"method: " + jsonQuote(s.method)}
+
+	if headers := s.mergedHeaders(); len(headers) > 0 {
+		b.WriteString("const headers = {\n")
+		for _, h := range headers {
+			fmt.Fprintf(&b, "  %s: %s,\n", jsonQuote(h.key), jsonQuote(h.value))
+		}
+		b.WriteString("};\n\n")
+		options = append(options, "headers")
+	}
+
+	switch {
+	case s.form:
+		b.WriteString("const body = new FormData();\n")
+		for _, f := range s.fields {
+			if f.File {
+				fmt.Fprintf(
+					&b,
+					"body.append(%s, await openAsBlob(%s%s), %s);\n",
+					jsonQuote(f.Key),
+					jsonQuote(f.Value),
+					blobOptions(f.ContentType),
+					jsonQuote(f.FileName()),
+				)
+				continue
+			}
+			fmt.Fprintf(&b, "body.append(%s, %s);\n", jsonQuote(f.Key), jsonQuote(f.Value))
+		}
+		b.WriteString("\n")
+		options = append(options, "body")
+	case s.body != "":
+		fmt.Fprintf(&b, "const body = %s;\n\n", jsonQuote(s.body))
+		options = append(options, "body")
+	case s.file != "":
+		fmt.Fprintf(&b, "const body = await openAsBlob(%s);\n\n", jsonQuote(s.file))
+		options = append(options, "body")
+	}
+
+	fmt.Fprintf(&b, "const response = await fetch(%s, {\n", jsonQuote(s.url))
+	for _, opt := range options {
+		fmt.Fprintf(&b, "  %s,\n", opt)
+	}
+	b.WriteString("});\n\n")
+	b.WriteString("console.log(response.status);\n")
+	b.WriteString("console.log(await response.text());\n")
+
+	return b.String()
+}
+
+func blobOptions(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+
+	return ", { type: " + jsonQuote(contentType) + " }"
+}
@@ -0,0 +1,79 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJavaScript(t *testing.T) {
+	t.Run("should only set the method without headers nor body", func(t *testing.T) {
+		req := request.NewModel().SetMethod(request.GET).SetURL("http://localhost")
+
+		code, err := Generate(req, LanguageJavaScript)
+
+		require.NoError(t, err)
+		assert.Contains(t, code, "const response = await fetch(\"http://localhost\", {\n  method: \"GET\",\n});")
+	})
+
+	t.Run("should pass headers and an escaped body", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.PATCH).
+			SetURL("http://localhost").
+			SetBody(`{"a":"</script>"}`).
+			SetBodyType(request.BodyTypeJSON)
+
+		code, err := Generate(req, LanguageJavaScript)
+
+		require.NoError(t, err)
+		assert.Contains(t, code, `const body = "{\"a\":\"</script>\"}";`)
+		assert.Contains(t, code, "  headers,\n  body,\n")
+	})
+
+	t.Run("should build a FormData for form-data", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetBody(`{"key":"value"}`).
+			SetBodyType(request.BodyTypeFormData)
+
+		code, err := Generate(req, LanguageJavaScript)
+
+		require.NoError(t, err)
+		assert.Contains(t, code, `body.append("key", "value");`)
+	})
+
+	t.Run("should read file fields as blobs", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetBodyType(request.BodyTypeFormData).
+			SetForm([]request.FormField{
+				{Key: "avatar", Value: "./me.png", File: true, ContentType: "image/png", Filename: "avatar.png"},
+				{Key: "doc", Value: "/tmp/doc.pdf", File: true},
+			})
+
+		code, err := Generate(req, LanguageJavaScript)
+
+		require.NoError(t, err)
+		assert.Contains(t, code, `import { openAsBlob } from "node:fs";`)
+		assert.Contains(t, code, `body.append("avatar", await openAsBlob("./me.png", { type: "image/png" }), "avatar.png");`)
+		assert.Contains(t, code, `body.append("doc", await openAsBlob("/tmp/doc.pdf"), "doc.pdf");`)
+	})
+
+	t.Run("should send binary bodies as a blob", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.PUT).
+			SetURL("http://localhost").
+			SetBody("./blob.bin").
+			SetBodyType(request.BodyTypeBinary)
+
+		code, err := Generate(req, LanguageJavaScript)
+
+		require.NoError(t, err)
+		assert.Contains(t, code, `import { openAsBlob } from "node:fs";`)
+		assert.Contains(t, code, `const body = await openAsBlob("./blob.bin");`)
+	})
+}
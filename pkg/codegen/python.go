@@ -0,0 +1,66 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (s *snippet) python() string {
+	var b strings.Builder
+
+	b.WriteString("import requests\n\n")
+	fmt.Fprintf(&b, "url = %s\n", jsonQuote(s.url))
+
+	args := ""
+
+	if headers := s.mergedHeaders(); len(headers) > 0 {
+		b.WriteString("headers = {\n")
+		for _, h := range headers {
+			fmt.Fprintf(&b, "    %s: %s,\n", jsonQuote(h.key), jsonQuote(h.value))
+		}
+		b.WriteString("}\n")
+		args += ", headers=headers"
+	}
+
+	switch {
+	case s.form:
+		b.WriteString("files = {\n")
+		for _, f := range s.fields {
+			switch {
+			case !f.File:
+				fmt.Fprintf(&b, "    %s: (None, %s),\n", jsonQuote(f.Key), jsonQuote(f.Value))
+			case f.ContentType != "":
+				fmt.Fprintf(
+					&b,
+					"    %s: (%s, open(%s, \"rb\"), %s),\n",
+					jsonQuote(f.Key),
+					jsonQuote(f.FileName()),
+					jsonQuote(f.Value),
+					jsonQuote(f.ContentType),
+				)
+			default:
+				fmt.Fprintf(
+					&b,
+					"    %s: (%s, open(%s, \"rb\")),\n",
+					jsonQuote(f.Key),
+					jsonQuote(f.FileName()),
+					jsonQuote(f.Value),
+				)
+			}
+		}
+		b.WriteString("}\n")
+		args += ", files=files"
+	case s.body != "":
+		fmt.Fprintf(&b, "payload = %s\n", jsonQuote(s.body))
+		args += ", data=payload.encode(\"utf-8\")"
+	case s.file != "":
+		fmt.Fprintf(&b, "payload = open(%s, \"rb\")\n", jsonQuote(s.file))
+		args += ", data=payload"
+	}
+
+	fmt.Fprintf(&b, "\nresponse = requests.request(%s, url%s)\n\n", jsonQuote(s.method), args)
+	b.WriteString("print(response.status_code)\n")
+	b.WriteString("print(response.text)\n")
+
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPython(t *testing.T) {
+	t.Run("should generate a request without headers nor body", func(t *testing.T) {
+		req := request.NewModel().SetMethod(request.DELETE).SetURL("http://localhost")
+
+		code, err := Generate(req, LanguagePython)
+
+		require.NoError(t, err)
+		assert.Contains(t, code, `response = requests.request("DELETE", url)`)
+		assert.NotContains(t, code, "headers")
+	})
+
+	t.Run("should merge repeated headers into one key", func(t *testing.T) {
+		req := request.NewModel().
+			SetURL("http://localhost").
+			AddHeader("Accept", "text/html").
+			AddHeader("Cookie", "a=1").
+			AddHeader("accept", "application/json").
+			AddHeader("Cookie", "b=2")
+
+		code, err := Generate(req, LanguagePython)
+
+		require.NoError(t, err)
+		assert.Contains(t, code, "headers = {\n    \"Accept\": \"text/html, application/json\",\n    \"Cookie\": \"a=1; b=2\",\n}\n")
+	})
+
+	t.Run("should pass headers and an escaped payload", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetBody(`{"a":"b"}`).
+			SetBodyType(request.BodyTypeJSON)
+
+		code, err := Generate(req, LanguagePython)
+
+		require.NoError(t, err)
+		assert.Contains(t, code, `"Content-Type": "application/json",`)
+		assert.Contains(t, code, `payload = "{\"a\":\"b\"}"`)
+		assert.Contains(t, code, `headers=headers, data=payload.encode("utf-8")`)
+	})
+
+	t.Run("should use files for form-data", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetBody(`{"key":"value"}`).
+			SetBodyType(request.BodyTypeFormData)
+
+		code, err := Generate(req, LanguagePython)
+
+		require.NoError(t, err)
+		assert.Contains(t, code, `"key": (None, "value"),`)
+		assert.Contains(t, code, "files=files")
+	})
+
+	t.Run("should open file fields", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.POST).
+			SetURL("http://localhost").
+			SetBodyType(request.BodyTypeFormData).
+			SetForm([]request.FormField{
+				{Key: "avatar", Value: "./me.png", File: true, ContentType: "image/png", Filename: "avatar.png"},
+				{Key: "doc", Value: "/tmp/doc.pdf", File: true},
+			})
+
+		code, err := Generate(req, LanguagePython)
+
+		require.NoError(t, err)
+		assert.Contains(t, code, `"avatar": ("avatar.png", open("./me.png", "rb"), "image/png"),`)
+		assert.Contains(t, code, `"doc": ("doc.pdf", open("/tmp/doc.pdf", "rb")),`)
+	})
+
+	t.Run("should send binary bodies from the file", func(t *testing.T) {
+		req := request.NewModel().
+			SetMethod(request.PUT).
+			SetURL("http://localhost").
+			SetBody("./blob.bin").
+			SetBodyType(request.BodyTypeBinary)
+
+		code, err := Generate(req, LanguagePython)
+
+		require.NoError(t, err)
+		assert.Contains(t, code, `payload = open("./blob.bin", "rb")`)
+		assert.Contains(t, code, "data=payload)")
+	})
+}
@@ -0,0 +1,75 @@
+package codegen
+
+import (
+	"errors"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+)
+
+var ErrUnknownLanguage = errors.New("unknown language")
+
+type Language uint
+
+const (
+	LanguageCurl Language = iota
+	LanguageHTTPie
+	LanguageGo
+	LanguagePython
+	LanguageJavaScript
+)
+
+var AllLanguages = []Language{
+	LanguageCurl,
+	LanguageHTTPie,
+	LanguageGo,
+	LanguagePython,
+	LanguageJavaScript,
+}
+
+func (l Language) String() string {
+	switch l {
+	case LanguageCurl:
+		return "curl"
+	case LanguageHTTPie:
+		return "HTTPie"
+	case LanguageGo:
+		return "Go"
+	case LanguagePython:
+		return "Python"
+	case LanguageJavaScript:
+		return "JavaScript"
+	default:
+		return "curl"
+	}
+}
+
+func (l Language) Lexer() string {
+	switch l {
+	case LanguageCurl, LanguageHTTPie:
+		return "bash"
+	case LanguageGo:
+		return "go"
+	case LanguagePython:
+		return "python"
+	case LanguageJavaScript:
+		return "javascript"
+	default:
+		return "bash"
+	}
+}
+
+type header struct {
+	key   string
+	value string
+}
+
+type snippet struct {
+	method  string
+	url     string
+	headers []header
+	body    string
+	file    string
+	fields  []request.FormField
+	form    bool
+	version request.HTTPVersion
+}
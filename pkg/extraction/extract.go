@@ -0,0 +1,91 @@
+package extraction
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/Yalaouf/gostman/pkg/jsonpath"
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+func Extract(extractions []storage.Extraction, res *request.Response) []Result {
+	results := make([]Result, 0, len(extractions))
+
+	for _, e := range extractions {
+		value, err := extract(e, res)
+		results = append(results, Result{Extraction: e, Value: value, Err: err})
+	}
+
+	return results
+}
+
+func Variables(results []Result, scope string) map[string]string {
+	vars := map[string]string{}
+
+	for _, r := range results {
+		if r.Err != nil {
+			continue
+		}
+
+		resultScope := r.Extraction.Scope
+		if resultScope == "" {
+			resultScope = ScopeEnvironment
+		}
+
+		if scope == "" || scope == resultScope {
+			vars[r.Extraction.Variable] = r.Value
+		}
+	}
+
+	return vars
+}
+
+func extract(e storage.Extraction, res *request.Response) (string, error) {
+	if res == nil {
+		return "", fmt.Errorf("no response")
+	}
+
+	switch e.Source {
+	case SourceJSON:
+		value, err := jsonpath.Lookup(res.Body, e.Expression)
+		if err != nil {
+			return "", err
+		}
+		return jsonpath.String(value), nil
+
+	case SourceHeader:
+		value := http.Header(res.Headers).Get(e.Expression)
+		if value == "" {
+			return "", fmt.Errorf("header %s is missing", e.Expression)
+		}
+		return value, nil
+
+	case SourceRegex:
+		re, err := regexp.Compile(e.Expression)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalidExtraction, err)
+		}
+
+		match := re.FindStringSubmatch(res.Body)
+		if match == nil {
+			return "", fmt.Errorf("no match for %s", e.Expression)
+		}
+		if len(match) > 1 {
+			return match[1], nil
+		}
+		return match[0], nil
+
+	case SourceCookie:
+		cookies := (&http.Response{Header: http.Header(res.Headers)}).Cookies()
+		for _, cookie := range cookies {
+			if cookie.Name == e.Expression {
+				return cookie.Value, nil
+			}
+		}
+		return "", fmt.Errorf("cookie %s is missing", e.Expression)
+	}
+
+	return "", fmt.Errorf("%w: unknown source %q", ErrInvalidExtraction, e.Source)
+}
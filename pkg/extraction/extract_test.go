@@ -0,0 +1,79 @@
+package extraction
+
+import (
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testResponse = &request.Response{
+	StatusCode: 200,
+	Headers: map[string][]string{
+		"X-Request-Id": {"req-1"},
+		"Set-Cookie":   {"session=abc123; Path=/; HttpOnly", "theme=dark"},
+	},
+	Body: `{"data": {"token": "t0k3n", "id": 42, "roles": ["admin"]}, "message": "order-981 created"}`,
+}
+
+func mustParse(t *testing.T, line string) storage.Extraction {
+	e, err := Parse(line)
+	require.NoError(t, err)
+	return e
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected string
+		err      string
+	}{
+		{"json $.data.token -> token", "t0k3n", ""},
+		{"json $.data.id -> id", "42", ""},
+		{"json $.data.roles -> roles", `["admin"]`, ""},
+		{"json $.data.missing -> missing", "", "JSON path not found"},
+		{"header x-request-id -> requestId", "req-1", ""},
+		{"header X-Missing -> missing", "", "header X-Missing is missing"},
+		{`regex order-(\d+) -> orderId`, "981", ""},
+		{`regex order-\d+ -> order`, "order-981", ""},
+		{`regex invoice-(\d+) -> invoice`, "", "no match"},
+		{"cookie session -> sid", "abc123", ""},
+		{"cookie missing -> sid", "", "cookie missing is missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			results := Extract([]storage.Extraction{mustParse(t, tt.line)}, testResponse)
+			require.Len(t, results, 1)
+
+			if tt.err != "" {
+				assert.ErrorContains(t, results[0].Err, tt.err)
+				return
+			}
+
+			require.NoError(t, results[0].Err)
+			assert.Equal(t, tt.expected, results[0].Value)
+		})
+	}
+
+	t.Run("should fail without a response", func(t *testing.T) {
+		results := Extract([]storage.Extraction{mustParse(t, "json $.a -> a")}, nil)
+
+		assert.Error(t, results[0].Err)
+	})
+}
+
+func TestVariables(t *testing.T) {
+	results := Extract([]storage.Extraction{
+		mustParse(t, "json $.data.token -> token"),
+		mustParse(t, "header X-Request-Id -> collection:requestId"),
+		mustParse(t, "json $.missing -> missing"),
+		{Source: SourceJSON, Expression: "$.data.id", Variable: "id"},
+	}, testResponse)
+
+	assert.Equal(t, map[string]string{"token": "t0k3n", "id": "42"}, Variables(results, ScopeEnvironment))
+	assert.Equal(t, map[string]string{"requestId": "req-1"}, Variables(results, ScopeCollection))
+	assert.Len(t, Variables(results, ""), 3)
+}
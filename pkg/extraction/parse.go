@@ -0,0 +1,77 @@
+package extraction
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/jsonpath"
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+var variableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+func Parse(line string) (storage.Extraction, error) {
+	line = strings.TrimSpace(line)
+
+	i := strings.LastIndex(line, "->")
+	if i == -1 {
+		return storage.Extraction{}, fmt.Errorf("%w: expected SOURCE EXPRESSION -> VARIABLE", ErrInvalidExtraction)
+	}
+
+	source, expression, _ := strings.Cut(strings.TrimSpace(line[:i]), " ")
+	e := storage.Extraction{
+		Source:     strings.ToLower(source),
+		Expression: strings.TrimSpace(expression),
+		Variable:   strings.TrimSpace(line[i+2:]),
+		Scope:      ScopeEnvironment,
+	}
+
+	if scope, name, ok := strings.Cut(e.Variable, ":"); ok {
+		e.Scope = strings.ToLower(strings.TrimSpace(scope))
+		e.Variable = strings.TrimSpace(name)
+	}
+
+	return e, Validate(e)
+}
+
+func Validate(e storage.Extraction) error {
+	if e.Expression == "" {
+		return fmt.Errorf("%w: missing expression", ErrInvalidExtraction)
+	}
+
+	if !variableName.MatchString(e.Variable) {
+		return fmt.Errorf("%w: invalid variable name %q", ErrInvalidExtraction, e.Variable)
+	}
+
+	switch e.Scope {
+	case "", ScopeEnvironment, ScopeCollection:
+	default:
+		return fmt.Errorf("%w: unknown scope %q", ErrInvalidExtraction, e.Scope)
+	}
+
+	switch e.Source {
+	case SourceJSON:
+		if _, err := jsonpath.Parse(e.Expression); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidExtraction, err)
+		}
+	case SourceRegex:
+		if _, err := regexp.Compile(e.Expression); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidExtraction, err)
+		}
+	case SourceHeader, SourceCookie:
+	default:
+		return fmt.Errorf("%w: unknown source %q", ErrInvalidExtraction, e.Source)
+	}
+
+	return nil
+}
+
+func Format(e storage.Extraction) string {
+	variable := e.Variable
+	if e.Scope == ScopeCollection {
+		variable = ScopeCollection + ":" + variable
+	}
+
+	return fmt.Sprintf("%s %s -> %s", e.Source, e.Expression, variable)
+}
@@ -0,0 +1,66 @@
+package extraction
+
+import (
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected storage.Extraction
+		format   string
+	}{
+		{
+			"json $.data.token -> token",
+			storage.Extraction{Source: SourceJSON, Expression: "$.data.token", Variable: "token", Scope: ScopeEnvironment},
+			"json $.data.token -> token",
+		},
+		{
+			"Header X-Request-Id -> collection:requestId",
+			storage.Extraction{Source: SourceHeader, Expression: "X-Request-Id", Variable: "requestId", Scope: ScopeCollection},
+			"header X-Request-Id -> collection:requestId",
+		},
+		{
+			`regex order-(\d+) -> environment:orderId`,
+			storage.Extraction{Source: SourceRegex, Expression: `order-(\d+)`, Variable: "orderId", Scope: ScopeEnvironment},
+			`regex order-(\d+) -> orderId`,
+		},
+		{
+			"cookie session -> sid",
+			storage.Extraction{Source: SourceCookie, Expression: "session", Variable: "sid", Scope: ScopeEnvironment},
+			"cookie session -> sid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			e, err := Parse(tt.line)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, e)
+			assert.Equal(t, tt.format, Format(e))
+		})
+	}
+
+	t.Run("should reject invalid rules", func(t *testing.T) {
+		lines := []string{
+			"json $.token",
+			"json -> token",
+			"json $.token ->",
+			"json $.token -> 1abc",
+			"json $.token -> global:token",
+			"body $.token -> token",
+			"json $.a[ -> token",
+			"regex ( -> token",
+		}
+
+		for _, line := range lines {
+			_, err := Parse(line)
+			assert.ErrorIs(t, err, ErrInvalidExtraction, line)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package extraction
+
+import (
+	"errors"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+var ErrInvalidExtraction = errors.New("invalid extraction")
+
+const (
+	SourceJSON   = "json"
+	SourceHeader = "header"
+	SourceRegex  = "regex"
+	SourceCookie = "cookie"
+)
+
+const (
+	ScopeEnvironment = "environment"
+	ScopeCollection  = "collection"
+)
+
+type Result struct {
+	Extraction storage.Extraction
+	Value      string
+	Err        error
+}
@@ -0,0 +1,146 @@
+package graphql
+
+import "strings"
+
+type Completion struct {
+	Prefix string
+	Type   string
+	Fields []Field
+}
+
+func Operations(query string) []string {
+	var names []string
+
+	tokens := lex(query)
+	depth, parens := 0, 0
+
+	for i, tok := range tokens {
+		if tok.kind == tokenPunct {
+			switch tok.text {
+			case "{":
+				depth++
+			case "}":
+				depth = max(depth-1, 0)
+			case "(":
+				parens++
+			case ")":
+				parens = max(parens-1, 0)
+			}
+			continue
+		}
+
+		if depth > 0 || parens > 0 || tok.kind != tokenName || i+1 >= len(tokens) {
+			continue
+		}
+
+		switch tok.text {
+		case "query", "mutation", "subscription":
+			if next := tokens[i+1]; next.kind == tokenName {
+				names = append(names, next.text)
+			}
+		}
+	}
+
+	return names
+}
+
+func (s *Schema) Complete(query string, offset int) Completion {
+	offset = min(max(offset, 0), len(query))
+	before := query[:offset]
+
+	start := len(before)
+	for start > 0 && isNameChar(before[start-1]) {
+		start--
+	}
+
+	completion := Completion{Prefix: before[start:]}
+
+	typeName, ok := s.selectionType(before[:start])
+	if !ok {
+		return completion
+	}
+
+	t, ok := s.Types[typeName]
+	if !ok {
+		return completion
+	}
+
+	completion.Type = typeName
+	prefix := strings.ToLower(completion.Prefix)
+	for _, f := range t.Fields {
+		if strings.HasPrefix(strings.ToLower(f.Name), prefix) {
+			completion.Fields = append(completion.Fields, f)
+		}
+	}
+
+	return completion
+}
+
+func (s *Schema) selectionType(src string) (string, bool) {
+	var (
+		stack         []string
+		operation     string
+		last          string
+		prev          string
+		pending       string
+		typeCondition bool
+		parens        int
+	)
+
+	for _, tok := range lex(src) {
+		if parens > 0 {
+			switch tok.text {
+			case "(":
+				parens++
+			case ")":
+				parens--
+			}
+			continue
+		}
+
+		switch {
+		case tok.kind == tokenPunct && tok.text == "(":
+			parens++
+		case tok.kind == tokenPunct && tok.text == "{":
+			var next string
+			switch {
+			case pending != "":
+				next = pending
+			case len(stack) == 0:
+				next = s.rootType(operation)
+			default:
+				if f, ok := s.Field(stack[len(stack)-1], last); ok {
+					next = f.Type.Named()
+				}
+			}
+			stack = append(stack, next)
+			operation, last, pending = "", "", ""
+		case tok.kind == tokenPunct && tok.text == "}":
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			last, pending = "", ""
+		case tok.kind == tokenName && typeCondition:
+			pending = tok.text
+			typeCondition = false
+		case tok.kind == tokenName && tok.text == "on" && (prev == "..." || len(stack) == 0):
+			typeCondition = true
+		case tok.kind == tokenName && prev == "@":
+		case tok.kind == tokenName && len(stack) == 0:
+			switch tok.text {
+			case "query", "mutation", "subscription":
+				operation = tok.text
+			}
+		case tok.kind == tokenName:
+			last = tok.text
+		}
+
+		prev = tok.text
+	}
+
+	if parens > 0 || len(stack) == 0 || stack[len(stack)-1] == "" {
+		return "", false
+	}
+
+	return stack[len(stack)-1], true
+}
@@ -0,0 +1,75 @@
+package graphql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fieldNames(fields []Field) []string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	return names
+}
+
+func completeAt(schema *Schema, query string) Completion {
+	offset := strings.Index(query, "|")
+	return schema.Complete(strings.Replace(query, "|", "", 1), offset)
+}
+
+func TestComplete(t *testing.T) {
+	schema := loadTestSchema(t)
+
+	tests := []struct {
+		name   string
+		query  string
+		typ    string
+		prefix string
+		fields []string
+	}{
+		{"root query fields", "{ |", "Query", "", []string{"user", "users"}},
+		{"prefix on root", "query { us| }", "Query", "us", []string{"user", "users"}},
+		{"nested selection", `query Q($id: ID!) { user(id: $id) { n| } }`, "User", "n", []string{"name"}},
+		{"list types", "{ users { posts { author { | } } } }", "User", "", []string{"id", "name", "email", "posts", "role"}},
+		{"after closed selection", "{ user(id: 1) { posts { title } | } }", "User", "", []string{"id", "name", "email", "posts", "role"}},
+		{"mutation root", "mutation { cr| }", "Mutation", "cr", []string{"createUser"}},
+		{"alias", "{ me: user(id: 1) { em| } }", "User", "em", []string{"email"}},
+		{"inline fragment", "{ user { ... on Post { t| } } }", "Post", "t", []string{"title"}},
+		{"named fragment", "fragment F on Post { a| }", "Post", "a", []string{"author"}},
+		{"ignores strings and comments", "{ user(id: \"{ x\") { # {\n n| } }", "User", "n", []string{"name"}},
+		{"case insensitive prefix", "{ user { NA| } }", "User", "NA", []string{"name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := completeAt(schema, tt.query)
+
+			assert.Equal(t, tt.typ, c.Type)
+			assert.Equal(t, tt.prefix, c.Prefix)
+			assert.Equal(t, tt.fields, fieldNames(c.Fields))
+		})
+	}
+
+	t.Run("should not complete outside a selection set", func(t *testing.T) {
+		assert.Empty(t, completeAt(schema, "query Us|").Fields)
+		assert.Empty(t, completeAt(schema, "{ user(i| }").Fields)
+		assert.Empty(t, completeAt(schema, "{ user { role { | } } }").Fields)
+		assert.Empty(t, completeAt(schema, "subscription { | }").Fields)
+	})
+}
+
+func TestOperations(t *testing.T) {
+	query := `
+query GetUser($id: ID!) { user(id: $id) { name } }
+# query Commented { a }
+mutation CreateUser { createUser(name: "query Fake") { id } }
+fragment F on User { query }
+{ anonymous }
+`
+
+	assert.Equal(t, []string{"GetUser", "CreateUser"}, Operations(query))
+	assert.Empty(t, Operations("{ user { id } }"))
+}
@@ -0,0 +1,147 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+)
+
+type rawName struct {
+	Name string `json:"name"`
+}
+
+type rawInputValue struct {
+	Name         string  `json:"name"`
+	Description  string  `json:"description"`
+	Type         TypeRef `json:"type"`
+	DefaultValue *string `json:"defaultValue"`
+}
+
+type rawField struct {
+	Name         string          `json:"name"`
+	Description  string          `json:"description"`
+	Args         []rawInputValue `json:"args"`
+	Type         TypeRef         `json:"type"`
+	IsDeprecated bool            `json:"isDeprecated"`
+}
+
+type rawType struct {
+	Kind          string          `json:"kind"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	Fields        []rawField      `json:"fields"`
+	InputFields   []rawInputValue `json:"inputFields"`
+	EnumValues    []rawName       `json:"enumValues"`
+	PossibleTypes []rawName       `json:"possibleTypes"`
+}
+
+type rawSchema struct {
+	QueryType        *rawName  `json:"queryType"`
+	MutationType     *rawName  `json:"mutationType"`
+	SubscriptionType *rawName  `json:"subscriptionType"`
+	Types            []rawType `json:"types"`
+}
+
+type rawResponse struct {
+	Data *struct {
+		Schema *rawSchema `json:"__schema"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func Introspect(model *request.Model) (*Schema, error) {
+	m := *model
+	m.Method = request.POST
+	m.BodyType = request.BodyTypeGraphQL
+	m.Body = request.GraphQL{Query: IntrospectionQuery, OperationName: "IntrospectionQuery"}.String()
+
+	res, err := request.SendRequest(&m)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf("%w: status %d", ErrIntrospection, res.StatusCode)
+	}
+
+	return Parse([]byte(res.Body))
+}
+
+func Parse(data []byte) (*Schema, error) {
+	var res rawResponse
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntrospection, err)
+	}
+
+	if res.Data == nil || res.Data.Schema == nil {
+		if len(res.Errors) > 0 {
+			return nil, fmt.Errorf("%w: %s", ErrIntrospection, res.Errors[0].Message)
+		}
+		return nil, fmt.Errorf("%w: no schema in response", ErrIntrospection)
+	}
+
+	raw := res.Data.Schema
+	schema := &Schema{
+		QueryType:        nameOf(raw.QueryType),
+		MutationType:     nameOf(raw.MutationType),
+		SubscriptionType: nameOf(raw.SubscriptionType),
+		Types:            make(map[string]*Type, len(raw.Types)),
+	}
+
+	for _, rt := range raw.Types {
+		t := &Type{
+			Name:        rt.Name,
+			Kind:        rt.Kind,
+			Description: rt.Description,
+			InputFields: inputValues(rt.InputFields),
+		}
+
+		for _, f := range rt.Fields {
+			t.Fields = append(t.Fields, Field{
+				Name:        f.Name,
+				Description: f.Description,
+				Args:        inputValues(f.Args),
+				Type:        f.Type,
+				Deprecated:  f.IsDeprecated,
+			})
+		}
+
+		for _, v := range rt.EnumValues {
+			t.EnumValues = append(t.EnumValues, v.Name)
+		}
+
+		for _, p := range rt.PossibleTypes {
+			t.PossibleTypes = append(t.PossibleTypes, p.Name)
+		}
+
+		schema.Types[t.Name] = t
+	}
+
+	return schema, nil
+}
+
+func nameOf(n *rawName) string {
+	if n == nil {
+		return ""
+	}
+	return n.Name
+}
+
+func inputValues(raw []rawInputValue) []InputValue {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	values := make([]InputValue, len(raw))
+	for i, v := range raw {
+		values[i] = InputValue{Name: v.Name, Description: v.Description, Type: v.Type}
+		if v.DefaultValue != nil {
+			values[i].DefaultValue = *v.DefaultValue
+		}
+	}
+
+	return values
+}
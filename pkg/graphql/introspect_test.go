@@ -0,0 +1,158 @@
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `{"data":{"__schema":{
+  "queryType":{"name":"Query"},
+  "mutationType":{"name":"Mutation"},
+  "subscriptionType":null,
+  "types":[
+    {"kind":"OBJECT","name":"Query","fields":[
+      {"name":"user","args":[{"name":"id","type":{"kind":"NON_NULL","ofType":{"kind":"SCALAR","name":"ID"}}}],"type":{"kind":"OBJECT","name":"User"}},
+      {"name":"users","args":[{"name":"first","type":{"kind":"SCALAR","name":"Int"},"defaultValue":"10"}],"type":{"kind":"NON_NULL","ofType":{"kind":"LIST","ofType":{"kind":"NON_NULL","ofType":{"kind":"OBJECT","name":"User"}}}}}
+    ]},
+    {"kind":"OBJECT","name":"Mutation","fields":[
+      {"name":"createUser","args":[{"name":"name","type":{"kind":"NON_NULL","ofType":{"kind":"SCALAR","name":"String"}}}],"type":{"kind":"OBJECT","name":"User"}}
+    ]},
+    {"kind":"OBJECT","name":"User","description":"A registered user","fields":[
+      {"name":"id","args":[],"type":{"kind":"NON_NULL","ofType":{"kind":"SCALAR","name":"ID"}}},
+      {"name":"name","args":[],"type":{"kind":"SCALAR","name":"String"}},
+      {"name":"email","args":[],"type":{"kind":"SCALAR","name":"String"},"isDeprecated":true},
+      {"name":"posts","args":[],"type":{"kind":"LIST","ofType":{"kind":"OBJECT","name":"Post"}}},
+      {"name":"role","args":[],"type":{"kind":"ENUM","name":"Role"}}
+    ]},
+    {"kind":"OBJECT","name":"Post","fields":[
+      {"name":"title","args":[],"type":{"kind":"SCALAR","name":"String"}},
+      {"name":"author","args":[],"type":{"kind":"OBJECT","name":"User"}}
+    ]},
+    {"kind":"ENUM","name":"Role","enumValues":[{"name":"ADMIN"},{"name":"MEMBER"}]},
+    {"kind":"SCALAR","name":"ID"},
+    {"kind":"SCALAR","name":"String"},
+    {"kind":"SCALAR","name":"Int"},
+    {"kind":"OBJECT","name":"__Schema","fields":[]}
+  ]
+}}}`
+
+func newTestServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Query         string `json:"query"`
+			OperationName string `json:"operationName"`
+		}
+
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			http.Error(w, "expected a JSON POST", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if payload.OperationName != "IntrospectionQuery" {
+			w.Write([]byte(`{"errors":[{"message":"introspection is disabled"}]}`))
+			return
+		}
+		w.Write([]byte(testSchema))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func loadTestSchema(t *testing.T) *Schema {
+	schema, err := Parse([]byte(testSchema))
+	require.NoError(t, err)
+	return schema
+}
+
+func TestIntrospect(t *testing.T) {
+	t.Run("should fetch and parse the schema", func(t *testing.T) {
+		server := newTestServer(t)
+		model := request.NewModel().SetMethod(request.GET).SetURL(server.URL)
+
+		schema, err := Introspect(model)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Query", schema.QueryType)
+		assert.Equal(t, "Mutation", schema.MutationType)
+		assert.Empty(t, schema.SubscriptionType)
+		assert.Equal(t, request.GET, model.Method)
+
+		user, ok := schema.Type("User")
+		require.True(t, ok)
+		assert.Equal(t, "A registered user", user.Description)
+		assert.Len(t, user.Fields, 5)
+		assert.True(t, user.Fields[2].Deprecated)
+	})
+
+	t.Run("should return an error on a failed status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		_, err := Introspect(request.NewModel().SetURL(server.URL))
+
+		assert.ErrorIs(t, err, ErrIntrospection)
+		assert.ErrorContains(t, err, "status 401")
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("should surface GraphQL errors", func(t *testing.T) {
+		_, err := Parse([]byte(`{"errors":[{"message":"introspection is disabled"}]}`))
+
+		assert.ErrorIs(t, err, ErrIntrospection)
+		assert.ErrorContains(t, err, "introspection is disabled")
+	})
+
+	t.Run("should reject a response without a schema", func(t *testing.T) {
+		_, err := Parse([]byte(`{"data":{}}`))
+
+		assert.ErrorContains(t, err, "no schema in response")
+	})
+
+	t.Run("should reject invalid JSON", func(t *testing.T) {
+		_, err := Parse([]byte("<html>"))
+
+		assert.ErrorIs(t, err, ErrIntrospection)
+	})
+
+	t.Run("should keep enum values and default values", func(t *testing.T) {
+		schema := loadTestSchema(t)
+
+		role, _ := schema.Type("Role")
+		users, _ := schema.Field("Query", "users")
+
+		assert.Equal(t, []string{"ADMIN", "MEMBER"}, role.EnumValues)
+		assert.Equal(t, "10", users.Args[0].DefaultValue)
+	})
+}
+
+func TestSchema(t *testing.T) {
+	schema := loadTestSchema(t)
+
+	t.Run("should render type references", func(t *testing.T) {
+		users, _ := schema.Field("Query", "users")
+
+		assert.Equal(t, "[User!]!", users.Type.String())
+		assert.Equal(t, "User", users.Type.Named())
+		assert.Equal(t, "users(first: Int): [User!]!", users.Signature())
+	})
+
+	t.Run("should list root types first and hide introspection types", func(t *testing.T) {
+		assert.Equal(t, []string{"Query", "Mutation", "ID", "Int", "Post", "Role", "String", "User"}, schema.TypeNames())
+	})
+}
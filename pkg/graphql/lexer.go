@@ -0,0 +1,84 @@
+package graphql
+
+import "strings"
+
+type tokenKind int
+
+const (
+	tokenName tokenKind = iota
+	tokenPunct
+	tokenString
+	tokenNumber
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func lex(src string) []token {
+	var tokens []token
+
+	for i := 0; i < len(src); {
+		c := src[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				return tokens
+			}
+			i += end
+		case strings.HasPrefix(src[i:], `"""`):
+			end := strings.Index(src[i+3:], `"""`)
+			if end < 0 {
+				return append(tokens, token{tokenString, src[i:]})
+			}
+			tokens = append(tokens, token{tokenString, src[i : i+end+6]})
+			i += end + 6
+		case c == '"':
+			j := i + 1
+			for j < len(src) && src[j] != '"' && src[j] != '\n' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			j = min(j+1, len(src))
+			tokens = append(tokens, token{tokenString, src[i:j]})
+			i = j
+		case strings.HasPrefix(src[i:], "..."):
+			tokens = append(tokens, token{tokenPunct, "..."})
+			i += 3
+		case isNameStart(c):
+			j := i + 1
+			for j < len(src) && isNameChar(src[j]) {
+				j++
+			}
+			tokens = append(tokens, token{tokenName, src[i:j]})
+			i = j
+		case c == '-' || (c >= '0' && c <= '9'):
+			j := i + 1
+			for j < len(src) && (isNameChar(src[j]) || src[j] == '.' || src[j] == '+' || src[j] == '-') {
+				j++
+			}
+			tokens = append(tokens, token{tokenNumber, src[i:j]})
+			i = j
+		default:
+			tokens = append(tokens, token{tokenPunct, string(c)})
+			i++
+		}
+	}
+
+	return tokens
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,92 @@
+package graphql
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+func (t TypeRef) String() string {
+	switch t.Kind {
+	case KindNonNull:
+		if t.OfType != nil {
+			return t.OfType.String() + "!"
+		}
+	case KindList:
+		if t.OfType != nil {
+			return "[" + t.OfType.String() + "]"
+		}
+	}
+	return t.Name
+}
+
+func (t TypeRef) Named() string {
+	if t.OfType != nil && (t.Kind == KindNonNull || t.Kind == KindList) {
+		return t.OfType.Named()
+	}
+	return t.Name
+}
+
+func (f Field) Signature() string {
+	if len(f.Args) == 0 {
+		return f.Name + ": " + f.Type.String()
+	}
+
+	args := make([]string, len(f.Args))
+	for i, arg := range f.Args {
+		args[i] = arg.Name + ": " + arg.Type.String()
+	}
+
+	return f.Name + "(" + strings.Join(args, ", ") + "): " + f.Type.String()
+}
+
+func (s *Schema) Type(name string) (*Type, bool) {
+	t, ok := s.Types[name]
+	return t, ok
+}
+
+func (s *Schema) Field(typeName, fieldName string) (Field, bool) {
+	t, ok := s.Types[typeName]
+	if !ok {
+		return Field{}, false
+	}
+
+	for _, f := range t.Fields {
+		if f.Name == fieldName {
+			return f, true
+		}
+	}
+
+	return Field{}, false
+}
+
+func (s *Schema) TypeNames() []string {
+	var roots, others []string
+
+	for _, name := range []string{s.QueryType, s.MutationType, s.SubscriptionType} {
+		if _, ok := s.Types[name]; ok && name != "" {
+			roots = append(roots, name)
+		}
+	}
+
+	for name := range s.Types {
+		if strings.HasPrefix(name, "__") || slices.Contains(roots, name) {
+			continue
+		}
+		others = append(others, name)
+	}
+	sort.Strings(others)
+
+	return append(roots, others...)
+}
+
+func (s *Schema) rootType(operation string) string {
+	switch operation {
+	case "mutation":
+		return s.MutationType
+	case "subscription":
+		return s.SubscriptionType
+	default:
+		return s.QueryType
+	}
+}
@@ -0,0 +1,102 @@
+package graphql
+
+import "errors"
+
+var ErrIntrospection = errors.New("introspection failed")
+
+const (
+	KindScalar      = "SCALAR"
+	KindObject      = "OBJECT"
+	KindInterface   = "INTERFACE"
+	KindUnion       = "UNION"
+	KindEnum        = "ENUM"
+	KindInputObject = "INPUT_OBJECT"
+	KindList        = "LIST"
+	KindNonNull     = "NON_NULL"
+)
+
+const IntrospectionQuery = `query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types {
+      kind
+      name
+      description
+      fields(includeDeprecated: true) {
+        name
+        description
+        args { ...InputValue }
+        type { ...TypeRef }
+        isDeprecated
+      }
+      inputFields { ...InputValue }
+      enumValues(includeDeprecated: true) { name }
+      possibleTypes { name }
+    }
+  }
+}
+
+fragment InputValue on __InputValue {
+  name
+  description
+  type { ...TypeRef }
+  defaultValue
+}
+
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType { kind name }
+      }
+    }
+  }
+}`
+
+type TypeRef struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	OfType *TypeRef `json:"ofType"`
+}
+
+type InputValue struct {
+	Name         string
+	Description  string
+	Type         TypeRef
+	DefaultValue string
+}
+
+type Field struct {
+	Name        string
+	Description string
+	Args        []InputValue
+	Type        TypeRef
+	Deprecated  bool
+}
+
+type Type struct {
+	Name          string
+	Kind          string
+	Description   string
+	Fields        []Field
+	InputFields   []InputValue
+	EnumValues    []string
+	PossibleTypes []string
+}
+
+type Schema struct {
+	QueryType        string
+	MutationType     string
+	SubscriptionType string
+	Types            map[string]*Type
+}
@@ -0,0 +1,264 @@
+package har
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+const timeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+func FromRequests(requests []*storage.Request) *HAR {
+	entries := make([]Entry, 0, len(requests))
+
+	for _, r := range requests {
+		entries = append(entries, Entry{
+			StartedDateTime: r.UpdatedAt.Format(timeFormat),
+			Request:         exportRequest(r),
+			Response:        emptyResponse(),
+		})
+	}
+
+	return newHAR(entries)
+}
+
+func FromHistory(history []*storage.HistoryEntry) *HAR {
+	entries := make([]Entry, 0, len(history))
+
+	for _, h := range history {
+		if h.Request == nil {
+			continue
+		}
+
+		entry := Entry{
+			StartedDateTime: h.SentAt.Format(timeFormat),
+			Request:         exportRequest(h.Request),
+			Response:        emptyResponse(),
+			Comment:         h.Error,
+		}
+
+		if h.Response != nil {
+			entry.Response = exportResponse(h.Response)
+			entry.Time = float64(h.Response.TimeTaken)
+			entry.Timings.Wait = float64(h.Response.TimeTaken)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return newHAR(entries)
+}
+
+func exportRequest(r *storage.Request) Request {
+	req := Request{
+		Method:      r.Method,
+		URL:         r.URL,
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []Cookie{},
+		Headers:     []NameValue{},
+		QueryString: []NameValue{},
+		HeadersSize: -1,
+		BodySize:    0,
+	}
+
+	for _, h := range r.Headers {
+		if !h.Disabled {
+			req.Headers = append(req.Headers, NameValue{Name: h.Key, Value: h.Value})
+		}
+	}
+
+	if u, err := url.Parse(r.URL); err == nil {
+		query := u.Query()
+		queryKeys := make([]string, 0, len(query))
+		for key := range query {
+			queryKeys = append(queryKeys, key)
+		}
+		sort.Strings(queryKeys)
+
+		for _, key := range queryKeys {
+			for _, value := range query[key] {
+				req.QueryString = append(req.QueryString, NameValue{Name: key, Value: value})
+			}
+		}
+	}
+
+	req.PostData = exportBody(r)
+	if req.PostData != nil {
+		req.BodySize = int64(len(req.PostData.Text))
+	}
+
+	return req
+}
+
+func exportBody(r *storage.Request) *PostData {
+	if len(r.Form) > 0 {
+		switch request.ParseBodyType(r.BodyType) {
+		case request.BodyTypeFormData:
+			return &PostData{MimeType: "multipart/form-data", Params: exportForm(r.Form)}
+		case request.BodyTypeURLEncoded:
+			return exportURLEncoded(exportForm(r.Form))
+		}
+	}
+
+	if r.Body == "" {
+		return nil
+	}
+
+	bodyType := request.ParseBodyType(r.BodyType)
+
+	switch bodyType {
+	case request.BodyTypeJSON:
+		return &PostData{MimeType: "application/json", Text: r.Body}
+	case request.BodyTypeGraphQL:
+		reader, _, err := request.EncodeBody(r.Body, bodyType)
+		if err != nil {
+			return &PostData{MimeType: "application/json", Text: r.Body}
+		}
+		text, _ := io.ReadAll(reader)
+
+		return &PostData{MimeType: "application/json", Text: string(text)}
+	case request.BodyTypeURLEncoded, request.BodyTypeFormData:
+		fields, err := request.ParseFormFields(r.Body)
+		if err != nil {
+			return &PostData{MimeType: r.Headers.Get("Content-Type"), Text: r.Body}
+		}
+
+		params := make([]Param, 0, len(fields))
+		for _, f := range fields {
+			params = append(params, Param{Name: f.Key, Value: f.Value})
+		}
+
+		if bodyType == request.BodyTypeFormData {
+			return &PostData{MimeType: "multipart/form-data", Params: params}
+		}
+
+		reader, contentType, err := request.EncodeBody(r.Body, bodyType)
+		if err != nil {
+			return &PostData{MimeType: contentType, Params: params}
+		}
+		text, _ := io.ReadAll(reader)
+
+		return &PostData{MimeType: contentType, Params: params, Text: string(text)}
+	case request.BodyTypeText, request.BodyTypeXML, request.BodyTypeHTML, request.BodyTypeCustom:
+		contentType := r.Headers.Get("Content-Type")
+		if contentType == "" {
+			contentType = bodyType.ContentType()
+		}
+
+		return &PostData{MimeType: contentType, Text: r.Body}
+	case request.BodyTypeBinary:
+		return &PostData{MimeType: bodyType.ContentType()}
+	}
+
+	if r.BodyType == request.BodyTypeNone.String() {
+		return nil
+	}
+
+	return &PostData{MimeType: r.Headers.Get("Content-Type"), Text: r.Body}
+}
+
+func exportForm(form []storage.FormField) []Param {
+	params := make([]Param, 0, len(form))
+
+	for _, f := range form {
+		if f.Disabled {
+			continue
+		}
+
+		if !f.IsFile() {
+			params = append(params, Param{Name: f.Key, Value: f.Value})
+			continue
+		}
+
+		filename := f.Filename
+		if filename == "" {
+			filename = filepath.Base(f.Value)
+		}
+
+		params = append(params, Param{Name: f.Key, FileName: filename, ContentType: f.ContentType})
+	}
+
+	return params
+}
+
+func exportURLEncoded(params []Param) *PostData {
+	fields := make([]request.FormField, 0, len(params))
+	text := make([]Param, 0, len(params))
+
+	for _, p := range params {
+		if p.FileName != "" {
+			continue
+		}
+		fields = append(fields, request.FormField{Key: p.Name, Value: p.Value})
+		text = append(text, p)
+	}
+
+	return &PostData{
+		MimeType: request.BodyTypeURLEncoded.ContentType(),
+		Params:   text,
+		Text:     request.EncodeURLEncoded(fields),
+	}
+}
+
+func exportResponse(r *storage.HistoryResponse) Response {
+	res := Response{
+		Status:      r.StatusCode,
+		StatusText:  http.StatusText(r.StatusCode),
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []Cookie{},
+		Headers:     []NameValue{},
+		HeadersSize: -1,
+		BodySize:    int64(len(r.Body)),
+	}
+
+	keys := make([]string, 0, len(r.Headers))
+	for key := range r.Headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var contentType string
+	for _, key := range keys {
+		for _, value := range r.Headers[key] {
+			res.Headers = append(res.Headers, NameValue{Name: key, Value: value})
+			if strings.EqualFold(key, "Content-Type") && contentType == "" {
+				contentType = value
+			}
+		}
+
+		if strings.EqualFold(key, "Location") && len(r.Headers[key]) > 0 {
+			res.RedirectURL = r.Headers[key][0]
+		}
+	}
+
+	res.Content = Content{
+		Size:     int64(len(r.Body)),
+		MimeType: contentType,
+		Text:     r.Body,
+	}
+
+	if !utf8.ValidString(r.Body) {
+		res.Content.Text = base64.StdEncoding.EncodeToString([]byte(r.Body))
+		res.Content.Encoding = "base64"
+	}
+
+	return res
+}
+
+func emptyResponse() Response {
+	return Response{
+		Cookies:     []Cookie{},
+		Headers:     []NameValue{},
+		Content:     Content{MimeType: "x-unknown"},
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+}
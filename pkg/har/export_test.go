@@ -0,0 +1,159 @@
+package har
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromRequests(t *testing.T) {
+	t.Run("should export requests without responses", func(t *testing.T) {
+		h := FromRequests([]*storage.Request{
+			{
+				Method: "POST",
+				URL:    "http://localhost/items?b=2&a=1",
+				Headers: storage.Headers{
+					{Key: "X-B", Value: "2"},
+					{Key: "X-A", Value: "1"},
+					{Key: "X-Debug", Value: "1", Disabled: true},
+					{Key: "X-B", Value: "3"},
+				},
+				Body:     `{"name":"x"}`,
+				BodyType: "urlencoded",
+			},
+		})
+
+		require.Len(t, h.Log.Entries, 1)
+		entry := h.Log.Entries[0]
+
+		assert.Equal(t, Version, h.Log.Version)
+		assert.Equal(t, creatorName, h.Log.Creator.Name)
+		assert.Equal(t, []NameValue{{"X-B", "2"}, {"X-A", "1"}, {"X-B", "3"}}, entry.Request.Headers)
+		assert.Equal(t, []NameValue{{"a", "1"}, {"b", "2"}}, entry.Request.QueryString)
+		assert.Equal(t, "application/x-www-form-urlencoded", entry.Request.PostData.MimeType)
+		assert.Equal(t, "name=x", entry.Request.PostData.Text)
+		assert.Equal(t, 0, entry.Response.Status)
+	})
+
+	t.Run("should return an empty entry list", func(t *testing.T) {
+		h := FromRequests(nil)
+
+		assert.NotNil(t, h.Log.Entries)
+		assert.Empty(t, h.Log.Entries)
+	})
+
+	t.Run("should not export a body on none", func(t *testing.T) {
+		h := FromRequests([]*storage.Request{
+			{Method: "GET", URL: "http://localhost", Body: "leftover", BodyType: "none"},
+		})
+
+		assert.Nil(t, h.Log.Entries[0].Request.PostData)
+	})
+
+	t.Run("should export raw bodies with their content type", func(t *testing.T) {
+		h := FromRequests([]*storage.Request{
+			{Method: "POST", URL: "http://localhost", Body: "<a/>", BodyType: "xml"},
+		})
+
+		assert.Equal(t, &PostData{MimeType: "application/xml", Text: "<a/>"}, h.Log.Entries[0].Request.PostData)
+	})
+
+	t.Run("should export file fields as params", func(t *testing.T) {
+		h := FromRequests([]*storage.Request{{
+			Method:   "POST",
+			URL:      "http://localhost",
+			BodyType: "form-data",
+			Form: []storage.FormField{
+				{Key: "name", Value: "bob"},
+				{Key: "avatar", Value: "/tmp/me.png", Type: storage.FormFieldFile, ContentType: "image/png"},
+			},
+		}})
+
+		assert.Equal(t, &PostData{
+			MimeType: "multipart/form-data",
+			Params: []Param{
+				{Name: "name", Value: "bob"},
+				{Name: "avatar", FileName: "me.png", ContentType: "image/png"},
+			},
+		}, h.Log.Entries[0].Request.PostData)
+	})
+
+	t.Run("should export url-encoded fields in order without disabled ones", func(t *testing.T) {
+		h := FromRequests([]*storage.Request{{
+			Method:   "POST",
+			URL:      "http://localhost",
+			BodyType: "urlencoded",
+			Form: []storage.FormField{
+				{Key: "tag", Value: "b"},
+				{Key: "tag", Value: "a b"},
+				{Key: "debug", Value: "1", Disabled: true},
+			},
+		}})
+
+		assert.Equal(t, &PostData{
+			MimeType: "application/x-www-form-urlencoded",
+			Params: []Param{
+				{Name: "tag", Value: "b"},
+				{Name: "tag", Value: "a b"},
+			},
+			Text: "tag=b&tag=a+b",
+		}, h.Log.Entries[0].Request.PostData)
+	})
+}
+
+func TestFromHistory(t *testing.T) {
+	t.Run("should export responses and timings", func(t *testing.T) {
+		sentAt := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+
+		h := FromHistory([]*storage.HistoryEntry{
+			{
+				Request: &storage.Request{Method: "GET", URL: "http://localhost"},
+				Response: &storage.HistoryResponse{
+					StatusCode: 301,
+					Headers: map[string][]string{
+						"Content-Type": {"text/plain"},
+						"Location":     {"/new"},
+					},
+					Body:      "moved",
+					TimeTaken: 120,
+				},
+				SentAt: sentAt,
+			},
+			{
+				Request: &storage.Request{Method: "GET", URL: "http://down"},
+				Error:   "connection refused",
+				SentAt:  sentAt,
+			},
+		})
+
+		require.Len(t, h.Log.Entries, 2)
+
+		ok := h.Log.Entries[0]
+		assert.Equal(t, "2026-01-02T10:00:00.000Z", ok.StartedDateTime)
+		assert.Equal(t, 120.0, ok.Time)
+		assert.Equal(t, 120.0, ok.Timings.Wait)
+		assert.Equal(t, 301, ok.Response.Status)
+		assert.Equal(t, "Moved Permanently", ok.Response.StatusText)
+		assert.Equal(t, "/new", ok.Response.RedirectURL)
+		assert.Equal(t, Content{Size: 5, MimeType: "text/plain", Text: "moved"}, ok.Response.Content)
+
+		failed := h.Log.Entries[1]
+		assert.Equal(t, "connection refused", failed.Comment)
+		assert.Equal(t, 0, failed.Response.Status)
+	})
+
+	t.Run("should base64 encode binary bodies", func(t *testing.T) {
+		h := FromHistory([]*storage.HistoryEntry{
+			{
+				Request:  &storage.Request{Method: "GET", URL: "http://localhost"},
+				Response: &storage.HistoryResponse{StatusCode: 200, Body: "\xff\xfe"},
+			},
+		})
+
+		assert.Equal(t, "base64", h.Log.Entries[0].Response.Content.Encoding)
+		assert.Equal(t, "//4=", h.Log.Entries[0].Response.Content.Text)
+	})
+}
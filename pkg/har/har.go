@@ -0,0 +1,43 @@
+package har
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+func Decode(r io.Reader) (*HAR, error) {
+	var h HAR
+
+	if err := json.NewDecoder(r).Decode(&h); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidHAR, err)
+	}
+
+	if h.Log.Version == "" && h.Log.Entries == nil {
+		return nil, fmt.Errorf("%w: missing log", ErrInvalidHAR)
+	}
+
+	return &h, nil
+}
+
+func Encode(w io.Writer, h *HAR) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+
+	return encoder.Encode(h)
+}
+
+func newHAR(entries []Entry) *HAR {
+	if entries == nil {
+		entries = []Entry{}
+	}
+
+	return &HAR{
+		Log: Log{
+			Version: Version,
+			Creator: Creator{Name: creatorName, Version: Version},
+			Entries: entries,
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package har
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadFixture(t *testing.T) *HAR {
+	f, err := os.Open("testdata/sample.har")
+	require.NoError(t, err)
+	defer f.Close()
+
+	h, err := Decode(f)
+	require.NoError(t, err)
+
+	return h
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("should decode a HAR file", func(t *testing.T) {
+		h := loadFixture(t)
+
+		assert.Equal(t, "1.2", h.Log.Version)
+		assert.Len(t, h.Log.Entries, 6)
+		assert.Equal(t, 42.5, h.Log.Entries[0].Time)
+	})
+
+	t.Run("should return ErrInvalidHAR on invalid JSON", func(t *testing.T) {
+		h, err := Decode(strings.NewReader("not a HAR"))
+
+		assert.Nil(t, h)
+		assert.ErrorIs(t, err, ErrInvalidHAR)
+	})
+
+	t.Run("should return ErrInvalidHAR without log", func(t *testing.T) {
+		h, err := Decode(strings.NewReader(`{"foo":"bar"}`))
+
+		assert.Nil(t, h)
+		assert.ErrorIs(t, err, ErrInvalidHAR)
+	})
+}
+
+func TestEncode(t *testing.T) {
+	t.Run("should encode a HAR that decodes back", func(t *testing.T) {
+		h := loadFixture(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, Encode(&buf, h))
+
+		decoded, err := Decode(&buf)
+
+		assert.NoError(t, err)
+		assert.Equal(t, h, decoded)
+	})
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Run("should keep requests identical through export and import", func(t *testing.T) {
+		requests := ToRequests(loadFixture(t), false)
+
+		var buf bytes.Buffer
+		require.NoError(t, Encode(&buf, FromRequests(requests)))
+
+		decoded, err := Decode(&buf)
+		require.NoError(t, err)
+
+		assert.Equal(t, requests, ToRequests(decoded, false))
+	})
+}
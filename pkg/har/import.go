@@ -0,0 +1,146 @@
+package har
+
+import (
+	"mime"
+	"net/url"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+var skippedHeaders = map[string]bool{
+	"content-length":    true,
+	"host":              true,
+	"connection":        true,
+	"transfer-encoding": true,
+}
+
+func ToRequests(h *HAR, dedupe bool) []*storage.Request {
+	var result []*storage.Request
+	seen := make(map[string]bool)
+
+	for _, entry := range h.Log.Entries {
+		req := entry.Request
+		if req.URL == "" {
+			continue
+		}
+
+		method := strings.ToUpper(req.Method)
+		if method == "" {
+			method = "GET"
+		}
+
+		key := method + " " + req.URL
+		if dedupe && seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		r := &storage.Request{
+			Name:   requestName(method, req.URL),
+			Method: method,
+			URL:    req.URL,
+		}
+
+		body, form, bodyType := importBody(req.PostData)
+		r.Body = body
+		r.Form = form
+		r.BodyType = bodyType
+
+		r.Headers = importHeaders(req.Headers, bodyType == request.BodyTypeFormData.String())
+
+		result = append(result, r)
+	}
+
+	return result
+}
+
+func requestName(method, rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return method + " " + rawURL
+	}
+
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+
+	return method + " " + u.Host + path
+}
+
+func importHeaders(headers []NameValue, dropContentType bool) storage.Headers {
+	var result storage.Headers
+
+	for _, h := range headers {
+		lower := strings.ToLower(h.Name)
+		if strings.HasPrefix(h.Name, ":") || skippedHeaders[lower] {
+			continue
+		}
+
+		if dropContentType && lower == "content-type" {
+			continue
+		}
+
+		result = append(result, storage.Header{Key: h.Name, Value: h.Value})
+	}
+
+	return result
+}
+
+func importBody(data *PostData) (string, []storage.FormField, string) {
+	if data == nil || (data.Text == "" && len(data.Params) == 0) {
+		return "", nil, request.BodyTypeNone.String()
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(data.MimeType)
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return data.Text, nil, request.BodyTypeJSON.String()
+	case mediaType == "application/x-www-form-urlencoded":
+		fields, err := request.DecodeURLEncoded(data.Text)
+		if len(data.Params) > 0 || err != nil {
+			return "", importParams(data.Params), request.BodyTypeURLEncoded.String()
+		}
+		return "", importForm(fields), request.BodyTypeURLEncoded.String()
+	case mediaType == "multipart/form-data":
+		fields, err := request.DecodeMultipart(data.Text, params["boundary"])
+		if len(data.Params) > 0 || err != nil {
+			return "", importParams(data.Params), request.BodyTypeFormData.String()
+		}
+		return "", importForm(fields), request.BodyTypeFormData.String()
+	}
+
+	return data.Text, nil, request.RawBodyType(data.MimeType).String()
+}
+
+func importParams(params []Param) []storage.FormField {
+	form := []storage.FormField{}
+
+	for _, p := range params {
+		if p.FileName != "" {
+			continue
+		}
+		form = append(form, storage.FormField{Key: p.Name, Value: p.Value})
+	}
+
+	return form
+}
+
+func importForm(fields []request.FormField) []storage.FormField {
+	form := make([]storage.FormField, 0, len(fields))
+
+	for _, f := range fields {
+		field := storage.FormField{Key: f.Key, Value: f.Value}
+		if f.File {
+			field.Type = storage.FormFieldFile
+			field.ContentType = f.ContentType
+			field.Filename = f.Filename
+		}
+		form = append(form, field)
+	}
+
+	return form
+}
@@ -0,0 +1,73 @@
+package har
+
+import (
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToRequests(t *testing.T) {
+	t.Run("should convert every entry without dedupe", func(t *testing.T) {
+		requests := ToRequests(loadFixture(t), false)
+
+		assert.Len(t, requests, 6)
+	})
+
+	t.Run("should dedupe by method and URL", func(t *testing.T) {
+		requests := ToRequests(loadFixture(t), true)
+
+		require.Len(t, requests, 5)
+		assert.Equal(t, "application/json", requests[0].Headers.Get("Accept"))
+	})
+
+	t.Run("should clean up headers and keep repeated ones in order", func(t *testing.T) {
+		requests := ToRequests(loadFixture(t), true)
+
+		headers := requests[0].Headers
+		assert.Equal(t, storage.Headers{
+			{Key: "Accept", Value: "application/json"},
+			{Key: "Cookie", Value: "a=1"},
+			{Key: "Cookie", Value: "b=2"},
+		}, headers)
+		assert.Equal(t, "GET api.example.com/users", requests[0].Name)
+	})
+
+	t.Run("should map JSON bodies", func(t *testing.T) {
+		req := ToRequests(loadFixture(t), true)[1]
+
+		assert.Equal(t, "json", req.BodyType)
+		assert.Equal(t, `{"name":"Alice"}`, req.Body)
+		assert.Empty(t, req.Headers.Get("Content-Length"))
+	})
+
+	t.Run("should map url-encoded text to ordered form fields", func(t *testing.T) {
+		req := ToRequests(loadFixture(t), true)[2]
+
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(t, "urlencoded", req.BodyType)
+		assert.Empty(t, req.Body)
+		assert.Equal(t, []storage.FormField{
+			{Key: "user", Value: "alice"},
+			{Key: "pass", Value: "s3cr&t"},
+		}, req.Form)
+	})
+
+	t.Run("should map multipart params and skip files", func(t *testing.T) {
+		req := ToRequests(loadFixture(t), true)[3]
+
+		assert.Equal(t, "form-data", req.BodyType)
+		assert.Equal(t, []storage.FormField{{Key: "title", Value: "me"}}, req.Form)
+		assert.Empty(t, req.Headers.Get("Content-Type"))
+	})
+
+	t.Run("should keep other bodies as raw text", func(t *testing.T) {
+		req := ToRequests(loadFixture(t), true)[4]
+
+		assert.Equal(t, "text", req.BodyType)
+		assert.Equal(t, "hello", req.Body)
+		assert.Equal(t, "text/plain", req.Headers.Get("Content-Type"))
+	})
+}
@@ -0,0 +1,281 @@
+package httpfile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+type block struct {
+	title string
+	start int
+	lines []string
+}
+
+func Parse(r io.Reader) (*File, error) {
+	blocks, err := splitBlocks(r)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &File{Variables: make(map[string]string)}
+
+	for _, b := range blocks {
+		req, err := parseBlock(b, file.Variables)
+		if err != nil {
+			return nil, err
+		}
+
+		if req != nil {
+			file.Requests = append(file.Requests, req)
+		}
+	}
+
+	return file, nil
+}
+
+func splitBlocks(r io.Reader) ([]block, error) {
+	var blocks []block
+	current := block{start: 1}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+
+		if separatorLine.MatchString(line) {
+			blocks = append(blocks, current)
+			current = block{
+				title: strings.TrimSpace(strings.TrimLeft(line, "#")),
+				start: lineNumber + 1,
+			}
+			continue
+		}
+
+		current.lines = append(current.lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return append(blocks, current), nil
+}
+
+func isComment(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "//")
+}
+
+func parseBlock(b block, vars map[string]string) (*storage.Request, error) {
+	name := b.title
+	i := 0
+
+	for ; i < len(b.lines); i++ {
+		line := strings.TrimSpace(b.lines[i])
+
+		if line == "" {
+			continue
+		}
+
+		if match := nameComment.FindStringSubmatch(line); match != nil {
+			name = match[1]
+			continue
+		}
+
+		if isComment(line) {
+			continue
+		}
+
+		if match := variableLine.FindStringSubmatch(line); match != nil {
+			vars[match[1]] = match[2]
+			continue
+		}
+
+		break
+	}
+
+	if i == len(b.lines) {
+		return nil, nil
+	}
+
+	method, rawURL, err := parseRequestLine(b.lines[i])
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", b.start+i, err)
+	}
+	i++
+
+	for ; i < len(b.lines); i++ {
+		line := b.lines[i]
+		trimmed := strings.TrimSpace(line)
+		if line == trimmed || !(strings.HasPrefix(trimmed, "?") || strings.HasPrefix(trimmed, "&")) {
+			break
+		}
+		rawURL += trimmed
+	}
+
+	var headers storage.Headers
+	for ; i < len(b.lines); i++ {
+		line := strings.TrimSpace(b.lines[i])
+		if line == "" {
+			i++
+			break
+		}
+
+		if isComment(line) {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("line %d: %w: malformed header %q", b.start+i, ErrInvalidRequest, line)
+		}
+		headers = append(headers, storage.Header{Key: strings.TrimSpace(key), Value: strings.TrimSpace(value)})
+	}
+
+	if name == "" {
+		name = defaultName(method, rawURL)
+	}
+
+	req := &storage.Request{
+		Name:    name,
+		Method:  method,
+		URL:     rawURL,
+		Headers: headers,
+	}
+
+	if err := setBody(req, bodyLines(b.lines[i:])); err != nil {
+		return nil, fmt.Errorf("line %d: %w", b.start+i, err)
+	}
+
+	return req, nil
+}
+
+func parseRequestLine(line string) (string, string, error) {
+	line = httpVersion.ReplaceAllString(strings.TrimSpace(line), "")
+
+	first, rest, found := strings.Cut(line, " ")
+	if found && methods[strings.ToUpper(first)] {
+		rawURL := strings.TrimSpace(rest)
+		if rawURL == "" {
+			return "", "", fmt.Errorf("%w: missing URL", ErrInvalidRequest)
+		}
+		return strings.ToUpper(first), rawURL, nil
+	}
+
+	if found {
+		return "", "", fmt.Errorf("%w: unknown method %q", ErrInvalidRequest, first)
+	}
+
+	return "GET", line, nil
+}
+
+func bodyLines(lines []string) []string {
+	var body []string
+	inHandler := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case inHandler:
+			if strings.HasSuffix(trimmed, "%}") {
+				inHandler = false
+			}
+			continue
+		case strings.HasPrefix(trimmed, "> {%"):
+			inHandler = !strings.HasSuffix(trimmed, "%}")
+			continue
+		case strings.HasPrefix(trimmed, "<> ") || strings.HasPrefix(trimmed, ">> "):
+			continue
+		}
+
+		body = append(body, line)
+	}
+
+	for len(body) > 0 && strings.TrimSpace(body[len(body)-1]) == "" {
+		body = body[:len(body)-1]
+	}
+
+	return body
+}
+
+func setBody(req *storage.Request, lines []string) error {
+	body := strings.Join(lines, "\n")
+	if body == "" {
+		req.BodyType = request.BodyTypeNone.String()
+		return nil
+	}
+
+	contentType := req.Headers.Get("Content-Type")
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		req.Body = body
+		req.BodyType = request.BodyTypeJSON.String()
+	case mediaType == "application/x-www-form-urlencoded":
+		fields, err := request.DecodeURLEncoded(strings.Join(lines, ""))
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+		}
+		req.Form = parseForm(fields)
+		req.BodyType = request.BodyTypeURLEncoded.String()
+	case mediaType == "multipart/form-data":
+		fields, err := request.DecodeMultipart(body, params["boundary"])
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+		}
+		req.Headers = slices.DeleteFunc(req.Headers, func(h storage.Header) bool {
+			return strings.EqualFold(h.Key, "Content-Type")
+		})
+		if len(req.Headers) == 0 {
+			req.Headers = nil
+		}
+		req.Form = parseForm(fields)
+		req.BodyType = request.BodyTypeFormData.String()
+	case len(lines) == 1 && strings.HasPrefix(lines[0], "< "):
+		req.Body = strings.TrimSpace(strings.TrimPrefix(lines[0], "< "))
+		req.BodyType = request.BodyTypeBinary.String()
+	default:
+		req.Body = body
+		req.BodyType = request.RawBodyType(contentType).String()
+	}
+
+	return nil
+}
+
+func defaultName(method, rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Path == "" {
+		return method + " " + rawURL
+	}
+
+	return method + " " + u.Path
+}
+
+func parseForm(fields []request.FormField) []storage.FormField {
+	form := make([]storage.FormField, 0, len(fields))
+
+	for _, f := range fields {
+		field := storage.FormField{Key: f.Key, Value: f.Value}
+		if f.File {
+			field.Type = storage.FormFieldFile
+			field.ContentType = f.ContentType
+			field.Filename = f.Filename
+		}
+		form = append(form, field)
+	}
+
+	return form
+}
@@ -0,0 +1,143 @@
+package httpfile
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadFixture(t *testing.T) *File {
+	f, err := os.Open("testdata/sample.http")
+	require.NoError(t, err)
+	defer f.Close()
+
+	file, err := Parse(f)
+	require.NoError(t, err)
+
+	return file
+}
+
+func TestParse(t *testing.T) {
+	t.Run("should collect file variables", func(t *testing.T) {
+		file := loadFixture(t)
+
+		assert.Equal(t, map[string]string{
+			"host":  "https://api.example.com",
+			"token": "abc123",
+			"id":    "42",
+		}, file.Variables)
+	})
+
+	t.Run("should parse every request", func(t *testing.T) {
+		file := loadFixture(t)
+
+		require.Len(t, file.Requests, 6)
+	})
+
+	t.Run("should parse the request line, multiline query and headers", func(t *testing.T) {
+		req := loadFixture(t).Requests[0]
+
+		assert.Equal(t, "List users", req.Name)
+		assert.Equal(t, "GET", req.Method)
+		assert.Equal(t, "{{host}}/users?page=2&sort=name", req.URL)
+		assert.Equal(t, storage.Headers{
+			{Key: "Accept", Value: "application/json"},
+			{Key: "Authorization", Value: "Bearer {{token}}"},
+		}, req.Headers)
+		assert.Equal(t, "none", req.BodyType)
+	})
+
+	t.Run("should use @name and drop response handlers", func(t *testing.T) {
+		req := loadFixture(t).Requests[1]
+
+		assert.Equal(t, "createUser", req.Name)
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(t, "{{host}}/users", req.URL)
+		assert.Equal(t, "json", req.BodyType)
+		assert.Equal(t, "{\n  \"name\": \"Alice\",\n  \"age\": 30\n}", req.Body)
+	})
+
+	t.Run("should decode url-encoded bodies", func(t *testing.T) {
+		req := loadFixture(t).Requests[2]
+
+		assert.Equal(t, "urlencoded", req.BodyType)
+		assert.Empty(t, req.Body)
+		assert.Equal(t, []storage.FormField{
+			{Key: "user", Value: "alice"},
+			{Key: "pass", Value: "s3cr&t"},
+		}, req.Form)
+	})
+
+	t.Run("should decode multipart bodies", func(t *testing.T) {
+		req := loadFixture(t).Requests[3]
+
+		assert.Equal(t, "form-data", req.BodyType)
+		assert.Equal(t, []storage.FormField{
+			{Key: "title", Value: "me"},
+			{Key: "file", Value: "./me.png", Type: storage.FormFieldFile},
+		}, req.Form)
+		assert.Nil(t, req.Headers)
+	})
+
+	t.Run("should name requests without title", func(t *testing.T) {
+		requests := loadFixture(t).Requests
+
+		assert.Equal(t, "DELETE {{host}}/users/{{id}}", requests[4].Name)
+		assert.Equal(t, "GET", requests[5].Method)
+		assert.Equal(t, "https://example.com/health", requests[5].URL)
+	})
+
+	t.Run("should type raw and file bodies", func(t *testing.T) {
+		file, err := Parse(strings.NewReader(
+			"### XML\nPOST http://localhost\nContent-Type: application/xml\n\n<a/>\n\n" +
+				"### Upload\nPUT http://localhost\n\n< ./blob.bin\n",
+		))
+
+		require.NoError(t, err)
+		require.Len(t, file.Requests, 2)
+		assert.Equal(t, "xml", file.Requests[0].BodyType)
+		assert.Equal(t, "<a/>", file.Requests[0].Body)
+		assert.Equal(t, "binary", file.Requests[1].BodyType)
+		assert.Equal(t, "./blob.bin", file.Requests[1].Body)
+	})
+
+	t.Run("should handle CRLF line endings", func(t *testing.T) {
+		file, err := Parse(strings.NewReader("### A\r\nGET http://localhost\r\nX-A: 1\r\n"))
+
+		require.NoError(t, err)
+		require.Len(t, file.Requests, 1)
+		assert.Equal(t, "http://localhost", file.Requests[0].URL)
+		assert.Equal(t, "1", file.Requests[0].Headers.Get("X-A"))
+	})
+
+	t.Run("should keep repeated headers in order", func(t *testing.T) {
+		file, err := Parse(strings.NewReader("GET http://localhost\nAccept: text/html\nX-A: 1\nAccept: application/json\n"))
+
+		require.NoError(t, err)
+		assert.Equal(t, storage.Headers{
+			{Key: "Accept", Value: "text/html"},
+			{Key: "X-A", Value: "1"},
+			{Key: "Accept", Value: "application/json"},
+		}, file.Requests[0].Headers)
+	})
+
+	t.Run("should return an error on malformed headers", func(t *testing.T) {
+		file, err := Parse(strings.NewReader("GET http://localhost\nnot a header\n"))
+
+		assert.Nil(t, file)
+		assert.ErrorIs(t, err, ErrInvalidRequest)
+		assert.ErrorContains(t, err, "line 2")
+	})
+
+	t.Run("should return an error on unknown methods", func(t *testing.T) {
+		_, err := Parse(strings.NewReader("FETCH http://localhost\n"))
+
+		assert.ErrorIs(t, err, ErrInvalidRequest)
+		assert.ErrorContains(t, err, `unknown method "FETCH"`)
+	})
+}
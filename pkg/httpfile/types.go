@@ -0,0 +1,36 @@
+package httpfile
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+var ErrInvalidRequest = errors.New("invalid request")
+
+const multipartBoundary = "gostman-boundary"
+
+var (
+	separatorLine = regexp.MustCompile(`^###`)
+	variableLine  = regexp.MustCompile(`^@([A-Za-z_$][\w.$-]*)\s*=\s*(.*?)\s*$`)
+	nameComment   = regexp.MustCompile(`^(?:#|//)\s*@name\s+(.+?)\s*$`)
+	httpVersion   = regexp.MustCompile(`\s+HTTP/[\d.]+$`)
+)
+
+var methods = map[string]bool{
+	"GET":     true,
+	"POST":    true,
+	"PUT":     true,
+	"DELETE":  true,
+	"PATCH":   true,
+	"OPTIONS": true,
+	"HEAD":    true,
+	"TRACE":   true,
+	"CONNECT": true,
+}
+
+type File struct {
+	Variables map[string]string
+	Requests  []*storage.Request
+}
@@ -0,0 +1,192 @@
+package httpfile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+func Write(w io.Writer, variables map[string]string, requests []*storage.Request) error {
+	bw := bufio.NewWriter(w)
+
+	if len(variables) > 0 {
+		for _, key := range sortedKeys(variables) {
+			fmt.Fprintf(bw, "@%s = %s\n", key, variables[key])
+		}
+		bw.WriteString("\n")
+	}
+
+	for i, req := range requests {
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+
+		if err := writeRequest(bw, req); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+func writeRequest(w *bufio.Writer, req *storage.Request) error {
+	body, contentType, err := exportBody(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", req.Name, err)
+	}
+
+	isFormData := request.ParseBodyType(req.BodyType) == request.BodyTypeFormData
+	headers := make(storage.Headers, 0, len(req.Headers)+1)
+	hasContentType := false
+
+	for _, h := range req.Headers {
+		if h.Disabled {
+			continue
+		}
+
+		if strings.EqualFold(h.Key, "Content-Type") {
+			if contentType != "" && isFormData {
+				continue
+			}
+			hasContentType = true
+		}
+
+		headers = append(headers, h)
+	}
+
+	if contentType != "" && !hasContentType {
+		headers = slices.Insert(headers, 0, storage.Header{Key: "Content-Type", Value: contentType})
+	}
+
+	method := req.Method
+	if method == "" {
+		method = "GET"
+	}
+
+	fmt.Fprintf(w, "### %s\n", req.Name)
+	fmt.Fprintf(w, "%s %s\n", method, req.URL)
+
+	for _, h := range headers {
+		fmt.Fprintf(w, "%s: %s\n", h.Key, h.Value)
+	}
+
+	if body != "" {
+		fmt.Fprintf(w, "\n%s\n", body)
+	}
+
+	return nil
+}
+
+func exportBody(req *storage.Request) (string, string, error) {
+	if len(req.Form) > 0 {
+		switch request.ParseBodyType(req.BodyType) {
+		case request.BodyTypeFormData:
+			return exportForm(req.Form), "multipart/form-data; boundary=" + multipartBoundary, nil
+		case request.BodyTypeURLEncoded:
+			return exportURLEncoded(req.Form), request.BodyTypeURLEncoded.ContentType(), nil
+		}
+	}
+
+	if req.Body == "" {
+		return "", "", nil
+	}
+
+	switch request.ParseBodyType(req.BodyType) {
+	case request.BodyTypeJSON:
+		return req.Body, "application/json", nil
+	case request.BodyTypeURLEncoded, request.BodyTypeGraphQL:
+		reader, contentType, err := request.EncodeBody(req.Body, request.ParseBodyType(req.BodyType))
+		if err != nil {
+			return "", "", err
+		}
+		data, err := io.ReadAll(reader)
+		return string(data), contentType, err
+	case request.BodyTypeFormData:
+		fields, err := request.ParseFormFields(req.Body)
+		if err != nil {
+			return "", "", err
+		}
+
+		var b strings.Builder
+		for _, f := range fields {
+			fmt.Fprintf(&b, "--%s\n", multipartBoundary)
+			fmt.Fprintf(&b, "Content-Disposition: form-data; name=%q\n\n", f.Key)
+			fmt.Fprintf(&b, "%s\n", f.Value)
+		}
+		fmt.Fprintf(&b, "--%s--", multipartBoundary)
+
+		return b.String(), "multipart/form-data; boundary=" + multipartBoundary, nil
+	case request.BodyTypeBinary:
+		return "< " + strings.TrimSpace(req.Body), request.BodyTypeBinary.ContentType(), nil
+	case request.BodyTypeText, request.BodyTypeXML, request.BodyTypeHTML, request.BodyTypeCustom:
+		return req.Body, request.ParseBodyType(req.BodyType).ContentType(), nil
+	}
+
+	if req.BodyType == request.BodyTypeNone.String() {
+		return "", "", nil
+	}
+
+	return req.Body, "", nil
+}
+
+func exportForm(form []storage.FormField) string {
+	var b strings.Builder
+
+	for _, f := range form {
+		if f.Disabled {
+			continue
+		}
+
+		fmt.Fprintf(&b, "--%s\n", multipartBoundary)
+
+		if !f.IsFile() {
+			fmt.Fprintf(&b, "Content-Disposition: form-data; name=%q\n\n", f.Key)
+			fmt.Fprintf(&b, "%s\n", f.Value)
+			continue
+		}
+
+		filename := f.Filename
+		if filename == "" {
+			filename = filepath.Base(f.Value)
+		}
+
+		fmt.Fprintf(&b, "Content-Disposition: form-data; name=%q; filename=%q\n", f.Key, filename)
+		if f.ContentType != "" {
+			fmt.Fprintf(&b, "Content-Type: %s\n", f.ContentType)
+		}
+		fmt.Fprintf(&b, "\n< %s\n", f.Value)
+	}
+	fmt.Fprintf(&b, "--%s--", multipartBoundary)
+
+	return b.String()
+}
+
+func exportURLEncoded(form []storage.FormField) string {
+	fields := make([]request.FormField, 0, len(form))
+
+	for _, f := range form {
+		if f.Disabled || f.IsFile() {
+			continue
+		}
+		fields = append(fields, request.FormField{Key: f.Key, Value: f.Value})
+	}
+
+	return request.EncodeURLEncoded(fields)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,176 @@
+package httpfile
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	t.Run("should write variables and requests", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := Write(&buf, map[string]string{"host": "http://localhost"}, []*storage.Request{
+			{
+				Name:   "List",
+				Method: "GET",
+				URL:    "{{host}}/items",
+				Headers: storage.Headers{
+					{Key: "X-B", Value: "2"},
+					{Key: "X-Debug", Value: "1", Disabled: true},
+					{Key: "X-A", Value: "1"},
+					{Key: "X-B", Value: "3"},
+				},
+			},
+			{
+				Name:     "Create",
+				Method:   "POST",
+				URL:      "{{host}}/items",
+				Body:     `{"a":1}`,
+				BodyType: "json",
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "@host = http://localhost\n\n"+
+			"### List\nGET {{host}}/items\nX-B: 2\nX-A: 1\nX-B: 3\n\n"+
+			"### Create\nPOST {{host}}/items\nContent-Type: application/json\n\n{\"a\":1}\n",
+			buf.String())
+	})
+
+	t.Run("should encode url-encoded and multipart bodies", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := Write(&buf, nil, []*storage.Request{
+			{Name: "Form", Method: "POST", URL: "http://localhost", Body: `{"a":"b c"}`, BodyType: "urlencoded"},
+			{
+				Name:     "Upload",
+				Method:   "POST",
+				URL:      "http://localhost",
+				Headers:  storage.Headers{{Key: "Content-Type", Value: "multipart/form-data"}},
+				Body:     `{"k":"v"}`,
+				BodyType: "form-data",
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "Content-Type: application/x-www-form-urlencoded\n\na=b+c\n")
+		assert.Contains(t, buf.String(), "Content-Type: multipart/form-data; boundary=gostman-boundary\n")
+		assert.Contains(t, buf.String(), "--gostman-boundary\nContent-Disposition: form-data; name=\"k\"\n\nv\n--gostman-boundary--\n")
+	})
+
+	t.Run("should reference uploaded files", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := Write(&buf, nil, []*storage.Request{{
+			Name:     "Upload",
+			Method:   "POST",
+			URL:      "http://localhost",
+			BodyType: "form-data",
+			Form: []storage.FormField{
+				{Key: "name", Value: "bob"},
+				{Key: "avatar", Value: "./me.png", Type: storage.FormFieldFile, ContentType: "image/png"},
+			},
+		}})
+
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "Content-Disposition: form-data; name=\"name\"\n\nbob\n")
+		assert.Contains(
+			t,
+			buf.String(),
+			"Content-Disposition: form-data; name=\"avatar\"; filename=\"me.png\"\nContent-Type: image/png\n\n< ./me.png\n",
+		)
+	})
+
+	t.Run("should write url-encoded fields in order without disabled ones", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := Write(&buf, nil, []*storage.Request{{
+			Name:     "Search",
+			Method:   "POST",
+			URL:      "http://localhost",
+			BodyType: "urlencoded",
+			Form: []storage.FormField{
+				{Key: "tag", Value: "b"},
+				{Key: "tag", Value: "a b"},
+				{Key: "debug", Value: "1", Disabled: true},
+			},
+		}})
+
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "Content-Type: application/x-www-form-urlencoded\n\ntag=b&tag=a+b\n")
+	})
+
+	t.Run("should write raw bodies with their content type and binary bodies as files", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := Write(&buf, nil, []*storage.Request{
+			{Name: "Page", Method: "POST", URL: "http://localhost", Body: "<p>hi</p>", BodyType: "html"},
+			{Name: "Blob", Method: "PUT", URL: "http://localhost", Body: "./blob.bin", BodyType: "binary"},
+		})
+
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "Content-Type: text/html\n\n<p>hi</p>\n")
+		assert.Contains(t, buf.String(), "Content-Type: application/octet-stream\n\n< ./blob.bin\n")
+	})
+
+	t.Run("should write graphql bodies as JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := Write(&buf, nil, []*storage.Request{
+			{
+				Name:     "Query",
+				Method:   "POST",
+				URL:      "http://localhost/graphql",
+				Body:     `{"query":"{ me { id } }","variables":"{\"id\": 1}"}`,
+				BodyType: "graphql",
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "Content-Type: application/json\n\n{\"query\":\"{ me { id } }\",\"variables\":{\"id\":1}}\n")
+	})
+
+	t.Run("should return an error on invalid bodies", func(t *testing.T) {
+		err := Write(&bytes.Buffer{}, nil, []*storage.Request{
+			{Name: "Bad", Method: "POST", URL: "http://localhost", Body: "nope", BodyType: "urlencoded"},
+		})
+
+		assert.ErrorContains(t, err, "Bad: invalid JSON for url-encoded")
+	})
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Run("should parse back what it writes", func(t *testing.T) {
+		file := loadFixture(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, file.Variables, file.Requests))
+
+		parsed, err := Parse(&buf)
+		require.NoError(t, err)
+
+		assert.Equal(t, file.Variables, parsed.Variables)
+		require.Len(t, parsed.Requests, len(file.Requests))
+
+		for i, req := range file.Requests {
+			got := parsed.Requests[i]
+			assert.Equal(t, req.Name, got.Name)
+			assert.Equal(t, req.Method, got.Method)
+			assert.Equal(t, req.URL, got.URL)
+			assert.Equal(t, req.BodyType, got.BodyType)
+			assert.Equal(t, req.Form, got.Form)
+			if req.BodyType == "json" {
+				assert.Equal(t, req.Body, got.Body)
+			} else if req.Body != "" {
+				assert.JSONEq(t, req.Body, got.Body)
+			}
+			for _, h := range req.Headers {
+				assert.Contains(t, got.Headers, h)
+			}
+		}
+	})
+}
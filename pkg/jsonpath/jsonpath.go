@@ -0,0 +1,172 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidPath = errors.New("invalid JSON path")
+	ErrNotFound    = errors.New("JSON path not found")
+)
+
+type Segment struct {
+	Key   string
+	Index int
+	IsKey bool
+}
+
+func Parse(path string) ([]Segment, error) {
+	path = strings.TrimSpace(path)
+	path = strings.TrimPrefix(path, "$")
+
+	var segments []Segment
+
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			i++
+			end := i
+			for end < len(path) && path[end] != '.' && path[end] != '[' {
+				end++
+			}
+			if end == i {
+				return nil, fmt.Errorf("%w: empty key at offset %d", ErrInvalidPath, i)
+			}
+			segments = append(segments, Segment{Key: path[i:end], IsKey: true})
+			i = end
+
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end == -1 {
+				return nil, fmt.Errorf("%w: unclosed bracket at offset %d", ErrInvalidPath, i)
+			}
+			inner := path[i+1 : i+end]
+			i += end + 1
+
+			if len(inner) >= 2 && (inner[0] == '"' || inner[0] == '\'') && inner[len(inner)-1] == inner[0] {
+				segments = append(segments, Segment{Key: inner[1 : len(inner)-1], IsKey: true})
+				continue
+			}
+
+			index, err := strconv.Atoi(inner)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid index %q", ErrInvalidPath, inner)
+			}
+			segments = append(segments, Segment{Index: index})
+
+		default:
+			if len(segments) > 0 {
+				return nil, fmt.Errorf("%w: unexpected %q at offset %d", ErrInvalidPath, path[i], i)
+			}
+			path = "." + path[i:]
+			i = 0
+		}
+	}
+
+	return segments, nil
+}
+
+func Get(data any, path string) (any, error) {
+	segments, err := Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := data
+	for _, segment := range segments {
+		switch v := current.(type) {
+		case map[string]any:
+			if !segment.IsKey {
+				return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+			}
+			value, ok := v[segment.Key]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+			}
+			current = value
+
+		case []any:
+			if segment.IsKey {
+				return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+			}
+			index := segment.Index
+			if index < 0 {
+				index += len(v)
+			}
+			if index < 0 || index >= len(v) {
+				return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+			}
+			current = v[index]
+
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+		}
+	}
+
+	return current, nil
+}
+
+func Lookup(body, path string) (any, error) {
+	var data any
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		return nil, fmt.Errorf("response is not valid JSON: %w", err)
+	}
+
+	return Get(data, path)
+}
+
+func Format(segments []Segment) string {
+	var b strings.Builder
+	b.WriteString("$")
+
+	for _, segment := range segments {
+		if !segment.IsKey {
+			fmt.Fprintf(&b, "[%d]", segment.Index)
+			continue
+		}
+
+		if isIdentifier(segment.Key) {
+			b.WriteString("." + segment.Key)
+		} else {
+			fmt.Fprintf(&b, "[%q]", segment.Key)
+		}
+	}
+
+	return b.String()
+}
+
+func String(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return "null"
+	case map[string]any, []any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if !(r == '_' || r == '-' || r == '$' ||
+			(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,116 @@
+package jsonpath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sample = `{
+  "data": {
+    "users": [
+      {"id": 1, "name": "bob", "tags": ["admin"]},
+      {"id": 2, "name": "alice", "tags": []}
+    ],
+    "total": 2,
+    "weird key": true
+  }
+}`
+
+func TestParse(t *testing.T) {
+	t.Run("should parse keys, indexes and quoted keys", func(t *testing.T) {
+		segments, err := Parse(`$.data.users[1]["weird key"]`)
+		require.NoError(t, err)
+
+		assert.Equal(t, []Segment{
+			{Key: "data", IsKey: true},
+			{Key: "users", IsKey: true},
+			{Index: 1},
+			{Key: "weird key", IsKey: true},
+		}, segments)
+	})
+
+	t.Run("should accept paths without the root marker", func(t *testing.T) {
+		segments, err := Parse("data.total")
+		require.NoError(t, err)
+
+		assert.Equal(t, []Segment{{Key: "data", IsKey: true}, {Key: "total", IsKey: true}}, segments)
+	})
+
+	t.Run("should return the root for empty paths", func(t *testing.T) {
+		segments, err := Parse("$")
+		require.NoError(t, err)
+		assert.Empty(t, segments)
+	})
+
+	t.Run("should reject invalid paths", func(t *testing.T) {
+		for _, path := range []string{"$.", "$.a[", "$.a[x]", "$.a..b"} {
+			_, err := Parse(path)
+			assert.ErrorIs(t, err, ErrInvalidPath, path)
+		}
+	})
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected any
+	}{
+		{"$", nil},
+		{"$.data.total", float64(2)},
+		{"$.data.users[0].name", "bob"},
+		{"data.users[-1].id", float64(2)},
+		{`$.data["weird key"]`, true},
+		{"$.data.users[0].tags", []any{"admin"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			value, err := Lookup(sample, tt.path)
+			require.NoError(t, err)
+
+			if tt.expected != nil {
+				assert.Equal(t, tt.expected, value)
+			}
+		})
+	}
+
+	t.Run("should return not found errors", func(t *testing.T) {
+		for _, path := range []string{"$.missing", "$.data.users[5]", "$.data.total.x", "$.data[0]", "$.data.users.x"} {
+			_, err := Lookup(sample, path)
+			assert.ErrorIs(t, err, ErrNotFound, path)
+		}
+	})
+
+	t.Run("should fail on invalid JSON", func(t *testing.T) {
+		_, err := Lookup("not json", "$.a")
+		assert.ErrorContains(t, err, "not valid JSON")
+	})
+}
+
+func TestFormat(t *testing.T) {
+	segments := []Segment{
+		{Key: "data", IsKey: true},
+		{Key: "users", IsKey: true},
+		{Index: 0},
+		{Key: "weird key", IsKey: true},
+	}
+
+	path := Format(segments)
+	assert.Equal(t, `$.data.users[0]["weird key"]`, path)
+
+	parsed, err := Parse(path)
+	require.NoError(t, err)
+	assert.Equal(t, segments, parsed)
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "bob", String("bob"))
+	assert.Equal(t, "2", String(float64(2)))
+	assert.Equal(t, "1.5", String(1.5))
+	assert.Equal(t, "true", String(true))
+	assert.Equal(t, "null", String(nil))
+	assert.Equal(t, `["a"]`, String([]any{"a"}))
+	assert.Equal(t, `{"a":1}`, String(map[string]any{"a": float64(1)}))
+}
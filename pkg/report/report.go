@@ -0,0 +1,147 @@
+package report
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/assertion"
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/runner"
+)
+
+func New(summary *runner.Summary, secrets []string) *Report {
+	redact := redactor(secrets)
+
+	r := &Report{
+		Collection: summary.Collection.Name,
+		StartedAt:  summary.StartedAt,
+		Duration:   summary.Duration.Milliseconds(),
+		Iterations: max(summary.Iterations, 1),
+		Total:      len(summary.Results),
+		Passed:     summary.Passed(),
+		Failed:     summary.Failed(),
+	}
+
+	for _, result := range summary.Results {
+		req := &Request{
+			Iteration: max(result.Iteration, 1),
+			Name:      redact(result.Request.Name),
+			Method:    result.Request.Method,
+			URL:       redact(result.URL),
+			Passed:    !result.Failed(),
+		}
+
+		if result.Response != nil {
+			req.Status = result.Response.StatusCode
+			req.Duration = result.Response.TimeTaken
+			req.Attempts = len(result.Response.Attempts)
+		}
+
+		if result.Err != nil {
+			req.Error = redact(result.Err.Error())
+			req.Failures = append(req.Failures, req.Error)
+
+			var retryErr *request.RetryError
+			if errors.As(result.Err, &retryErr) {
+				req.Attempts = len(retryErr.Attempts)
+			}
+		}
+
+		for _, a := range result.Assertions {
+			entry := &Assertion{
+				Assertion: redact(assertion.Format(a.Assertion)),
+				Passed:    a.Passed,
+				Message:   redact(a.Message),
+			}
+			req.Assertions = append(req.Assertions, entry)
+
+			if !a.Passed {
+				req.Failures = append(req.Failures, entry.Assertion+": "+entry.Message)
+			}
+		}
+
+		if !req.Passed && len(req.Failures) == 0 {
+			req.Failures = append(req.Failures, fmt.Sprintf("unexpected status %d", req.Status))
+		}
+
+		r.Requests = append(r.Requests, req)
+	}
+
+	return r
+}
+
+func Secrets(vars map[string]string, names []string) []string {
+	var secrets []string
+
+	for name, value := range vars {
+		if value != "" && (IsSecret(name) || slices.ContainsFunc(names, func(n string) bool {
+			return strings.EqualFold(n, name)
+		})) {
+			secrets = append(secrets, value)
+		}
+	}
+
+	return secrets
+}
+
+func SummarySecrets(summary *runner.Summary, names []string) []string {
+	secrets := Secrets(summary.Variables, names)
+	for _, vars := range summary.IterationVariables {
+		secrets = append(secrets, Secrets(vars, names)...)
+	}
+	for _, row := range summary.Data {
+		secrets = append(secrets, Secrets(row, names)...)
+	}
+
+	return secrets
+}
+
+func (r *Report) caseName(req *Request) string {
+	if r.Iterations > 1 {
+		return fmt.Sprintf("%s (iteration %d)", req.Name, req.Iteration)
+	}
+
+	return req.Name
+}
+
+func IsSecret(name string) bool {
+	name = strings.ToLower(name)
+
+	return slices.ContainsFunc(secretNames, func(s string) bool {
+		return strings.Contains(name, s)
+	})
+}
+
+func Write(w io.Writer, format Format, r *Report) error {
+	switch format {
+	case FormatJUnit:
+		return writeJUnit(w, r)
+	case FormatJSON:
+		return writeJSON(w, r)
+	case FormatTAP:
+		return writeTAP(w, r)
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+}
+
+func redactor(secrets []string) func(string) string {
+	secrets = slices.Clone(secrets)
+	slices.SortFunc(secrets, func(a, b string) int {
+		return cmp.Compare(len(b), len(a))
+	})
+
+	var pairs []string
+	for _, secret := range secrets {
+		if secret != "" {
+			pairs = append(pairs, secret, redacted)
+		}
+	}
+
+	replacer := strings.NewReplacer(pairs...)
+	return replacer.Replace
+}
@@ -0,0 +1,187 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Yalaouf/gostman/pkg/assertion"
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/runner"
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSummary() *runner.Summary {
+	statusOK := storage.Assertion{Type: "status", Operator: "equals", Value: "200"}
+
+	return &runner.Summary{
+		Collection: &storage.Collection{Name: "Smoke"},
+		StartedAt:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Duration:   1500 * time.Millisecond,
+		Variables:  map[string]string{"apiToken": "s3cr3t", "host": "http://localhost"},
+		Results: []*runner.Result{
+			{
+				Request:    &storage.Request{Name: "Ping", Method: "GET"},
+				URL:        "http://localhost/ping?key=s3cr3t",
+				Response:   &request.Response{StatusCode: 200, TimeTaken: 12},
+				Assertions: []assertion.Result{{Assertion: statusOK, Passed: true}},
+			},
+			{
+				Request:  &storage.Request{Name: "Orders", Method: "POST", Assertions: []storage.Assertion{statusOK}},
+				URL:      "http://localhost/orders",
+				Response: &request.Response{StatusCode: 500, TimeTaken: 30},
+				Assertions: []assertion.Result{
+					{Assertion: statusOK, Passed: false, Message: "expected 200, got 500"},
+				},
+			},
+			{
+				Request: &storage.Request{Name: "Down", Method: "GET"},
+				URL:     "http://localhost/down",
+				Err:     errors.New("connection refused using s3cr3t"),
+			},
+		},
+	}
+}
+
+func TestSecrets(t *testing.T) {
+	vars := map[string]string{"apiToken": "a", "Password": "b", "host": "c", "tenant": "d", "empty_secret": ""}
+
+	assert.ElementsMatch(t, []string{"a", "b"}, Secrets(vars, nil))
+	assert.ElementsMatch(t, []string{"a", "b", "d"}, Secrets(vars, []string{"TENANT"}))
+}
+
+func TestNew(t *testing.T) {
+	summary := testSummary()
+	r := New(summary, Secrets(summary.Variables, nil))
+
+	assert.Equal(t, "Smoke", r.Collection)
+	assert.Equal(t, int64(1500), r.Duration)
+	assert.Equal(t, 3, r.Total)
+	assert.Equal(t, 1, r.Passed)
+	assert.Equal(t, 2, r.Failed)
+	require.Len(t, r.Requests, 3)
+
+	assert.True(t, r.Requests[0].Passed)
+	assert.Equal(t, "http://localhost/ping?key=[REDACTED]", r.Requests[0].URL)
+	assert.Empty(t, r.Requests[0].Failures)
+
+	assert.False(t, r.Requests[1].Passed)
+	assert.Equal(t, 500, r.Requests[1].Status)
+	assert.Equal(t, []string{"status == 200: expected 200, got 500"}, r.Requests[1].Failures)
+
+	assert.Equal(t, "connection refused using [REDACTED]", r.Requests[2].Error)
+}
+
+func TestAttempts(t *testing.T) {
+	summary := testSummary()
+	summary.Results[1].Response.Attempts = []request.Attempt{{StatusCode: 503, Wait: time.Millisecond}, {StatusCode: 500}}
+	summary.Results[2].Err = &request.RetryError{Attempts: make([]request.Attempt, 3), Err: errors.New("connection refused")}
+
+	r := New(summary, nil)
+
+	assert.Zero(t, r.Requests[0].Attempts)
+	assert.Equal(t, 2, r.Requests[1].Attempts)
+	assert.Equal(t, 3, r.Requests[2].Attempts)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatTAP, r))
+	assert.Contains(t, buf.String(), "  duration_ms: 30\n  attempts: 2\n")
+}
+
+func TestIterations(t *testing.T) {
+	summary := testSummary()
+	summary.Iterations = 2
+	summary.Data = []map[string]string{{"password": "hunter2"}, {"password": "letmein"}}
+	summary.Results[1].Iteration = 2
+	summary.Results[1].URL = "http://localhost/orders?p=letmein"
+
+	r := New(summary, SummarySecrets(summary, nil))
+
+	assert.Equal(t, 2, r.Iterations)
+	assert.Equal(t, 1, r.Requests[0].Iteration)
+	assert.Equal(t, 2, r.Requests[1].Iteration)
+	assert.Equal(t, "http://localhost/orders?p=[REDACTED]", r.Requests[1].URL)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatTAP, r))
+	assert.Contains(t, buf.String(), "not ok 2 - Orders (iteration 2)\n  ---\n  iteration: 2\n")
+
+	buf.Reset()
+	require.NoError(t, Write(&buf, FormatJUnit, r))
+	assert.Contains(t, buf.String(), `name="Ping (iteration 1)"`)
+}
+
+func TestSummarySecrets(t *testing.T) {
+	summary := testSummary()
+	summary.Iterations = 2
+	summary.Variables = map[string]string{"host": "http://localhost"}
+	summary.IterationVariables = []map[string]string{
+		{"host": "http://localhost", "sessionToken": "row-1-token"},
+		summary.Variables,
+	}
+	summary.Results[0].URL = "http://localhost/ping?token=row-1-token"
+
+	r := New(summary, SummarySecrets(summary, nil))
+
+	assert.Equal(t, "http://localhost/ping?token=[REDACTED]", r.Requests[0].URL)
+}
+
+func TestWrite(t *testing.T) {
+	summary := testSummary()
+	r := New(summary, Secrets(summary.Variables, nil))
+
+	t.Run("should write JUnit XML", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, FormatJUnit, r))
+
+		var suites junitSuites
+		require.NoError(t, xml.Unmarshal(buf.Bytes(), &suites))
+
+		assert.Equal(t, 3, suites.Tests)
+		assert.Equal(t, 1, suites.Failures)
+		assert.Equal(t, 1, suites.Errors)
+		require.Len(t, suites.Suites, 1)
+		assert.Equal(t, "2026-01-02T03:04:05Z", suites.Suites[0].Timestamp)
+		require.Len(t, suites.Suites[0].Cases, 3)
+		assert.Nil(t, suites.Suites[0].Cases[0].Failure)
+		assert.Equal(t, "0.030", suites.Suites[0].Cases[1].Time)
+		assert.Contains(t, suites.Suites[0].Cases[1].Failure.Message, "expected 200, got 500")
+		assert.NotNil(t, suites.Suites[0].Cases[2].Error)
+		assert.NotContains(t, buf.String(), "s3cr3t")
+	})
+
+	t.Run("should write JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, FormatJSON, r))
+
+		var decoded Report
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+
+		assert.Equal(t, r.Requests[1].Failures, decoded.Requests[1].Failures)
+		assert.Equal(t, 2, decoded.Failed)
+		assert.NotContains(t, buf.String(), "s3cr3t")
+	})
+
+	t.Run("should write TAP", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, FormatTAP, r))
+
+		out := buf.String()
+		assert.Contains(t, out, "TAP version 13\n1..3\n")
+		assert.Contains(t, out, "ok 1 - Ping\n")
+		assert.Contains(t, out, "not ok 2 - Orders\n")
+		assert.Contains(t, out, `    - "status == 200: expected 200, got 500"`)
+		assert.Contains(t, out, "not ok 3 - Down\n")
+		assert.Contains(t, out, "# pass 1\n# fail 2\n")
+		assert.NotContains(t, out, "s3cr3t")
+	})
+
+	t.Run("should reject unknown formats", func(t *testing.T) {
+		assert.ErrorIs(t, Write(&bytes.Buffer{}, Format("csv"), r), ErrUnsupportedFormat)
+	})
+}
@@ -0,0 +1,55 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func writeTAP(w io.Writer, r *Report) error {
+	var b strings.Builder
+
+	b.WriteString("TAP version 13\n")
+	fmt.Fprintf(&b, "1..%d\n", len(r.Requests))
+
+	for i, req := range r.Requests {
+		verdict := "ok"
+		if !req.Passed {
+			verdict = "not ok"
+		}
+
+		fmt.Fprintf(&b, "%s %d - %s\n", verdict, i+1, tapEscape(r.caseName(req)))
+
+		if req.Passed {
+			continue
+		}
+
+		b.WriteString("  ---\n")
+		if r.Iterations > 1 {
+			fmt.Fprintf(&b, "  iteration: %d\n", req.Iteration)
+		}
+		fmt.Fprintf(&b, "  method: %s\n", req.Method)
+		fmt.Fprintf(&b, "  url: %s\n", strconv.Quote(req.URL))
+		fmt.Fprintf(&b, "  status: %d\n", req.Status)
+		fmt.Fprintf(&b, "  duration_ms: %d\n", req.Duration)
+		if req.Attempts > 1 {
+			fmt.Fprintf(&b, "  attempts: %d\n", req.Attempts)
+		}
+		b.WriteString("  failures:\n")
+		for _, failure := range req.Failures {
+			fmt.Fprintf(&b, "    - %s\n", strconv.Quote(failure))
+		}
+		b.WriteString("  ...\n")
+	}
+
+	fmt.Fprintf(&b, "# pass %d\n", r.Passed)
+	fmt.Fprintf(&b, "# fail %d\n", r.Failed)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func tapEscape(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "#", "\\#", "\n", " ").Replace(s)
+}
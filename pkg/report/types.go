@@ -0,0 +1,63 @@
+package report
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported report format")
+
+type Format string
+
+const (
+	FormatJUnit Format = "junit"
+	FormatJSON  Format = "json"
+	FormatTAP   Format = "tap"
+)
+
+const redacted = "[REDACTED]"
+
+var secretNames = []string{
+	"token",
+	"secret",
+	"password",
+	"passwd",
+	"apikey",
+	"api_key",
+	"api-key",
+	"auth",
+	"credential",
+	"private",
+	"session",
+}
+
+type Report struct {
+	Collection string     `json:"collection"`
+	StartedAt  time.Time  `json:"startedAt"`
+	Duration   int64      `json:"durationMs"`
+	Iterations int        `json:"iterations"`
+	Total      int        `json:"total"`
+	Passed     int        `json:"passed"`
+	Failed     int        `json:"failed"`
+	Requests   []*Request `json:"requests"`
+}
+
+type Request struct {
+	Iteration  int          `json:"iteration"`
+	Name       string       `json:"name"`
+	Method     string       `json:"method"`
+	URL        string       `json:"url"`
+	Status     int          `json:"status,omitempty"`
+	Duration   int64        `json:"durationMs"`
+	Attempts   int          `json:"attempts,omitempty"`
+	Passed     bool         `json:"passed"`
+	Error      string       `json:"error,omitempty"`
+	Assertions []*Assertion `json:"assertions,omitempty"`
+	Failures   []string     `json:"failures,omitempty"`
+}
+
+type Assertion struct {
+	Assertion string `json:"assertion"`
+	Passed    bool   `json:"passed"`
+	Message   string `json:"message,omitempty"`
+}
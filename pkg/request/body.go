@@ -0,0 +1,177 @@
+package request
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+type bodyReader struct {
+	src      io.Reader
+	limit    int64
+	total    int64
+	start    time.Time
+	last     time.Time
+	progress ProgressFunc
+	buf      bytes.Buffer
+	file     *os.File
+	size     int64
+}
+
+func newBodyReader(src io.Reader, total, limit int64, progress ProgressFunc) *bodyReader {
+	if limit <= 0 {
+		limit = DefaultMaxBodySize
+	}
+
+	return &bodyReader{src: src, limit: limit, total: total, start: time.Now(), progress: progress}
+}
+
+func readBody(src io.Reader, total, limit int64, progress ProgressFunc) (*Response, error) {
+	r := newBodyReader(src, total, limit, progress)
+
+	res, err := r.read()
+	if err != nil {
+		r.discard()
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *bodyReader) read() (*Response, error) {
+	chunk := make([]byte, chunkSize)
+
+	for {
+		n, err := r.src.Read(chunk)
+		if n > 0 {
+			if werr := r.write(chunk[:n]); werr != nil {
+				return nil, werr
+			}
+			r.report(false)
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r.report(true)
+
+	return r.response()
+}
+
+func (r *bodyReader) response() (*Response, error) {
+	res := &Response{Body: r.buf.String(), Size: r.size}
+	if r.file != nil {
+		res.BodyFile = r.file.Name()
+		if err := r.file.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func (r *bodyReader) Write(p []byte) (int, error) {
+	if err := r.write(p); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (r *bodyReader) write(p []byte) error {
+	r.size += int64(len(p))
+
+	if r.file != nil {
+		_, err := r.file.Write(p)
+		return err
+	}
+
+	if int64(r.buf.Len()+len(p)) <= r.limit {
+		r.buf.Write(p)
+		return nil
+	}
+
+	file, err := os.CreateTemp("", tempFilePattern)
+	if err != nil {
+		return err
+	}
+	r.file = file
+
+	if _, err := file.Write(r.buf.Bytes()); err != nil {
+		return err
+	}
+	if _, err := file.Write(p); err != nil {
+		return err
+	}
+
+	r.buf.Write(p[:r.limit-int64(r.buf.Len())])
+	return nil
+}
+
+func (r *bodyReader) report(done bool) {
+	if r.progress == nil {
+		return
+	}
+
+	now := time.Now()
+	if !done && now.Sub(r.last) < progressInterval {
+		return
+	}
+	r.last = now
+
+	preview := r.buf.Bytes()
+	if len(preview) > previewSize {
+		preview = preview[:previewSize]
+	}
+
+	r.progress(Progress{
+		Received: r.size,
+		Total:    r.total,
+		Elapsed:  now.Sub(r.start),
+		Preview:  string(preview),
+		Done:     done,
+	})
+}
+
+func (r *bodyReader) discard() {
+	if r.file != nil {
+		r.file.Close()
+		os.Remove(r.file.Name())
+	}
+}
+
+func (p Progress) Rate() float64 {
+	seconds := p.Elapsed.Seconds()
+	if seconds <= 0 {
+		return 0
+	}
+
+	return float64(p.Received) / seconds
+}
+
+func (r Response) Truncated() bool {
+	return r.BodyFile != ""
+}
+
+func (r Response) Open() (io.ReadCloser, error) {
+	if r.BodyFile == "" {
+		return io.NopCloser(strings.NewReader(r.Body)), nil
+	}
+
+	return os.Open(r.BodyFile)
+}
+
+func (r Response) Remove() error {
+	if r.BodyFile == "" {
+		return nil
+	}
+
+	return os.Remove(r.BodyFile)
+}
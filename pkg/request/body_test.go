@@ -0,0 +1,126 @@
+package request
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBody(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should keep small bodies in memory", func(t *testing.T) {
+		res, err := readBody(strings.NewReader("hello"), 5, 10, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "hello", res.Body)
+		assert.Equal(t, int64(5), res.Size)
+		assert.False(t, res.Truncated())
+	})
+
+	t.Run("should spill bodies over the limit to a temp file", func(t *testing.T) {
+		body := strings.Repeat("abcdefghij", 10000)
+
+		res, err := readBody(strings.NewReader(body), -1, 1000, nil)
+		require.NoError(t, err)
+		defer res.Remove()
+
+		assert.True(t, res.Truncated())
+		assert.Equal(t, body[:1000], res.Body)
+		assert.Equal(t, int64(len(body)), res.Size)
+
+		r, err := res.Open()
+		require.NoError(t, err)
+		defer r.Close()
+
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(data))
+	})
+
+	t.Run("should return an error when reading fails", func(t *testing.T) {
+		src := io.MultiReader(strings.NewReader(strings.Repeat("x", 100)), errReader{})
+
+		res, err := readBody(src, -1, 10, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("should report progress with a preview", func(t *testing.T) {
+		var updates []Progress
+
+		res, err := readBody(strings.NewReader("hello world"), 11, 0, func(p Progress) {
+			updates = append(updates, p)
+		})
+		require.NoError(t, err)
+
+		require.NotEmpty(t, updates)
+		last := updates[len(updates)-1]
+		assert.True(t, last.Done)
+		assert.Equal(t, int64(11), last.Received)
+		assert.Equal(t, int64(11), last.Total)
+		assert.Equal(t, "hello world", last.Preview)
+		assert.Equal(t, res.Body, last.Preview)
+	})
+}
+
+func TestProgressRate(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0.0, Progress{Received: 100}.Rate())
+	assert.Equal(t, 50.0, Progress{Received: 100, Elapsed: 2 * time.Second}.Rate())
+}
+
+func TestResponseOpen(t *testing.T) {
+	t.Parallel()
+
+	r, err := Response{Body: "inline"}.Open()
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "inline", string(data))
+	assert.NoError(t, Response{Body: "inline"}.Remove())
+}
+
+func TestSendRequestMaxBodySize(t *testing.T) {
+	t.Parallel()
+
+	body := strings.Repeat("0123456789", 5000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	var received int64
+	req := NewModel().SetMethod(GET).
+		SetURL(server.URL).
+		SetMaxBodySize(100).
+		SetProgress(func(p Progress) { received = p.Received })
+
+	res, err := SendRequest(req)
+	require.NoError(t, err)
+	defer res.Remove()
+
+	assert.Equal(t, body[:100], res.Body)
+	assert.Equal(t, int64(len(body)), res.Size)
+	assert.Equal(t, int64(len(body)), received)
+
+	data, err := os.ReadFile(res.BodyFile)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(data))
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
@@ -0,0 +1,181 @@
+package request
+
+import (
+	"bufio"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+type Compression string
+
+const (
+	CompressionNone   Compression = ""
+	CompressionGzip   Compression = "gzip"
+	CompressionBrotli Compression = "br"
+	CompressionZstd   Compression = "zstd"
+)
+
+const acceptEncoding = "gzip, br, zstd"
+
+var Compressions = []Compression{CompressionNone, CompressionGzip, CompressionBrotli, CompressionZstd}
+
+func (c Compression) String() string {
+	if c == CompressionNone {
+		return "none"
+	}
+	return string(c)
+}
+
+func ParseCompression(s string) Compression {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "gzip":
+		return CompressionGzip
+	case "br", "brotli":
+		return CompressionBrotli
+	case "zstd":
+		return CompressionZstd
+	default:
+		return CompressionNone
+	}
+}
+
+func compress(body io.Reader, c Compression) (io.Reader, error) {
+	closeBody := func() {
+		if closer, ok := body.(io.Closer); ok {
+			closer.Close()
+		}
+	}
+
+	pr, pw := io.Pipe()
+	w, err := newCompressor(pw, c)
+	if err != nil {
+		closeBody()
+		return nil, err
+	}
+
+	go func() {
+		_, err := io.Copy(w, body)
+		if closeErr := w.Close(); err == nil {
+			err = closeErr
+		}
+		closeBody()
+		pw.CloseWithError(err)
+	}()
+
+	return pr, nil
+}
+
+func newCompressor(w io.Writer, c Compression) (io.WriteCloser, error) {
+	switch c {
+	case CompressionGzip:
+		return gzip.NewWriter(w), nil
+	case CompressionBrotli:
+		return brotli.NewWriter(w), nil
+	case CompressionZstd:
+		return zstd.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", c)
+	}
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+type decodedBody struct {
+	io.Reader
+	closers []func()
+}
+
+func (d *decodedBody) Close() error {
+	for _, fn := range d.closers {
+		fn()
+	}
+	return nil
+}
+
+func decompress(body io.Reader, contentEncoding string) (io.ReadCloser, bool, error) {
+	encodings := contentEncodings(contentEncoding)
+	if len(encodings) == 0 || !supportedEncodings(encodings) {
+		return io.NopCloser(body), false, nil
+	}
+
+	buffered := bufio.NewReader(body)
+	if _, err := buffered.Peek(1); err == io.EOF {
+		return io.NopCloser(buffered), false, nil
+	}
+
+	d := &decodedBody{Reader: buffered}
+	for i := len(encodings) - 1; i >= 0; i-- {
+		if err := d.wrap(encodings[i]); err != nil {
+			d.Close()
+			return nil, false, fmt.Errorf("failed to decode %s body: %w", encodings[i], err)
+		}
+	}
+
+	return d, true, nil
+}
+
+func (d *decodedBody) wrap(encoding string) error {
+	switch encoding {
+	case "gzip", "x-gzip":
+		r, err := gzip.NewReader(d.Reader)
+		if err != nil {
+			return err
+		}
+		d.Reader = r
+	case "deflate":
+		r, err := zlib.NewReader(d.Reader)
+		if err != nil {
+			return err
+		}
+		d.Reader = r
+		d.closers = append(d.closers, func() { r.Close() })
+	case "br":
+		d.Reader = brotli.NewReader(d.Reader)
+	case "zstd":
+		r, err := zstd.NewReader(d.Reader, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return err
+		}
+		d.Reader = r
+		d.closers = append(d.closers, r.Close)
+	}
+
+	return nil
+}
+
+func contentEncodings(header string) []string {
+	var encodings []string
+	for _, enc := range strings.Split(header, ",") {
+		enc = strings.ToLower(strings.TrimSpace(enc))
+		if enc != "" && enc != "identity" {
+			encodings = append(encodings, enc)
+		}
+	}
+	return encodings
+}
+
+func supportedEncodings(encodings []string) bool {
+	for _, enc := range encodings {
+		switch enc {
+		case "gzip", "x-gzip", "deflate", "br", "zstd":
+		default:
+			return false
+		}
+	}
+	return true
+}
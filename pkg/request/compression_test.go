@@ -0,0 +1,270 @@
+package request
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeWith(t *testing.T, c Compression, data string) []byte {
+	t.Helper()
+
+	r, err := compress(strings.NewReader(data), c)
+	require.NoError(t, err)
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return out
+}
+
+type closeTracker struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestParseCompression(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, CompressionGzip, ParseCompression("gzip"))
+	assert.Equal(t, CompressionBrotli, ParseCompression("br"))
+	assert.Equal(t, CompressionBrotli, ParseCompression("Brotli"))
+	assert.Equal(t, CompressionZstd, ParseCompression("zstd"))
+	assert.Equal(t, CompressionNone, ParseCompression(""))
+	assert.Equal(t, CompressionNone, ParseCompression("lz4"))
+	assert.Equal(t, "none", CompressionNone.String())
+}
+
+func TestCompress(t *testing.T) {
+	t.Parallel()
+
+	data := strings.Repeat("gostman ", 100)
+
+	t.Run("should compress with gzip", func(t *testing.T) {
+		r, err := gzip.NewReader(bytes.NewReader(encodeWith(t, CompressionGzip, data)))
+		require.NoError(t, err)
+
+		out, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, data, string(out))
+	})
+
+	t.Run("should compress with brotli", func(t *testing.T) {
+		out, err := io.ReadAll(brotli.NewReader(bytes.NewReader(encodeWith(t, CompressionBrotli, data))))
+		require.NoError(t, err)
+		assert.Equal(t, data, string(out))
+	})
+
+	t.Run("should compress with zstd", func(t *testing.T) {
+		d, err := zstd.NewReader(bytes.NewReader(encodeWith(t, CompressionZstd, data)))
+		require.NoError(t, err)
+		defer d.Close()
+
+		out, err := io.ReadAll(d)
+		require.NoError(t, err)
+		assert.Equal(t, data, string(out))
+	})
+
+	t.Run("should stream and close the source", func(t *testing.T) {
+		source := &closeTracker{Reader: strings.NewReader(data)}
+
+		r, err := compress(source, CompressionGzip)
+		require.NoError(t, err)
+		assert.IsType(t, &io.PipeReader{}, r)
+
+		_, err = io.ReadAll(r)
+		require.NoError(t, err)
+		assert.True(t, source.closed)
+	})
+
+	t.Run("should fail when the source fails", func(t *testing.T) {
+		r, err := compress(errReader{}, CompressionGzip)
+		require.NoError(t, err)
+
+		_, err = io.ReadAll(r)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
+
+func TestDecompress(t *testing.T) {
+	t.Parallel()
+
+	data := strings.Repeat("gostman ", 100)
+
+	t.Run("should decode each supported encoding", func(t *testing.T) {
+		for _, c := range []Compression{CompressionGzip, CompressionBrotli, CompressionZstd} {
+			body, decoded, err := decompress(bytes.NewReader(encodeWith(t, c, data)), string(c))
+			require.NoError(t, err, c)
+
+			out, err := io.ReadAll(body)
+			body.Close()
+			require.NoError(t, err, c)
+			assert.True(t, decoded, c)
+			assert.Equal(t, data, string(out), c)
+		}
+	})
+
+	t.Run("should undo stacked encodings in reverse order", func(t *testing.T) {
+		gz := encodeWith(t, CompressionGzip, data)
+		r, err := compress(bytes.NewReader(gz), CompressionZstd)
+		require.NoError(t, err)
+
+		body, decoded, err := decompress(r, "gzip, zstd")
+		require.NoError(t, err)
+		defer body.Close()
+
+		out, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.True(t, decoded)
+		assert.Equal(t, data, string(out))
+	})
+
+	t.Run("should leave unknown encodings untouched", func(t *testing.T) {
+		body, decoded, err := decompress(strings.NewReader("raw"), "lz4")
+		require.NoError(t, err)
+
+		out, _ := io.ReadAll(body)
+		assert.False(t, decoded)
+		assert.Equal(t, "raw", string(out))
+	})
+
+	t.Run("should accept an empty encoded body", func(t *testing.T) {
+		body, decoded, err := decompress(strings.NewReader(""), "gzip")
+		require.NoError(t, err)
+
+		out, _ := io.ReadAll(body)
+		assert.False(t, decoded)
+		assert.Empty(t, out)
+	})
+
+	t.Run("should fail on a corrupt body", func(t *testing.T) {
+		_, _, err := decompress(strings.NewReader("not gzip"), "gzip")
+		assert.ErrorContains(t, err, "failed to decode gzip body")
+	})
+}
+
+func TestSendRequestCompression(t *testing.T) {
+	t.Parallel()
+
+	data := strings.Repeat(`{"name":"gostman"}`, 50)
+
+	t.Run("should compress the outgoing body and set Content-Encoding", func(t *testing.T) {
+		var encoding string
+		var received []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding = r.Header.Get("Content-Encoding")
+			d, err := zstd.NewReader(r.Body)
+			require.NoError(t, err)
+			defer d.Close()
+			received, _ = io.ReadAll(d)
+		}))
+		defer server.Close()
+
+		req := NewModel().SetMethod(POST).SetURL(server.URL).
+			SetBodyType(BodyTypeJSON).SetBody(data).
+			SetCompression(CompressionZstd)
+
+		_, err := SendRequest(req)
+		require.NoError(t, err)
+		assert.Equal(t, "zstd", encoding)
+		assert.Equal(t, data, string(received))
+	})
+
+	t.Run("should keep a Content-Encoding header set by the user", func(t *testing.T) {
+		var encoding string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding = r.Header.Get("Content-Encoding")
+		}))
+		defer server.Close()
+
+		req := NewModel().SetMethod(POST).SetURL(server.URL).
+			SetBody(data).SetCompression(CompressionGzip).
+			SetHeader("Content-Encoding", "x-gzip")
+
+		_, err := SendRequest(req)
+		require.NoError(t, err)
+		assert.Equal(t, "x-gzip", encoding)
+	})
+
+	t.Run("should not compress an empty body", func(t *testing.T) {
+		var encoding string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding = r.Header.Get("Content-Encoding")
+		}))
+		defer server.Close()
+
+		_, err := SendRequest(NewModel().SetMethod(GET).SetURL(server.URL).SetCompression(CompressionGzip))
+		require.NoError(t, err)
+		assert.Empty(t, encoding)
+	})
+}
+
+func TestSendRequestDecompression(t *testing.T) {
+	t.Parallel()
+
+	data := strings.Repeat("gostman ", 500)
+
+	serve := func(t *testing.T, c Compression) *httptest.Server {
+		encoded := encodeWith(t, c, data)
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, acceptEncoding, r.Header.Get("Accept-Encoding"))
+			w.Header().Set("Content-Encoding", string(c))
+			w.Write(encoded)
+		}))
+	}
+
+	t.Run("should decode brotli, zstd and gzip responses and report both sizes", func(t *testing.T) {
+		for _, c := range []Compression{CompressionGzip, CompressionBrotli, CompressionZstd} {
+			server := serve(t, c)
+
+			resp, err := SendRequest(NewModel().SetMethod(GET).SetURL(server.URL))
+			server.Close()
+
+			require.NoError(t, err, c)
+			assert.Equal(t, data, resp.Body, c)
+			assert.True(t, resp.Decoded, c)
+			assert.Equal(t, string(c), resp.Encoding, c)
+			assert.Equal(t, int64(len(data)), resp.Size, c)
+			assert.Equal(t, int64(len(encodeWith(t, c, data))), resp.WireSize, c)
+			assert.Less(t, resp.WireSize, resp.Size, c)
+		}
+	})
+
+	t.Run("should keep the raw body when decompression is disabled", func(t *testing.T) {
+		server := serve(t, CompressionBrotli)
+		defer server.Close()
+
+		resp, err := SendRequest(NewModel().SetMethod(GET).SetURL(server.URL).SetDisableDecompression(true))
+		require.NoError(t, err)
+
+		assert.False(t, resp.Decoded)
+		assert.Equal(t, "br", resp.Encoding)
+		assert.Equal(t, string(encodeWith(t, CompressionBrotli, data)), resp.Body)
+		assert.Equal(t, resp.Size, resp.WireSize)
+	})
+
+	t.Run("should keep a user Accept-Encoding header", func(t *testing.T) {
+		var accept string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			accept = r.Header.Get("Accept-Encoding")
+		}))
+		defer server.Close()
+
+		_, err := SendRequest(NewModel().SetMethod(GET).SetURL(server.URL).SetHeader("Accept-Encoding", "identity"))
+		require.NoError(t, err)
+		assert.Equal(t, "identity", accept)
+	})
+}
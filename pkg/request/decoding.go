@@ -0,0 +1,97 @@
+package request
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"strings"
+)
+
+func DecodeURLEncoded(text string) ([]FormField, error) {
+	fields := []FormField{}
+
+	for pair := range strings.SplitSeq(strings.TrimSpace(text), "&") {
+		if pair == "" {
+			continue
+		}
+
+		rawKey, rawValue, _ := strings.Cut(pair, "=")
+
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, FormField{Key: key, Value: value})
+	}
+
+	return fields, nil
+}
+
+func DecodeMultipart(text, boundary string) ([]FormField, error) {
+	if boundary == "" {
+		return nil, errors.New("missing multipart boundary")
+	}
+
+	fields := []FormField{}
+	reader := multipart.NewReader(strings.NewReader(text), boundary)
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		field, ok, err := decodePart(part)
+		part.Close()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields, nil
+}
+
+func decodePart(part *multipart.Part) (FormField, bool, error) {
+	if part.FormName() == "" {
+		return FormField{}, false, nil
+	}
+
+	value, err := io.ReadAll(part)
+	if err != nil {
+		return FormField{}, false, err
+	}
+
+	if part.FileName() == "" {
+		return FormField{Key: part.FormName(), Value: string(value)}, true, nil
+	}
+
+	path, ok := strings.CutPrefix(strings.TrimSpace(string(value)), "< ")
+	if !ok {
+		return FormField{}, false, nil
+	}
+
+	field := FormField{
+		Key:         part.FormName(),
+		Value:       strings.TrimSpace(path),
+		File:        true,
+		ContentType: part.Header.Get("Content-Type"),
+	}
+	if part.FileName() != field.FileName() {
+		field.Filename = part.FileName()
+	}
+
+	return field, true, nil
+}
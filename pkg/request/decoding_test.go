@@ -0,0 +1,88 @@
+package request
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeURLEncoded(t *testing.T) {
+	t.Run("should decode to ordered fields", func(t *testing.T) {
+		res, err := DecodeURLEncoded("b=hello+world&a=1&c=%26\n")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []FormField{
+			{Key: "b", Value: "hello world"},
+			{Key: "a", Value: "1"},
+			{Key: "c", Value: "&"},
+		}, res)
+	})
+
+	t.Run("should keep repeated keys", func(t *testing.T) {
+		res, err := DecodeURLEncoded("a=1&a=2&flag")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []FormField{{Key: "a", Value: "1"}, {Key: "a", Value: "2"}, {Key: "flag"}}, res)
+	})
+
+	t.Run("should return an error on invalid escapes", func(t *testing.T) {
+		res, err := DecodeURLEncoded("a=%zz")
+
+		assert.Empty(t, res)
+		assert.Error(t, err)
+	})
+}
+
+func TestDecodeMultipart(t *testing.T) {
+	t.Run("should decode text parts and skip inline files", func(t *testing.T) {
+		text := "--b\r\nContent-Disposition: form-data; name=\"a\"\r\n\r\n1\r\n" +
+			"--b\r\nContent-Disposition: form-data; name=\"f\"; filename=\"x.txt\"\r\n\r\nfile\r\n" +
+			"--b--\r\n"
+
+		res, err := DecodeMultipart(text, "b")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []FormField{{Key: "a", Value: "1"}}, res)
+	})
+
+	t.Run("should decode file references", func(t *testing.T) {
+		text := "--b\nContent-Disposition: form-data; name=\"doc\"; filename=\"doc.pdf\"\n\n< ./doc.pdf\n" +
+			"--b\nContent-Disposition: form-data; name=\"avatar\"; filename=\"me.png\"\n" +
+			"Content-Type: image/png\n\n< ./avatar.png\n" +
+			"--b--\n"
+
+		res, err := DecodeMultipart(text, "b")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []FormField{
+			{Key: "doc", Value: "./doc.pdf", File: true},
+			{Key: "avatar", Value: "./avatar.png", File: true, ContentType: "image/png", Filename: "me.png"},
+		}, res)
+	})
+
+	t.Run("should round trip with EncodeBody", func(t *testing.T) {
+		r, ct, err := EncodeBody(`{"key":"value","other":[1,2]}`, BodyTypeFormData)
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		boundary := ct[len("multipart/form-data; boundary="):]
+
+		res, err := DecodeMultipart(string(data), boundary)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []FormField{
+			{Key: "key", Value: "value"},
+			{Key: "other", Value: "1"},
+			{Key: "other", Value: "2"},
+		}, res)
+	})
+
+	t.Run("should return an error without boundary", func(t *testing.T) {
+		_, err := DecodeMultipart("anything", "")
+
+		assert.ErrorContains(t, err, "missing multipart boundary")
+	})
+}
@@ -3,39 +3,145 @@ package request
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"mime/multipart"
+	"net/textproto"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 )
 
-func encodeURLEncoded(body string) (string, error) {
-	var data map[string]any
+type FormField struct {
+	Key         string
+	Value       string
+	File        bool
+	ContentType string
+	Filename    string
+}
+
+func ParseFormFields(body string) ([]FormField, error) {
+	fields, err := parseFormObject(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON for form-data: %w", err)
+	}
+
+	return fields, nil
+}
+
+func parseFormObject(body string) ([]FormField, error) {
+	decoder := json.NewDecoder(strings.NewReader(body))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, errors.New("expected a JSON object")
+	}
+
+	fields := []FormField{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, err
+		}
+
+		values, err := formValues(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, value := range values {
+			fields = append(fields, FormField{Key: token.(string), Value: value})
+		}
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after the JSON object")
+	}
+
+	return fields, nil
+}
+
+func formValues(raw json.RawMessage) ([]string, error) {
+	if raw[0] != '[' {
+		return []string{formValue(raw)}, nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		values = append(values, formValue(item))
+	}
+
+	return values, nil
+}
+
+func formValue(raw json.RawMessage) string {
+	switch raw[0] {
+	case '"':
+		var s string
+		json.Unmarshal(raw, &s)
+		return s
+	case 'n':
+		return ""
+	case '{', '[':
+		var buf bytes.Buffer
+		json.Compact(&buf, raw)
+		return buf.String()
+	default:
+		return string(raw)
+	}
+}
 
-	if err := json.Unmarshal([]byte(body), &data); err != nil {
+func encodeURLEncoded(body string) (string, error) {
+	fields, err := parseFormObject(body)
+	if err != nil {
 		return "", fmt.Errorf("invalid JSON for url-encoded: %w", err)
 	}
 
-	values := url.Values{}
-	for key, val := range data {
-		values.Set(key, fmt.Sprintf("%v", val))
+	return EncodeURLEncoded(fields), nil
+}
+
+func EncodeURLEncoded(fields []FormField) string {
+	pairs := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f.File {
+			continue
+		}
+		pairs = append(pairs, url.QueryEscape(f.Key)+"="+url.QueryEscape(f.Value))
 	}
 
-	return values.Encode(), nil
+	return strings.Join(pairs, "&")
 }
 
 func encodeFormData(body string) (io.Reader, string, error) {
-	var data map[string]any
-
-	if err := json.Unmarshal([]byte(body), &data); err != nil {
-		return nil, "", fmt.Errorf("invalid JSON for form-data: %w", err)
+	fields, err := ParseFormFields(body)
+	if err != nil {
+		return nil, "", err
 	}
 
 	var buf bytes.Buffer
 	writer := multipart.NewWriter(&buf)
 
-	for key, val := range data {
-		if err := writer.WriteField(key, fmt.Sprintf("%v", val)); err != nil {
+	for _, f := range fields {
+		if err := writer.WriteField(f.Key, f.Value); err != nil {
 			return nil, "", err
 		}
 	}
@@ -47,6 +153,110 @@ func encodeFormData(body string) (io.Reader, string, error) {
 	return &buf, writer.FormDataContentType(), nil
 }
 
+func EncodeForm(fields []FormField) (io.Reader, string, error) {
+	for _, f := range fields {
+		if !f.File {
+			continue
+		}
+
+		info, err := os.Stat(f.Value)
+		if err != nil {
+			return nil, "", fmt.Errorf("form-data file %q: %w", f.Key, err)
+		}
+		if info.IsDir() {
+			return nil, "", fmt.Errorf("form-data file %q: %s is a directory", f.Key, f.Value)
+		}
+	}
+
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	go func() {
+		err := writeFormFields(writer, fields)
+		if err == nil {
+			err = writer.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	return pr, writer.FormDataContentType(), nil
+}
+
+func writeFormFields(writer *multipart.Writer, fields []FormField) error {
+	for _, f := range fields {
+		if !f.File {
+			if err := writer.WriteField(f.Key, f.Value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := writeFormFile(writer, f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFormFile(writer *multipart.Writer, f FormField) error {
+	file, err := os.Open(f.Value)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	filename := f.FileName()
+
+	var part io.Writer
+	if f.ContentType == "" {
+		part, err = writer.CreateFormFile(f.Key, filename)
+	} else {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{
+			"name":     f.Key,
+			"filename": filename,
+		}))
+		header.Set("Content-Type", f.ContentType)
+		part, err = writer.CreatePart(header)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(part, file)
+	return err
+}
+
+func (f FormField) FileName() string {
+	if f.Filename != "" {
+		return f.Filename
+	}
+
+	return filepath.Base(f.Value)
+}
+
+func (m *Model) FormFields() ([]FormField, error) {
+	if m.Form != nil {
+		return m.Form, nil
+	}
+
+	return ParseFormFields(m.Body)
+}
+
+func (m *Model) EncodeBody() (io.Reader, string, error) {
+	if m.Form != nil {
+		switch m.BodyType {
+		case BodyTypeFormData:
+			return EncodeForm(m.Form)
+		case BodyTypeURLEncoded:
+			return strings.NewReader(EncodeURLEncoded(m.Form)), m.BodyType.ContentType(), nil
+		}
+	}
+
+	return EncodeBody(m.Body, m.BodyType)
+}
+
 func EncodeBody(body string, bodyType BodyType) (io.Reader, string, error) {
 	if body == "" || bodyType == BodyTypeNone {
 		return nil, "", nil
@@ -63,7 +273,39 @@ func EncodeBody(body string, bodyType BodyType) (io.Reader, string, error) {
 		return bytes.NewReader([]byte(encoded)), "application/x-www-form-urlencoded", nil
 	case BodyTypeFormData:
 		return encodeFormData(body)
+	case BodyTypeGraphQL:
+		encoded, err := encodeGraphQL(body)
+		if err != nil {
+			return nil, "", err
+		}
+		return bytes.NewReader(encoded), "application/json", nil
+	case BodyTypeXML:
+		if err := ValidateXML(body); err != nil {
+			return nil, "", err
+		}
+		return strings.NewReader(body), bodyType.ContentType(), nil
+	case BodyTypeBinary:
+		file, err := OpenBinary(body)
+		if err != nil {
+			return nil, "", err
+		}
+		return file, bodyType.ContentType(), nil
 	default:
-		return bytes.NewReader([]byte(body)), "", nil
+		return bytes.NewReader([]byte(body)), bodyType.ContentType(), nil
+	}
+}
+
+func OpenBinary(path string) (*os.File, error) {
+	path = strings.TrimSpace(path)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("binary body %q: %w", path, err)
 	}
+
+	if info.IsDir() {
+		return nil, fmt.Errorf("binary body %q: is a directory", path)
+	}
+
+	return os.Open(path)
 }
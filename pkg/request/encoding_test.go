@@ -2,6 +2,11 @@ package request
 
 import (
 	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -122,4 +127,191 @@ func TestEncodeBody(t *testing.T) {
 		assert.Empty(t, ct)
 		assert.ErrorContains(t, err, "invalid JSON for form-data")
 	})
+
+	t.Run("should send raw bodies with their content type", func(t *testing.T) {
+		for bodyType, expected := range map[BodyType]string{
+			BodyTypeText:   "text/plain",
+			BodyTypeHTML:   "text/html",
+			BodyTypeXML:    "application/xml",
+			BodyTypeCustom: "",
+		} {
+			r, ct, err := EncodeBody("<p>hi</p>", bodyType)
+
+			require.NoError(t, err)
+			data, _ := io.ReadAll(r)
+			assert.Equal(t, "<p>hi</p>", string(data))
+			assert.Equal(t, expected, ct)
+		}
+	})
+
+	t.Run("should reject invalid XML", func(t *testing.T) {
+		r, _, err := EncodeBody("<a>", BodyTypeXML)
+
+		assert.Nil(t, r)
+		assert.ErrorIs(t, err, ErrInvalidXML)
+	})
+
+	t.Run("should stream binary bodies from a file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "blob.bin")
+		require.NoError(t, os.WriteFile(path, []byte{0, 1, 2}, 0644))
+
+		r, ct, err := EncodeBody(" "+path+"\n", BodyTypeBinary)
+		require.NoError(t, err)
+		defer r.(io.Closer).Close()
+
+		data, _ := io.ReadAll(r)
+		assert.Equal(t, []byte{0, 1, 2}, data)
+		assert.Equal(t, "application/octet-stream", ct)
+	})
+
+	t.Run("should fail on missing binary files", func(t *testing.T) {
+		_, _, err := EncodeBody(filepath.Join(t.TempDir(), "missing"), BodyTypeBinary)
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("should fail on binary directories", func(t *testing.T) {
+		_, _, err := EncodeBody(t.TempDir(), BodyTypeBinary)
+
+		assert.ErrorContains(t, err, "is a directory")
+	})
+}
+
+func TestEncodeForm(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "avatar.png")
+	require.NoError(t, os.WriteFile(path, []byte("PNGDATA"), 0644))
+
+	readForm := func(t *testing.T, r io.Reader, contentType string) *multipart.Form {
+		_, params, err := mime.ParseMediaType(contentType)
+		require.NoError(t, err)
+
+		form, err := multipart.NewReader(r, params["boundary"]).ReadForm(1 << 20)
+		require.NoError(t, err)
+		return form
+	}
+
+	t.Run("should stream text and file parts", func(t *testing.T) {
+		r, ct, err := EncodeForm([]FormField{
+			{Key: "name", Value: "bob"},
+			{Key: "avatar", Value: path, File: true},
+			{Key: "doc", Value: path, File: true, ContentType: "image/png", Filename: "me.png"},
+		})
+		require.NoError(t, err)
+
+		form := readForm(t, r, ct)
+		assert.Equal(t, []string{"bob"}, form.Value["name"])
+
+		avatar := form.File["avatar"][0]
+		assert.Equal(t, "avatar.png", avatar.Filename)
+		assert.Equal(t, "application/octet-stream", avatar.Header.Get("Content-Type"))
+
+		doc := form.File["doc"][0]
+		assert.Equal(t, "me.png", doc.Filename)
+		assert.Equal(t, "image/png", doc.Header.Get("Content-Type"))
+
+		f, err := doc.Open()
+		require.NoError(t, err)
+		defer f.Close()
+		data, err := io.ReadAll(f)
+		require.NoError(t, err)
+		assert.Equal(t, "PNGDATA", string(data))
+	})
+
+	t.Run("should fail on missing files before sending", func(t *testing.T) {
+		_, _, err := EncodeForm([]FormField{{Key: "avatar", Value: filepath.Join(dir, "missing"), File: true}})
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("should fail on directories", func(t *testing.T) {
+		_, _, err := EncodeForm([]FormField{{Key: "avatar", Value: dir, File: true}})
+
+		assert.ErrorContains(t, err, "is a directory")
+	})
+
+	t.Run("should prefer form fields over the JSON body", func(t *testing.T) {
+		model := NewModel().
+			SetBodyType(BodyTypeFormData).
+			SetBody(`{"ignored":"yes"}`).
+			SetForm([]FormField{{Key: "name", Value: "bob"}})
+
+		r, ct, err := model.EncodeBody()
+		require.NoError(t, err)
+
+		form := readForm(t, r, ct)
+		assert.Equal(t, map[string][]string{"name": {"bob"}}, form.Value)
+
+		fields, err := model.FormFields()
+		require.NoError(t, err)
+		assert.Equal(t, model.Form, fields)
+	})
+}
+
+func TestParseFormFields(t *testing.T) {
+	t.Run("should return an error on invalid JSON", func(t *testing.T) {
+		fields, err := ParseFormFields("not a valid JSON")
+
+		assert.Nil(t, fields)
+		assert.ErrorContains(t, err, "invalid JSON for form-data")
+	})
+
+	t.Run("should keep the key order of the object", func(t *testing.T) {
+		fields, err := ParseFormFields(`{"b":"two","a":1}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []FormField{{Key: "b", Value: "two"}, {Key: "a", Value: "1"}}, fields)
+	})
+
+	t.Run("should format values without mangling them", func(t *testing.T) {
+		fields, err := ParseFormFields(
+			`{"n": 12345678901234567890, "f": 1.50, "b": true, "z": null, "tags": [ "a", 2 ], "o": { "y": 1, "x": [1] }}`,
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []FormField{
+			{Key: "n", Value: "12345678901234567890"},
+			{Key: "f", Value: "1.50"},
+			{Key: "b", Value: "true"},
+			{Key: "z", Value: ""},
+			{Key: "tags", Value: "a"},
+			{Key: "tags", Value: "2"},
+			{Key: "o", Value: `{"y":1,"x":[1]}`},
+		}, fields)
+	})
+
+	t.Run("should reject values other than objects", func(t *testing.T) {
+		for _, body := range []string{`["a"]`, `"a"`, `{"a":1} trailing`, `{"a":}`} {
+			_, err := ParseFormFields(body)
+
+			assert.ErrorContains(t, err, "invalid JSON for form-data", body)
+		}
+	})
+}
+
+func TestEncodeURLEncodedFields(t *testing.T) {
+	t.Run("should keep order and repeated keys and skip files", func(t *testing.T) {
+		encoded := EncodeURLEncoded([]FormField{
+			{Key: "b", Value: "x y"},
+			{Key: "a", Value: "1"},
+			{Key: "a", Value: "&"},
+			{Key: "upload", Value: "/tmp/file", File: true},
+		})
+
+		assert.Equal(t, "b=x+y&a=1&a=%26", encoded)
+	})
+
+	t.Run("should prefer form fields over the JSON body", func(t *testing.T) {
+		model := NewModel().
+			SetBodyType(BodyTypeURLEncoded).
+			SetBody(`{"ignored":"yes"}`).
+			SetForm([]FormField{})
+
+		r, ct, err := model.EncodeBody()
+		require.NoError(t, err)
+
+		data, _ := io.ReadAll(r)
+		assert.Empty(t, string(data))
+		assert.Equal(t, "application/x-www-form-urlencoded", ct)
+	})
 }
@@ -0,0 +1,87 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type GraphQL struct {
+	Query         string
+	Variables     string
+	OperationName string
+}
+
+type graphQLPayload struct {
+	Query         string          `json:"query"`
+	Variables     json.RawMessage `json:"variables,omitempty"`
+	OperationName string          `json:"operationName,omitempty"`
+}
+
+func ParseGraphQL(body string) GraphQL {
+	var payload graphQLPayload
+	if err := json.Unmarshal([]byte(body), &payload); err != nil {
+		return GraphQL{Query: body}
+	}
+
+	g := GraphQL{Query: payload.Query, OperationName: payload.OperationName}
+
+	var text string
+	switch {
+	case len(payload.Variables) == 0 || string(payload.Variables) == "null":
+	case json.Unmarshal(payload.Variables, &text) == nil:
+		g.Variables = text
+	default:
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, payload.Variables, "", "  "); err != nil {
+			g.Variables = string(payload.Variables)
+		} else {
+			g.Variables = buf.String()
+		}
+	}
+
+	return g
+}
+
+func (g GraphQL) String() string {
+	payload := graphQLPayload{Query: g.Query, OperationName: g.OperationName}
+
+	variables := strings.TrimSpace(g.Variables)
+	if variables != "" {
+		if json.Valid([]byte(variables)) {
+			payload.Variables = json.RawMessage(variables)
+		} else {
+			text, _ := json.Marshal(variables)
+			payload.Variables = text
+		}
+	}
+
+	return marshalGraphQL(payload)
+}
+
+func encodeGraphQL(body string) ([]byte, error) {
+	g := ParseGraphQL(body)
+	payload := graphQLPayload{Query: g.Query, OperationName: g.OperationName}
+
+	variables := strings.TrimSpace(g.Variables)
+	if variables != "" {
+		var data map[string]any
+		if err := json.Unmarshal([]byte(variables), &data); err != nil {
+			return nil, fmt.Errorf("invalid JSON for graphql variables: %w", err)
+		}
+		payload.Variables = json.RawMessage(variables)
+	}
+
+	return []byte(marshalGraphQL(payload)), nil
+}
+
+func marshalGraphQL(payload graphQLPayload) string {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(payload)
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,87 @@
+package request
+
+import (
+	"io"
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/variables"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGraphQL(t *testing.T) {
+	t.Run("should read query, variables and operation name", func(t *testing.T) {
+		body := `{"query":"query User($id: ID!) { user(id: $id) { name } }","variables":{"id":"1"},"operationName":"User"}`
+
+		g := ParseGraphQL(body)
+
+		assert.Equal(t, "query User($id: ID!) { user(id: $id) { name } }", g.Query)
+		assert.Equal(t, "{\n  \"id\": \"1\"\n}", g.Variables)
+		assert.Equal(t, "User", g.OperationName)
+	})
+
+	t.Run("should keep variables stored as text", func(t *testing.T) {
+		g := ParseGraphQL(`{"query":"{ me }","variables":"{\"id\": {{id}}}"}`)
+
+		assert.Equal(t, `{"id": {{id}}}`, g.Variables)
+	})
+
+	t.Run("should treat a non JSON body as the query", func(t *testing.T) {
+		g := ParseGraphQL("{ me { name } }")
+
+		assert.Equal(t, "{ me { name } }", g.Query)
+		assert.Empty(t, g.Variables)
+	})
+}
+
+func TestGraphQLString(t *testing.T) {
+	t.Run("should round trip through ParseGraphQL", func(t *testing.T) {
+		g := GraphQL{Query: "query A { a }", Variables: "{\n  \"limit\": 10\n}", OperationName: "A"}
+
+		assert.Equal(t, `{"query":"query A { a }","variables":{"limit":10},"operationName":"A"}`, g.String())
+		assert.Equal(t, g, ParseGraphQL(g.String()))
+	})
+
+	t.Run("should store invalid variables as a string", func(t *testing.T) {
+		g := GraphQL{Query: "{ a }", Variables: `{"id": {{id}}}`}
+
+		assert.Equal(t, g, ParseGraphQL(g.String()))
+	})
+}
+
+func TestEncodeGraphQL(t *testing.T) {
+	t.Run("should encode the request envelope", func(t *testing.T) {
+		body := GraphQL{Query: "query A { a }", Variables: `{"id": 1}`, OperationName: "A"}.String()
+
+		r, ct, err := EncodeBody(body, BodyTypeGraphQL)
+		require.NoError(t, err)
+
+		data, _ := io.ReadAll(r)
+		assert.Equal(t, "application/json", ct)
+		assert.JSONEq(t, `{"query":"query A { a }","variables":{"id":1},"operationName":"A"}`, string(data))
+	})
+
+	t.Run("should omit empty variables and operation name", func(t *testing.T) {
+		data, err := encodeGraphQL("{ me }")
+
+		require.NoError(t, err)
+		assert.Equal(t, `{"query":"{ me }"}`, string(data))
+	})
+
+	t.Run("should encode variables resolved after storage", func(t *testing.T) {
+		body := GraphQL{Query: "{ a }", Variables: `{"id": {{id}}}`}.String()
+
+		data, err := encodeGraphQL(variables.Resolve(body, map[string]string{"id": "7"}))
+
+		require.NoError(t, err)
+		assert.Equal(t, `{"query":"{ a }","variables":{"id":7}}`, string(data))
+	})
+
+	t.Run("should return an error when variables are not an object", func(t *testing.T) {
+		body := GraphQL{Query: "{ a }", Variables: `{"id": {{id}}}`}.String()
+
+		_, err := encodeGraphQL(body)
+
+		assert.ErrorContains(t, err, "invalid JSON for graphql variables")
+	})
+}
@@ -3,11 +3,15 @@ package request
 import (
 	"context"
 	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/variables"
 )
 
 func NewModel() *Model {
 	return &Model{
-		Headers: make(map[string]string),
+		Headers: []Header{},
 		Timeout: DefaultTimeout,
 	}
 }
@@ -37,16 +41,86 @@ func (m *Model) SetBodyType(bodyType BodyType) *Model {
 	return m
 }
 
+func (m *Model) SetForm(fields []FormField) *Model {
+	m.Form = fields
+	return m
+}
+
 func (m *Model) AddHeader(key, value string) *Model {
-	if m.Headers == nil {
-		m.Headers = make(map[string]string)
+	m.Headers = append(m.Headers, Header{Key: key, Value: value})
+	return m
+}
+
+func (m *Model) SetHeader(key, value string) *Model {
+	matches := func(h Header) bool { return strings.EqualFold(h.Key, key) }
+
+	i := slices.IndexFunc(m.Headers, matches)
+	if i < 0 {
+		return m.AddHeader(key, value)
 	}
-	m.Headers[key] = value
+
+	m.Headers[i] = Header{Key: key, Value: value}
+	rest := slices.DeleteFunc(m.Headers[i+1:], matches)
+	m.Headers = m.Headers[:i+1+len(rest)]
+	return m
+}
+
+func (m *Model) RemoveHeader(key string) *Model {
+	m.Headers = slices.DeleteFunc(m.Headers, func(h Header) bool { return strings.EqualFold(h.Key, key) })
 	return m
 }
 
+func (m *Model) HeaderValue(key string) (string, bool) {
+	for _, h := range m.Headers {
+		if strings.EqualFold(h.Key, key) {
+			return h.Value, true
+		}
+	}
+
+	return "", false
+}
+
+func (m *Model) HTTPHeader() http.Header {
+	header := make(http.Header, len(m.Headers))
+	for _, h := range m.Headers {
+		header.Add(h.Key, h.Value)
+	}
+
+	return header
+}
+
 func (m *Model) ClearHeaders() *Model {
-	m.Headers = make(map[string]string)
+	m.Headers = []Header{}
+	return m
+}
+
+func (m *Model) SetCompression(c Compression) *Model {
+	m.Compression = c
+	return m
+}
+
+func (m *Model) SetDisableDecompression(disable bool) *Model {
+	m.DisableDecompression = disable
+	return m
+}
+
+func (m *Model) SetRetry(policy RetryPolicy) *Model {
+	m.Retry = policy
+	return m
+}
+
+func (m *Model) SetHTTPVersion(version HTTPVersion) *Model {
+	m.HTTPVersion = version
+	return m
+}
+
+func (m *Model) SetDisableKeepAlives(disable bool) *Model {
+	m.DisableKeepAlives = disable
+	return m
+}
+
+func (m *Model) SetMaxIdleConns(n int) *Model {
+	m.MaxIdleConns = n
 	return m
 }
 
@@ -67,3 +141,37 @@ func (m *Model) SetClient(client *http.Client) *Model {
 	m.Client = client
 	return m
 }
+
+func (m *Model) SetMaxBodySize(size int64) *Model {
+	m.MaxBodySize = size
+	return m
+}
+
+func (m *Model) SetProgress(progress ProgressFunc) *Model {
+	m.Progress = progress
+	return m
+}
+
+func (m *Model) ResolveVariables(vars map[string]string) *Model {
+	m.URL = variables.Resolve(m.URL, vars)
+	m.Body = variables.Resolve(m.Body, vars)
+
+	headers := make([]Header, len(m.Headers))
+	for i, h := range m.Headers {
+		headers[i] = Header{Key: variables.Resolve(h.Key, vars), Value: variables.Resolve(h.Value, vars)}
+	}
+	m.Headers = headers
+
+	if m.Form != nil {
+		form := make([]FormField, len(m.Form))
+		for i, f := range m.Form {
+			f.Key = variables.Resolve(f.Key, vars)
+			f.Value = variables.Resolve(f.Value, vars)
+			f.Filename = variables.Resolve(f.Filename, vars)
+			form[i] = f
+		}
+		m.Form = form
+	}
+
+	return m
+}
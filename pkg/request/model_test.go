@@ -23,7 +23,7 @@ func TestNewModel(t *testing.T) {
 		assert.Equal(t, DefaultTimeout, model.Timeout)
 	})
 
-	t.Run("should have an initialized header map", func(t *testing.T) {
+	t.Run("should have an initialized header list", func(t *testing.T) {
 		model := NewModel()
 
 		assert.NotNil(t, model.Headers)
@@ -78,7 +78,57 @@ func TestModelSetters(t *testing.T) {
 		key := "Content-Type"
 		value := "application/json"
 		model.AddHeader(key, value)
-		assert.Equal(t, value, model.Headers[key])
+		assert.Equal(t, []Header{{Key: key, Value: value}}, model.Headers)
+	})
+
+	t.Run("AddHeader should keep repeated keys in order", func(t *testing.T) {
+		model := NewModel().
+			AddHeader("Accept", "text/html").
+			AddHeader("X-Id", "1").
+			AddHeader("Accept", "application/json")
+
+		assert.Equal(t, []Header{
+			{Key: "Accept", Value: "text/html"},
+			{Key: "X-Id", Value: "1"},
+			{Key: "Accept", Value: "application/json"},
+		}, model.Headers)
+		assert.Equal(t, []string{"text/html", "application/json"}, model.HTTPHeader().Values("Accept"))
+	})
+
+	t.Run("SetHeader should replace every value of a key in place", func(t *testing.T) {
+		model := NewModel().
+			AddHeader("accept", "text/html").
+			AddHeader("X-Id", "1").
+			AddHeader("Accept", "application/json").
+			SetHeader("Accept", "*/*").
+			SetHeader("X-New", "2")
+
+		assert.Equal(t, []Header{
+			{Key: "Accept", Value: "*/*"},
+			{Key: "X-Id", Value: "1"},
+			{Key: "X-New", Value: "2"},
+		}, model.Headers)
+	})
+
+	t.Run("RemoveHeader should remove every value of a key", func(t *testing.T) {
+		model := NewModel().
+			AddHeader("Cookie", "a=1").
+			AddHeader("X-Id", "1").
+			AddHeader("cookie", "b=2").
+			RemoveHeader("COOKIE")
+
+		assert.Equal(t, []Header{{Key: "X-Id", Value: "1"}}, model.Headers)
+	})
+
+	t.Run("HeaderValue should return the first value ignoring case", func(t *testing.T) {
+		model := NewModel().AddHeader("Accept", "a").AddHeader("ACCEPT", "b")
+
+		value, ok := model.HeaderValue("accept")
+		assert.True(t, ok)
+		assert.Equal(t, "a", value)
+
+		_, ok = model.HeaderValue("X-Missing")
+		assert.False(t, ok)
 	})
 
 	t.Run("SetTimeout should set a valid timeout", func(t *testing.T) {
@@ -123,4 +173,33 @@ func TestModelSetters(t *testing.T) {
 
 		assert.Equal(t, BodyTypeJSON, model.BodyType)
 	})
+
+	t.Run("ResolveVariables should resolve the URL, headers and body", func(t *testing.T) {
+		model := NewModel().
+			SetURL("{{host}}/users").
+			SetBody(`{"id":"{{id}}"}`).
+			AddHeader("Authorization", "Bearer {{token}}")
+
+		model.ResolveVariables(map[string]string{
+			"host":  "http://localhost",
+			"id":    "42",
+			"token": "secret",
+		})
+
+		assert.Equal(t, "http://localhost/users", model.URL)
+		assert.Equal(t, `{"id":"42"}`, model.Body)
+		assert.Equal(t, []Header{{Key: "Authorization", Value: "Bearer secret"}}, model.Headers)
+	})
+
+	t.Run("ResolveVariables should resolve form fields", func(t *testing.T) {
+		model := NewModel().SetForm([]FormField{
+			{Key: "{{field}}", Value: "{{dir}}/avatar.png", File: true, Filename: "{{name}}.png"},
+		})
+
+		model.ResolveVariables(map[string]string{"field": "avatar", "dir": "/tmp", "name": "me"})
+
+		assert.Equal(t, []FormField{
+			{Key: "avatar", Value: "/tmp/avatar.png", File: true, Filename: "me.png"},
+		}, model.Form)
+	})
 }
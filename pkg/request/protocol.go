@@ -0,0 +1,147 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type HTTPVersion string
+
+const (
+	HTTPVersionAuto HTTPVersion = ""
+	HTTPVersion11   HTTPVersion = "http/1.1"
+	HTTPVersion2    HTTPVersion = "h2"
+	HTTPVersionH2C  HTTPVersion = "h2c"
+)
+
+var (
+	ErrHTTP2RequiresTLS     = errors.New("h2 needs an https URL, use h2c for cleartext HTTP/2")
+	ErrUnsupportedTransport = errors.New("connection settings need an *http.Transport")
+)
+
+var HTTPVersions = []HTTPVersion{HTTPVersionAuto, HTTPVersion11, HTTPVersion2, HTTPVersionH2C}
+
+type transportKey struct {
+	version           HTTPVersion
+	disableKeepAlives bool
+	maxIdleConns      int
+}
+
+var (
+	transportsMu sync.Mutex
+	transports   = map[transportKey]*http.Transport{}
+)
+
+func (v HTTPVersion) String() string {
+	if v == HTTPVersionAuto {
+		return "auto"
+	}
+	return string(v)
+}
+
+func ParseHTTPVersion(s string) HTTPVersion {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1.1", "http/1.1", "http1.1", "http1":
+		return HTTPVersion11
+	case "2", "h2", "http/2", "http2":
+		return HTTPVersion2
+	case "h2c":
+		return HTTPVersionH2C
+	default:
+		return HTTPVersionAuto
+	}
+}
+
+func (m *Model) ConfigureTransport(t *http.Transport) {
+	var protocols http.Protocols
+
+	switch m.HTTPVersion {
+	case HTTPVersion11:
+		protocols.SetHTTP1(true)
+		t.Protocols = &protocols
+		if t.TLSClientConfig != nil {
+			t.TLSClientConfig = t.TLSClientConfig.Clone()
+			t.TLSClientConfig.NextProtos = slices.DeleteFunc(t.TLSClientConfig.NextProtos, func(p string) bool {
+				return p == "h2"
+			})
+		}
+	case HTTPVersion2:
+		protocols.SetHTTP2(true)
+		t.Protocols = &protocols
+	case HTTPVersionH2C:
+		protocols.SetUnencryptedHTTP2(true)
+		t.Protocols = &protocols
+	}
+
+	if m.DisableKeepAlives {
+		t.DisableKeepAlives = true
+	}
+
+	if m.MaxIdleConns > 0 {
+		t.MaxIdleConns = m.MaxIdleConns
+		t.MaxIdleConnsPerHost = m.MaxIdleConns
+	}
+}
+
+func (m *Model) httpClient() (*http.Client, func(), error) {
+	key := transportKey{m.HTTPVersion, m.DisableKeepAlives, m.MaxIdleConns}
+
+	if m.Client == nil {
+		if key == (transportKey{}) {
+			return http.DefaultClient, func() {}, nil
+		}
+		return &http.Client{Transport: defaultTransport(m, key)}, func() {}, nil
+	}
+
+	if key == (transportKey{}) {
+		return m.Client, func() {}, nil
+	}
+
+	roundTripper := m.Client.Transport
+	if roundTripper == nil {
+		roundTripper = http.DefaultTransport
+	}
+
+	base, ok := roundTripper.(*http.Transport)
+	if !ok {
+		return nil, nil, ErrUnsupportedTransport
+	}
+
+	t := base.Clone()
+	m.ConfigureTransport(t)
+
+	client := *m.Client
+	client.Transport = t
+
+	return &client, t.CloseIdleConnections, nil
+}
+
+func defaultTransport(m *Model, key transportKey) *http.Transport {
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+
+	t, ok := transports[key]
+	if !ok {
+		t = http.DefaultTransport.(*http.Transport).Clone()
+		m.ConfigureTransport(t)
+		transports[key] = t
+	}
+
+	return t
+}
+
+func (r Response) HTTP3Advertised() bool {
+	for _, value := range http.Header(r.Headers).Values("Alt-Svc") {
+		for _, service := range strings.Split(value, ",") {
+			protocol, _, _ := strings.Cut(strings.TrimSpace(service), "=")
+			if protocol == "h3" || strings.HasPrefix(protocol, "h3-") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
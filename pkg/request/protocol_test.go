@@ -0,0 +1,212 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var protoHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Close", strconv.FormatBool(r.Close))
+	w.Write([]byte(r.Proto))
+})
+
+func newHTTP2Server(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(protoHandler)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newH2CServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(protoHandler)
+	server.Config.Protocols = new(http.Protocols)
+	server.Config.Protocols.SetHTTP1(true)
+	server.Config.Protocols.SetUnencryptedHTTP2(true)
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func tlsClient(server *httptest.Server, model *Model) *http.Client {
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	model.ConfigureTransport(transport)
+	return &http.Client{Transport: transport}
+}
+
+func TestParseHTTPVersion(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, HTTPVersion11, ParseHTTPVersion("HTTP/1.1"))
+	assert.Equal(t, HTTPVersion2, ParseHTTPVersion("h2"))
+	assert.Equal(t, HTTPVersion2, ParseHTTPVersion("2"))
+	assert.Equal(t, HTTPVersionH2C, ParseHTTPVersion("h2c"))
+	assert.Equal(t, HTTPVersionAuto, ParseHTTPVersion("auto"))
+	assert.Equal(t, HTTPVersionAuto, ParseHTTPVersion("h3"))
+	assert.Equal(t, "auto", HTTPVersionAuto.String())
+}
+
+func TestSendRequestHTTPVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should negotiate HTTP/2 over TLS", func(t *testing.T) {
+		server := newHTTP2Server(t)
+
+		for _, version := range []HTTPVersion{HTTPVersionAuto, HTTPVersion2} {
+			req := NewModel().SetMethod(GET).SetURL(server.URL).SetHTTPVersion(version)
+			req.SetClient(tlsClient(server, req))
+
+			res, err := SendRequest(req)
+
+			require.NoError(t, err, version)
+			assert.Equal(t, "HTTP/2.0", res.Proto, version)
+			assert.Equal(t, "HTTP/2.0", res.Body, version)
+		}
+	})
+
+	t.Run("should force HTTP/1.1 against an HTTP/2 server", func(t *testing.T) {
+		server := newHTTP2Server(t)
+
+		req := NewModel().SetMethod(GET).SetURL(server.URL).SetHTTPVersion(HTTPVersion11)
+		req.SetClient(tlsClient(server, req))
+
+		res, err := SendRequest(req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "HTTP/1.1", res.Proto)
+	})
+
+	t.Run("should speak h2c with prior knowledge", func(t *testing.T) {
+		server := newH2CServer(t)
+
+		res, err := SendRequest(NewModel().SetMethod(GET).SetURL(server.URL).SetHTTPVersion(HTTPVersionH2C))
+		require.NoError(t, err)
+		assert.Equal(t, "HTTP/2.0", res.Proto)
+		assert.Equal(t, "HTTP/2.0", res.Body)
+
+		res, err = SendRequest(NewModel().SetMethod(GET).SetURL(server.URL))
+		require.NoError(t, err)
+		assert.Equal(t, "HTTP/1.1", res.Proto)
+	})
+
+	t.Run("should fail to send h2 without TLS", func(t *testing.T) {
+		server := newH2CServer(t)
+
+		_, err := SendRequest(NewModel().SetMethod(GET).SetURL(server.URL).SetHTTPVersion(HTTPVersion2))
+		assert.ErrorIs(t, err, ErrHTTP2RequiresTLS)
+	})
+
+	t.Run("should close the connection when keep-alive is disabled", func(t *testing.T) {
+		server := httptest.NewServer(protoHandler)
+		defer server.Close()
+
+		res, err := SendRequest(NewModel().SetMethod(GET).SetURL(server.URL).SetDisableKeepAlives(true))
+		require.NoError(t, err)
+		assert.Equal(t, "true", http.Header(res.Headers).Get("X-Close"))
+
+		res, err = SendRequest(NewModel().SetMethod(GET).SetURL(server.URL))
+		require.NoError(t, err)
+		assert.Equal(t, "false", http.Header(res.Headers).Get("X-Close"))
+	})
+}
+
+func TestConfigureTransport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should leave the transport alone by default", func(t *testing.T) {
+		transport := &http.Transport{MaxIdleConns: 100}
+
+		NewModel().ConfigureTransport(transport)
+
+		assert.Nil(t, transport.Protocols)
+		assert.False(t, transport.DisableKeepAlives)
+		assert.Equal(t, 100, transport.MaxIdleConns)
+	})
+
+	t.Run("should apply the connection settings", func(t *testing.T) {
+		transport := &http.Transport{}
+
+		NewModel().SetHTTPVersion(HTTPVersionH2C).SetDisableKeepAlives(true).SetMaxIdleConns(3).
+			ConfigureTransport(transport)
+
+		require.NotNil(t, transport.Protocols)
+		assert.True(t, transport.Protocols.UnencryptedHTTP2())
+		assert.False(t, transport.Protocols.HTTP1())
+		assert.True(t, transport.DisableKeepAlives)
+		assert.Equal(t, 3, transport.MaxIdleConns)
+		assert.Equal(t, 3, transport.MaxIdleConnsPerHost)
+	})
+
+	t.Run("should reuse one transport per setting", func(t *testing.T) {
+		a, _, err := NewModel().SetHTTPVersion(HTTPVersion11).httpClient()
+		require.NoError(t, err)
+		b, _, err := NewModel().SetHTTPVersion(HTTPVersion11).httpClient()
+		require.NoError(t, err)
+
+		assert.Same(t, a.Transport, b.Transport)
+
+		client, _, err := NewModel().httpClient()
+		require.NoError(t, err)
+		assert.Same(t, http.DefaultClient, client)
+	})
+
+	t.Run("should configure a copy of an injected client without caching it", func(t *testing.T) {
+		transport := &http.Transport{}
+		injected := &http.Client{Transport: transport, Timeout: time.Second}
+
+		transportsMu.Lock()
+		cached := len(transports)
+		transportsMu.Unlock()
+
+		client, release, err := NewModel().SetClient(injected).SetHTTPVersion(HTTPVersionH2C).httpClient()
+		require.NoError(t, err)
+		defer release()
+
+		assert.NotSame(t, transport, client.Transport)
+		assert.True(t, client.Transport.(*http.Transport).Protocols.UnencryptedHTTP2())
+		assert.Nil(t, transport.Protocols)
+		assert.Equal(t, time.Second, client.Timeout)
+
+		transportsMu.Lock()
+		assert.Len(t, transports, cached)
+		transportsMu.Unlock()
+
+		client, _, err = NewModel().SetClient(injected).httpClient()
+		require.NoError(t, err)
+		assert.Same(t, injected, client)
+	})
+
+	t.Run("should reject connection settings on a custom round tripper", func(t *testing.T) {
+		injected := &http.Client{Transport: roundTripperFunc(http.DefaultTransport.RoundTrip)}
+
+		_, _, err := NewModel().SetClient(injected).SetDisableKeepAlives(true).httpClient()
+		assert.ErrorIs(t, err, ErrUnsupportedTransport)
+	})
+}
+
+func TestHTTP3Advertised(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Response{Headers: http.Header{"Alt-Svc": {`h3=":443"; ma=86400`}}}.HTTP3Advertised())
+	assert.True(t, Response{Headers: http.Header{"Alt-Svc": {`h2=":443", h3-29=":443"`}}}.HTTP3Advertised())
+	assert.False(t, Response{Headers: http.Header{"Alt-Svc": {`h2=":443"`}}}.HTTP3Advertised())
+	assert.False(t, Response{}.HTTP3Advertised())
+}
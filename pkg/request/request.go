@@ -2,9 +2,13 @@ package request
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 )
 
@@ -14,33 +18,112 @@ func SendRequest(model *Model) (*Response, error) {
 		ctx = context.Background()
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, time.Duration(model.Timeout)*time.Millisecond)
-	defer cancel()
+	policy := model.Retry
+	if !policy.Enabled() || !idempotent(model) {
+		return send(ctx, model)
+	}
+
+	var attempts []Attempt
+	for n := 1; ; n++ {
+		start := time.Now()
+		res, err := send(ctx, model)
+		attempt := newAttempt(res, err, time.Since(start))
+
+		delay, ok := policy.delay(n, res)
+		if n >= policy.MaxAttempts || ctx.Err() != nil || !policy.retryable(res, err) || !ok {
+			attempts = append(attempts, attempt)
+			if err != nil {
+				return nil, &RetryError{Attempts: attempts, Err: err}
+			}
+			res.Attempts = attempts
+			return res, nil
+		}
+
+		attempt.Wait = delay
+		attempts = append(attempts, attempt)
+		if res != nil {
+			res.Remove()
+		}
+
+		if err := wait(ctx, delay); err != nil {
+			return nil, &RetryError{Attempts: attempts, Err: err}
+		}
+	}
+}
+
+func send(ctx context.Context, model *Model) (*Response, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
 
-	bodyReader, contentType, err := EncodeBody(model.Body, model.BodyType)
+	timer := time.AfterFunc(time.Duration(model.Timeout)*time.Millisecond, func() {
+		cancel(context.DeadlineExceeded)
+	})
+	defer timer.Stop()
+
+	bodyReader, contentType, err := model.EncodeBody()
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode body: %w", err)
 	}
 
+	compressed := model.Compression != CompressionNone && bodyReader != nil
+	if compressed {
+		bodyReader, err = compress(bodyReader, model.Compression)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress body: %w", err)
+		}
+	}
+
 	req, err := http.NewRequestWithContext(ctx, model.MethodString(), model.URL, bodyReader)
 	if err != nil {
+		if closer, ok := bodyReader.(io.Closer); ok {
+			closer.Close()
+		}
 		return nil, err
 	}
 
+	if model.HTTPVersion == HTTPVersion2 && req.URL.Scheme != "https" {
+		if closer, ok := bodyReader.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, ErrHTTP2RequiresTLS
+	}
+
+	if file, ok := bodyReader.(*os.File); ok {
+		if info, err := file.Stat(); err == nil {
+			req.ContentLength = info.Size()
+		}
+	}
+
 	if contentType != "" {
-		if _, exists := model.Headers["Content-Type"]; !exists {
+		if _, exists := model.HeaderValue("Content-Type"); !exists {
 			req.Header.Set("Content-Type", contentType)
 		}
 	}
 
-	for key, value := range model.Headers {
-		req.Header.Add(key, value)
+	for _, h := range model.Headers {
+		req.Header.Add(h.Key, h.Value)
+	}
+
+	if missingBoundary(req.Header.Get("Content-Type"), contentType) {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if compressed && req.Header.Get("Content-Encoding") == "" {
+		req.Header.Set("Content-Encoding", string(model.Compression))
+	}
+
+	if req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
 	}
 
-	client := model.Client
-	if client == nil {
-		client = http.DefaultClient
+	client, release, err := model.httpClient()
+	if err != nil {
+		if closer, ok := bodyReader.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, err
 	}
+	defer release()
 
 	startTime := time.Now()
 
@@ -52,17 +135,79 @@ func SendRequest(model *Model) (*Response, error) {
 
 	timeTaken := time.Since(startTime).Milliseconds()
 
-	bodyBytes, err := io.ReadAll(resp.Body)
+	wire := &countingReader{r: resp.Body}
+	body := io.ReadCloser(io.NopCloser(wire))
+	decoded := false
+	if !model.DisableDecompression {
+		body, decoded, err = decompress(wire, resp.Header.Get("Content-Encoding"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer body.Close()
+
+	if model.Stream != nil && IsEventStream(resp.Header) {
+		timer.Stop()
+		return stream(model.Stream, resp, body, timeTaken, model.MaxBodySize)
+	}
+
+	total := resp.ContentLength
+	if decoded {
+		total = -1
+	}
+
+	response, err := readBody(body, total, model.MaxBodySize, model.Progress)
 	if err != nil {
 		return nil, err
 	}
 
+	response.StatusCode = resp.StatusCode
+	response.Proto = resp.Proto
+	response.Headers = resp.Header
+	response.TimeTaken = timeTaken
+	response.WireSize = wire.n
+	response.Encoding = resp.Header.Get("Content-Encoding")
+	response.Decoded = decoded
+
+	return response, nil
+}
+
+func IsEventStream(headers http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(headers.Get("Content-Type"))
+	return err == nil && mediaType == "text/event-stream"
+}
+
+func stream(fn StreamFunc, resp *http.Response, body io.Reader, timeTaken, limit int64) (*Response, error) {
 	response := &Response{
 		StatusCode: resp.StatusCode,
+		Proto:      resp.Proto,
 		Headers:    resp.Header,
 		TimeTaken:  timeTaken,
-		Body:       string(bodyBytes),
 	}
 
-	return response, nil
+	raw := newBodyReader(nil, -1, limit, nil)
+	err := fn(response, io.TeeReader(body, raw))
+
+	copied, cerr := raw.response()
+	if cerr != nil {
+		raw.discard()
+		return nil, cerr
+	}
+	response.Body, response.Size, response.BodyFile = copied.Body, copied.Size, copied.BodyFile
+
+	if errors.Is(err, context.Canceled) {
+		err = nil
+	}
+
+	return response, err
+}
+
+func missingBoundary(header, encoded string) bool {
+	mediaType, params, err := mime.ParseMediaType(header)
+	if err != nil || mediaType != "multipart/form-data" {
+		return false
+	}
+
+	_, ok := params["boundary"]
+	return !ok && strings.HasPrefix(encoded, mediaType)
 }
@@ -1,11 +1,20 @@
 package request
 
 import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSendRequest(t *testing.T) {
@@ -43,6 +52,60 @@ func TestSendRequest(t *testing.T) {
 		assert.Equal(t, 200, resp.StatusCode)
 	})
 
+	t.Run("should upload form-data files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "notes.txt")
+		require.NoError(t, os.WriteFile(path, []byte("hello"), 0644))
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			file, header, err := r.FormFile("upload")
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			defer file.Close()
+
+			data, _ := io.ReadAll(file)
+			fmt.Fprintf(w, "%s:%s:%s", r.FormValue("name"), header.Filename, data)
+		}))
+		defer server.Close()
+
+		req := NewModel().SetMethod(POST).
+			SetURL(server.URL).
+			SetBodyType(BodyTypeFormData).
+			AddHeader("Content-Type", "multipart/form-data").
+			SetForm([]FormField{
+				{Key: "name", Value: "bob"},
+				{Key: "upload", Value: path, File: true},
+			})
+
+		resp, err := SendRequest(req)
+
+		require.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "bob:notes.txt:hello", string(resp.Body))
+	})
+
+	t.Run("should stream binary bodies with a content length", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "blob.bin")
+		require.NoError(t, os.WriteFile(path, []byte("0123456789"), 0644))
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			fmt.Fprintf(w, "%d %v %s %s", r.ContentLength, r.TransferEncoding, r.Header.Get("Content-Type"), data)
+		}))
+		defer server.Close()
+
+		req := NewModel().SetMethod(PUT).
+			SetURL(server.URL).
+			SetBodyType(BodyTypeBinary).
+			SetBody(path)
+
+		resp, err := SendRequest(req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "10 [] application/octet-stream 0123456789", string(resp.Body))
+	})
+
 	t.Run("should read response body correctly", func(t *testing.T) {
 		expectedBody := "Hello, World!"
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -75,6 +138,23 @@ func TestSendRequest(t *testing.T) {
 		assert.Equal(t, 200, resp.StatusCode)
 	})
 
+	t.Run("should send every value of a repeated header", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, strings.Join(r.Header.Values("Accept"), "|"))
+		}))
+		defer server.Close()
+
+		req := NewModel().SetMethod(GET).
+			SetURL(server.URL).
+			AddHeader("Accept", "text/html").
+			AddHeader("Accept", "application/json")
+
+		res, err := SendRequest(req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "text/html|application/json", res.Body)
+	})
+
 	t.Run("should handle http.NewRequest error", func(t *testing.T) {
 		req := NewModel().SetMethod(GET).
 			SetURL("http://%41:8080/")
@@ -124,3 +204,107 @@ func TestSendRequest(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func newEventStreamServer(events int, interval time.Duration) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+
+		for i := range events {
+			fmt.Fprintf(w, "id: %d\ndata: tick\n\n", i)
+			w.(http.Flusher).Flush()
+
+			select {
+			case <-time.After(interval):
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}))
+}
+
+func TestSendRequestStream(t *testing.T) {
+	t.Run("should hand event streams to the stream function without the timeout", func(t *testing.T) {
+		server := newEventStreamServer(4, 50*time.Millisecond)
+		defer server.Close()
+
+		var lines []string
+		req := NewModel().SetMethod(GET).SetURL(server.URL).SetTimeout(100)
+		req.Stream = func(res *Response, body io.Reader) error {
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+
+			scanner := bufio.NewScanner(body)
+			for scanner.Scan() {
+				if line := scanner.Text(); line != "" {
+					lines = append(lines, line)
+				}
+			}
+			return scanner.Err()
+		}
+
+		res, err := SendRequest(req)
+
+		require.NoError(t, err)
+		assert.Len(t, lines, 8)
+		assert.Contains(t, res.Body, "id: 3\ndata: tick\n\n")
+	})
+
+	t.Run("should stop quietly when the context is cancelled", func(t *testing.T) {
+		server := newEventStreamServer(100, 20*time.Millisecond)
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		req := NewModel().SetMethod(GET).SetURL(server.URL).SetContext(ctx)
+		req.Stream = func(res *Response, body io.Reader) error {
+			time.AfterFunc(50*time.Millisecond, cancel)
+			_, err := io.Copy(io.Discard, body)
+			return err
+		}
+
+		res, err := SendRequest(req)
+
+		require.NoError(t, err)
+		assert.Contains(t, res.Body, "id: 0")
+	})
+
+	t.Run("should spill streams past the body size limit", func(t *testing.T) {
+		server := newEventStreamServer(20, 0)
+		defer server.Close()
+
+		req := NewModel().SetMethod(GET).SetURL(server.URL).SetMaxBodySize(32)
+		req.Stream = func(res *Response, body io.Reader) error {
+			_, err := io.Copy(io.Discard, body)
+			return err
+		}
+
+		res, err := SendRequest(req)
+		require.NoError(t, err)
+		defer res.Remove()
+
+		assert.Len(t, res.Body, 32)
+		assert.True(t, res.Truncated())
+
+		data, err := os.ReadFile(res.BodyFile)
+		require.NoError(t, err)
+		assert.Equal(t, res.Size, int64(len(data)))
+		assert.Contains(t, string(data), "id: 19\ndata: tick\n\n")
+	})
+
+	t.Run("should read other responses fully", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("plain"))
+		}))
+		defer server.Close()
+
+		req := NewModel().SetMethod(GET).SetURL(server.URL)
+		req.Stream = func(*Response, io.Reader) error {
+			t.Fatal("stream function should not be called")
+			return nil
+		}
+
+		res, err := SendRequest(req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "plain", res.Body)
+	})
+}
@@ -0,0 +1,185 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	RetryErrorReset   = "reset"
+	RetryErrorRefused = "refused"
+	RetryErrorTimeout = "timeout"
+	RetryErrorEOF     = "eof"
+)
+
+const (
+	DefaultRetryBackoff    = 500 * time.Millisecond
+	DefaultRetryMaxBackoff = 10 * time.Second
+)
+
+var (
+	DefaultRetryStatuses = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+	DefaultRetryErrors   = []string{RetryErrorReset, RetryErrorRefused, RetryErrorEOF}
+	RetryErrors          = []string{RetryErrorReset, RetryErrorRefused, RetryErrorTimeout, RetryErrorEOF}
+)
+
+type RetryPolicy struct {
+	MaxAttempts int
+	Statuses    []int
+	Errors      []string
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+type Attempt struct {
+	StatusCode int
+	Error      string
+	TimeTaken  int64
+	Wait       time.Duration
+}
+
+type RetryError struct {
+	Attempts []Attempt
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%s (after %d attempts)", e.Err, len(e.Attempts))
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+func (p RetryPolicy) Enabled() bool {
+	return p.MaxAttempts > 1
+}
+
+func (p RetryPolicy) statuses() []int {
+	if len(p.Statuses) == 0 {
+		return DefaultRetryStatuses
+	}
+	return p.Statuses
+}
+
+func (p RetryPolicy) errors() []string {
+	if len(p.Errors) == 0 {
+		return DefaultRetryErrors
+	}
+	return p.Errors
+}
+
+func (p RetryPolicy) backoff() (time.Duration, time.Duration) {
+	base, ceiling := p.Backoff, p.MaxBackoff
+	if base <= 0 {
+		base = DefaultRetryBackoff
+	}
+	if ceiling <= 0 {
+		ceiling = DefaultRetryMaxBackoff
+	}
+	return base, max(base, ceiling)
+}
+
+func (p RetryPolicy) retryable(res *Response, err error) bool {
+	if err != nil {
+		kind := errorKind(err)
+		return kind != "" && slices.Contains(p.errors(), kind)
+	}
+
+	return slices.Contains(p.statuses(), res.StatusCode)
+}
+
+func (p RetryPolicy) delay(attempt int, res *Response) (time.Duration, bool) {
+	base, ceiling := p.backoff()
+
+	if res != nil {
+		if wait, ok := retryAfter(res.Headers); ok {
+			return wait, wait <= ceiling
+		}
+	}
+
+	wait := ceiling
+	if shift := attempt - 1; shift < 32 && base<<shift < ceiling {
+		wait = base << shift
+	}
+
+	return wait/2 + rand.N(wait/2+1), true
+}
+
+func retryAfter(headers http.Header) (time.Duration, bool) {
+	value := headers.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+
+	return 0, false
+}
+
+func errorKind(err error) string {
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, syscall.ECONNRESET):
+		return RetryErrorReset
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return RetryErrorRefused
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return RetryErrorEOF
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return RetryErrorTimeout
+	default:
+		return ""
+	}
+}
+
+func idempotent(model *Model) bool {
+	switch model.Method {
+	case GET, HEAD, OPTIONS, TRACE, PUT, DELETE:
+		return true
+	}
+
+	for _, key := range []string{"Idempotency-Key", "X-Idempotency-Key"} {
+		if _, ok := model.HeaderValue(key); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newAttempt(res *Response, err error, elapsed time.Duration) Attempt {
+	if err != nil {
+		return Attempt{Error: err.Error(), TimeTaken: elapsed.Milliseconds()}
+	}
+
+	return Attempt{StatusCode: res.StatusCode, TimeTaken: res.TimeTaken}
+}
+
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
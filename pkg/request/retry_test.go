@@ -0,0 +1,211 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFlakyServer(failures int32, status int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if calls.Add(1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write(append([]byte("ok "), body...))
+	}))
+
+	return server, &calls
+}
+
+func fastRetry(attempts int) RetryPolicy {
+	return RetryPolicy{MaxAttempts: attempts, Backoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func TestSendRequestRetry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should retry retryable statuses until success", func(t *testing.T) {
+		server, calls := newFlakyServer(2, http.StatusServiceUnavailable, nil)
+		defer server.Close()
+
+		res, err := SendRequest(NewModel().SetMethod(GET).SetURL(server.URL).SetRetry(fastRetry(3)))
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), calls.Load())
+		assert.Equal(t, 200, res.StatusCode)
+		require.Len(t, res.Attempts, 3)
+		assert.Equal(t, 503, res.Attempts[0].StatusCode)
+		assert.Positive(t, res.Attempts[0].Wait)
+		assert.Equal(t, 200, res.Attempts[2].StatusCode)
+		assert.Zero(t, res.Attempts[2].Wait)
+	})
+
+	t.Run("should return the last response once attempts run out", func(t *testing.T) {
+		server, calls := newFlakyServer(5, http.StatusBadGateway, nil)
+		defer server.Close()
+
+		res, err := SendRequest(NewModel().SetMethod(GET).SetURL(server.URL).SetRetry(fastRetry(2)))
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Equal(t, 502, res.StatusCode)
+		assert.Len(t, res.Attempts, 2)
+	})
+
+	t.Run("should not retry statuses outside the policy", func(t *testing.T) {
+		server, calls := newFlakyServer(1, http.StatusInternalServerError, nil)
+		defer server.Close()
+
+		res, err := SendRequest(NewModel().SetMethod(GET).SetURL(server.URL).SetRetry(fastRetry(3)))
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, 500, res.StatusCode)
+		assert.Len(t, res.Attempts, 1)
+	})
+
+	t.Run("should use custom statuses", func(t *testing.T) {
+		server, calls := newFlakyServer(1, http.StatusInternalServerError, nil)
+		defer server.Close()
+
+		policy := fastRetry(3)
+		policy.Statuses = []int{500}
+
+		res, err := SendRequest(NewModel().SetMethod(GET).SetURL(server.URL).SetRetry(policy))
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Equal(t, 200, res.StatusCode)
+	})
+
+	t.Run("should only retry non-idempotent methods with an idempotency key", func(t *testing.T) {
+		server, calls := newFlakyServer(1, http.StatusServiceUnavailable, nil)
+		defer server.Close()
+
+		res, err := SendRequest(NewModel().SetMethod(POST).SetURL(server.URL).SetRetry(fastRetry(3)))
+		require.NoError(t, err)
+		assert.Equal(t, 503, res.StatusCode)
+		assert.Nil(t, res.Attempts)
+
+		res, err = SendRequest(NewModel().SetMethod(POST).SetURL(server.URL).SetBodyType(BodyTypeText).SetBody("payload").
+			SetHeader("Idempotency-Key", "abc").SetRetry(fastRetry(3)))
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Equal(t, "ok payload", res.Body)
+	})
+
+	t.Run("should resend the body on every attempt", func(t *testing.T) {
+		server, _ := newFlakyServer(1, http.StatusServiceUnavailable, nil)
+		defer server.Close()
+
+		res, err := SendRequest(NewModel().SetMethod(PUT).SetURL(server.URL).SetBodyType(BodyTypeText).SetBody("payload").
+			SetRetry(fastRetry(2)))
+
+		require.NoError(t, err)
+		assert.Equal(t, "ok payload", res.Body)
+	})
+
+	t.Run("should honor Retry-After", func(t *testing.T) {
+		server, _ := newFlakyServer(1, http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}})
+		defer server.Close()
+
+		res, err := SendRequest(NewModel().SetMethod(GET).SetURL(server.URL).SetRetry(fastRetry(2)))
+
+		require.NoError(t, err)
+		assert.Equal(t, 200, res.StatusCode)
+		assert.Zero(t, res.Attempts[0].Wait)
+	})
+
+	t.Run("should give up when Retry-After exceeds the maximum backoff", func(t *testing.T) {
+		server, calls := newFlakyServer(1, http.StatusServiceUnavailable, http.Header{"Retry-After": {"120"}})
+		defer server.Close()
+
+		res, err := SendRequest(NewModel().SetMethod(GET).SetURL(server.URL).SetRetry(fastRetry(3)))
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, 503, res.StatusCode)
+	})
+
+	t.Run("should retry connection errors and report every attempt", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := listener.Addr().String()
+		listener.Close()
+
+		_, err = SendRequest(NewModel().SetMethod(GET).SetURL("http://" + addr).SetRetry(fastRetry(3)))
+
+		var retryErr *RetryError
+		require.ErrorAs(t, err, &retryErr)
+		assert.Len(t, retryErr.Attempts, 3)
+		assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+		assert.Contains(t, err.Error(), "(after 3 attempts)")
+	})
+
+	t.Run("should stop waiting when the context is cancelled", func(t *testing.T) {
+		server, calls := newFlakyServer(5, http.StatusServiceUnavailable, nil)
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		policy := RetryPolicy{MaxAttempts: 5, Backoff: time.Minute, MaxBackoff: time.Minute}
+		_, err := SendRequest(NewModel().SetMethod(GET).SetURL(server.URL).SetContext(ctx).SetRetry(policy))
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{MaxAttempts: 5, Backoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	t.Run("should grow exponentially with jitter up to the maximum", func(t *testing.T) {
+		for attempt, ceiling := range map[int]time.Duration{1: 100, 2: 200, 3: 400, 4: 800, 5: 1000, 40: 1000} {
+			delay, ok := policy.delay(attempt, nil)
+
+			assert.True(t, ok)
+			assert.GreaterOrEqual(t, delay, ceiling*time.Millisecond/2, attempt)
+			assert.LessOrEqual(t, delay, ceiling*time.Millisecond, attempt)
+		}
+	})
+
+	t.Run("should read Retry-After as seconds or a date", func(t *testing.T) {
+		delay, ok := policy.delay(1, &Response{Headers: http.Header{"Retry-After": {"1"}}})
+		assert.True(t, ok)
+		assert.Equal(t, time.Second, delay)
+
+		date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+		_, ok = policy.delay(1, &Response{Headers: http.Header{"Retry-After": {date}}})
+		assert.False(t, ok)
+	})
+}
+
+func TestErrorKind(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, RetryErrorReset, errorKind(fmt.Errorf("read: %w", syscall.ECONNRESET)))
+	assert.Equal(t, RetryErrorRefused, errorKind(fmt.Errorf("dial: %w", syscall.ECONNREFUSED)))
+	assert.Equal(t, RetryErrorEOF, errorKind(io.ErrUnexpectedEOF))
+	assert.Equal(t, RetryErrorTimeout, errorKind(context.DeadlineExceeded))
+	assert.Empty(t, errorKind(fmt.Errorf("invalid URL")))
+}
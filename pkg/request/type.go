@@ -2,7 +2,11 @@ package request
 
 import (
 	"context"
+	"io"
+	"mime"
 	"net/http"
+	"strings"
+	"time"
 )
 
 type HTTPMethod string
@@ -19,24 +23,68 @@ const (
 	CONNECT HTTPMethod = http.MethodConnect
 )
 
-const DefaultTimeout int64 = 30000
+const (
+	DefaultTimeout     int64 = 30000
+	DefaultMaxBodySize int64 = 10 << 20
+)
+
+const (
+	chunkSize        = 32 << 10
+	previewSize      = 64 << 10
+	progressInterval = 100 * time.Millisecond
+	tempFilePattern  = "gostman-body-*"
+)
+
+type Header struct {
+	Key   string
+	Value string
+}
 
 type Model struct {
-	Ctx      context.Context
-	Method   HTTPMethod
-	URL      string
-	Body     string
-	BodyType BodyType
-	Headers  map[string]string
-	Timeout  int64
-	Client   *http.Client
+	Ctx                  context.Context
+	Method               HTTPMethod
+	URL                  string
+	Body                 string
+	BodyType             BodyType
+	Form                 []FormField
+	Headers              []Header
+	Compression          Compression
+	DisableDecompression bool
+	Retry                RetryPolicy
+	HTTPVersion          HTTPVersion
+	DisableKeepAlives    bool
+	MaxIdleConns         int
+	Timeout              int64
+	Client               *http.Client
+	Stream               StreamFunc
+	MaxBodySize          int64
+	Progress             ProgressFunc
+}
+
+type StreamFunc func(res *Response, body io.Reader) error
+
+type ProgressFunc func(p Progress)
+
+type Progress struct {
+	Received int64
+	Total    int64
+	Elapsed  time.Duration
+	Preview  string
+	Done     bool
 }
 
 type Response struct {
 	TimeTaken  int64
 	StatusCode int
+	Proto      string
 	Headers    map[string][]string
 	Body       string
+	Size       int64
+	WireSize   int64
+	Encoding   string
+	Decoded    bool
+	BodyFile   string
+	Attempts   []Attempt
 }
 
 type BodyType uint
@@ -46,4 +94,96 @@ const (
 	BodyTypeJSON
 	BodyTypeFormData
 	BodyTypeURLEncoded
+	BodyTypeGraphQL
+	BodyTypeText
+	BodyTypeXML
+	BodyTypeHTML
+	BodyTypeCustom
+	BodyTypeBinary
 )
+
+func (b BodyType) String() string {
+	switch b {
+	case BodyTypeJSON:
+		return "json"
+	case BodyTypeFormData:
+		return "form-data"
+	case BodyTypeURLEncoded:
+		return "urlencoded"
+	case BodyTypeGraphQL:
+		return "graphql"
+	case BodyTypeText:
+		return "text"
+	case BodyTypeXML:
+		return "xml"
+	case BodyTypeHTML:
+		return "html"
+	case BodyTypeCustom:
+		return "custom"
+	case BodyTypeBinary:
+		return "binary"
+	default:
+		return "none"
+	}
+}
+
+func (b BodyType) ContentType() string {
+	switch b {
+	case BodyTypeJSON, BodyTypeGraphQL:
+		return "application/json"
+	case BodyTypeFormData:
+		return "multipart/form-data"
+	case BodyTypeURLEncoded:
+		return "application/x-www-form-urlencoded"
+	case BodyTypeText:
+		return "text/plain"
+	case BodyTypeXML:
+		return "application/xml"
+	case BodyTypeHTML:
+		return "text/html"
+	case BodyTypeBinary:
+		return "application/octet-stream"
+	default:
+		return ""
+	}
+}
+
+func ParseBodyType(s string) BodyType {
+	switch s {
+	case "json":
+		return BodyTypeJSON
+	case "form-data":
+		return BodyTypeFormData
+	case "urlencoded":
+		return BodyTypeURLEncoded
+	case "graphql":
+		return BodyTypeGraphQL
+	case "text":
+		return BodyTypeText
+	case "xml":
+		return BodyTypeXML
+	case "html":
+		return BodyTypeHTML
+	case "custom":
+		return BodyTypeCustom
+	case "binary":
+		return BodyTypeBinary
+	default:
+		return BodyTypeNone
+	}
+}
+
+func RawBodyType(contentType string) BodyType {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == "" || mediaType == "text/plain":
+		return BodyTypeText
+	case mediaType == "text/html":
+		return BodyTypeHTML
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		return BodyTypeXML
+	default:
+		return BodyTypeCustom
+	}
+}
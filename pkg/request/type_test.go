@@ -0,0 +1,52 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBodyTypeString(t *testing.T) {
+	t.Run("should round trip through ParseBodyType", func(t *testing.T) {
+		for _, bodyType := range []BodyType{
+			BodyTypeNone,
+			BodyTypeJSON,
+			BodyTypeFormData,
+			BodyTypeURLEncoded,
+			BodyTypeGraphQL,
+			BodyTypeText,
+			BodyTypeXML,
+			BodyTypeHTML,
+			BodyTypeCustom,
+			BodyTypeBinary,
+		} {
+			assert.Equal(t, bodyType, ParseBodyType(bodyType.String()))
+		}
+	})
+
+	t.Run("should fall back to none on unknown values", func(t *testing.T) {
+		assert.Equal(t, BodyTypeNone, ParseBodyType("unknown"))
+		assert.Equal(t, "none", BodyType(42).String())
+	})
+}
+
+func TestRawBodyType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		expected    BodyType
+	}{
+		{"", BodyTypeText},
+		{"text/plain; charset=utf-8", BodyTypeText},
+		{"text/html", BodyTypeHTML},
+		{"application/xml", BodyTypeXML},
+		{"text/xml", BodyTypeXML},
+		{"application/soap+xml", BodyTypeXML},
+		{"application/vnd.api+yaml", BodyTypeCustom},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RawBodyType(tt.contentType))
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package request
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var ErrInvalidXML = errors.New("invalid XML")
+
+func ValidateXML(body string) error {
+	decoder := xml.NewDecoder(strings.NewReader(body))
+	root := false
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidXML, err)
+		}
+
+		if _, ok := token.(xml.StartElement); ok {
+			root = true
+		}
+	}
+
+	if !root {
+		return fmt.Errorf("%w: no root element", ErrInvalidXML)
+	}
+
+	return nil
+}
+
+func FormatXML(body string) (string, error) {
+	if err := ValidateXML(body); err != nil {
+		return "", err
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(body))
+	f := &xmlFormatter{}
+
+	for {
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrInvalidXML, err)
+		}
+
+		f.write(token)
+	}
+
+	return f.b.String(), nil
+}
+
+type xmlFormatter struct {
+	b     strings.Builder
+	depth int
+	open  bool
+	text  bool
+}
+
+func (f *xmlFormatter) write(token xml.Token) {
+	switch t := token.(type) {
+	case xml.StartElement:
+		f.closeStart()
+		f.newline()
+		f.b.WriteString("<" + xmlName(t.Name))
+		for _, attr := range t.Attr {
+			f.b.WriteString(" " + xmlName(attr.Name) + `="`)
+			xml.EscapeText(&f.b, []byte(attr.Value))
+			f.b.WriteString(`"`)
+		}
+		f.open = true
+		f.text = false
+		f.depth++
+	case xml.EndElement:
+		f.depth--
+		switch {
+		case f.open:
+			f.b.WriteString("/>")
+			f.open = false
+		case f.text:
+			f.b.WriteString("</" + xmlName(t.Name) + ">")
+		default:
+			f.newline()
+			f.b.WriteString("</" + xmlName(t.Name) + ">")
+		}
+		f.text = false
+	case xml.CharData:
+		data := strings.TrimSpace(string(t))
+		if data == "" {
+			return
+		}
+		f.closeStart()
+		xml.EscapeText(&f.b, []byte(data))
+		f.text = true
+	case xml.Comment:
+		f.closeStart()
+		f.newline()
+		f.b.WriteString("<!--" + string(t) + "-->")
+	case xml.ProcInst:
+		f.newline()
+		f.b.WriteString("<?" + t.Target + " " + string(t.Inst) + "?>")
+	case xml.Directive:
+		f.newline()
+		f.b.WriteString("<!" + string(t) + ">")
+	}
+}
+
+func (f *xmlFormatter) closeStart() {
+	if f.open {
+		f.b.WriteString(">")
+		f.open = false
+	}
+}
+
+func (f *xmlFormatter) newline() {
+	if f.b.Len() > 0 {
+		f.b.WriteString("\n" + strings.Repeat("  ", f.depth))
+	}
+}
+
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+
+	return name.Space + ":" + name.Local
+}
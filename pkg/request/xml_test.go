@@ -0,0 +1,50 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateXML(t *testing.T) {
+	t.Run("should accept well-formed documents", func(t *testing.T) {
+		assert.NoError(t, ValidateXML(`<?xml version="1.0"?><a><b/></a>`))
+	})
+
+	t.Run("should reject mismatched tags", func(t *testing.T) {
+		assert.ErrorIs(t, ValidateXML(`<a><b></a>`), ErrInvalidXML)
+	})
+
+	t.Run("should reject documents without a root element", func(t *testing.T) {
+		assert.ErrorIs(t, ValidateXML("plain text"), ErrInvalidXML)
+	})
+}
+
+func TestFormatXML(t *testing.T) {
+	t.Run("should indent nested elements and keep prefixes", func(t *testing.T) {
+		formatted, err := FormatXML(
+			`<?xml version="1.0"?><soap:Envelope xmlns:soap="urn:x"><b a="&lt;">x &amp; y</b><c></c></soap:Envelope>`,
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, `<?xml version="1.0"?>
+<soap:Envelope xmlns:soap="urn:x">
+  <b a="&lt;">x &amp; y</b>
+  <c/>
+</soap:Envelope>`, formatted)
+	})
+
+	t.Run("should drop whitespace between elements", func(t *testing.T) {
+		formatted, err := FormatXML("<a>\n    <b>1</b>\n\n</a>")
+
+		require.NoError(t, err)
+		assert.Equal(t, "<a>\n  <b>1</b>\n</a>", formatted)
+	})
+
+	t.Run("should return an error on invalid XML", func(t *testing.T) {
+		_, err := FormatXML("<a>")
+
+		assert.ErrorIs(t, err, ErrInvalidXML)
+	})
+}
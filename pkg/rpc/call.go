@@ -0,0 +1,165 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func Dial(target string) (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+
+	for _, scheme := range []string{"grpcs://", "https://"} {
+		if rest, ok := strings.CutPrefix(target, scheme); ok {
+			target = rest
+			creds = credentials.NewTLS(&tls.Config{})
+		}
+	}
+	for _, scheme := range []string{"grpc://", "http://"} {
+		target = strings.TrimPrefix(target, scheme)
+	}
+
+	return grpc.NewClient(strings.TrimSuffix(target, "/"), grpc.WithTransportCredentials(creds))
+}
+
+func Invoke(
+	ctx context.Context,
+	conn grpc.ClientConnInterface,
+	method Method,
+	headers http.Header,
+	body string,
+	onResponse func(string),
+) (*Result, error) {
+	messages, err := decodeMessages(method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	md := metadata.MD{}
+	for key, values := range headers {
+		md.Append(key, values...)
+	}
+
+	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(ctx, md))
+	defer cancel()
+
+	desc := &grpc.StreamDesc{
+		StreamName:    method.Name,
+		ClientStreams: method.ClientStreaming(),
+		ServerStreams: method.ServerStreaming(),
+	}
+
+	start := time.Now()
+	result := &Result{}
+
+	stream, err := conn.NewStream(ctx, desc, "/"+method.FullName())
+	if err != nil {
+		return finish(result, start, err), nil
+	}
+
+	for _, msg := range messages {
+		if err := stream.SendMsg(msg); err != nil {
+			break
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return finish(result, start, err), nil
+	}
+
+	var rpcErr error
+	for {
+		out := dynamicpb.NewMessage(method.Output)
+		err := stream.RecvMsg(out)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				rpcErr = err
+			}
+			break
+		}
+
+		text := Format(out)
+		result.Responses = append(result.Responses, text)
+		if onResponse != nil {
+			onResponse(text)
+		}
+	}
+
+	result.Headers, _ = stream.Header()
+	result.Trailers = stream.Trailer()
+
+	return finish(result, start, rpcErr), nil
+}
+
+func Format(msg proto.Message) string {
+	data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(msg)
+	if err != nil {
+		return err.Error()
+	}
+
+	return indent(data)
+}
+
+func decodeMessages(method Method, body string) ([]proto.Message, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return []proto.Message{dynamicpb.NewMessage(method.Input)}, nil
+	}
+
+	raw := []json.RawMessage{json.RawMessage(body)}
+	if method.ClientStreaming() && strings.HasPrefix(body, "[") {
+		raw = nil
+		if err := json.Unmarshal([]byte(body), &raw); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidMessage, err)
+		}
+	}
+
+	messages := make([]proto.Message, 0, len(raw))
+	for i, data := range raw {
+		msg := dynamicpb.NewMessage(method.Input)
+		if err := protojson.Unmarshal(data, msg); err != nil {
+			return nil, fmt.Errorf("%w %d: %w", ErrInvalidMessage, i+1, err)
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+func finish(result *Result, start time.Time, err error) *Result {
+	st := status.Convert(err)
+
+	result.Code = st.Code()
+	result.Message = st.Message()
+	result.TimeTaken = time.Since(start).Milliseconds()
+
+	return result
+}
+
+func indent(data []byte) string {
+	var compact, out bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		return string(data)
+	}
+	if err := json.Indent(&out, compact.Bytes(), "", "  "); err != nil {
+		return compact.String()
+	}
+
+	return out.String()
+}
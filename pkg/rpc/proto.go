@@ -0,0 +1,60 @@
+package rpc
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func LoadProtos(ctx context.Context, paths []string) (*Registry, error) {
+	var importPaths, names []string
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+
+		dir := filepath.Dir(abs)
+		if !slices.Contains(importPaths, dir) {
+			importPaths = append(importPaths, dir)
+		}
+		names = append(names, filepath.Base(abs))
+	}
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: importPaths}),
+	}
+
+	compiled, err := compiler.Compile(ctx, names...)
+	if err != nil {
+		return nil, err
+	}
+
+	files := new(protoregistry.Files)
+	for _, fd := range compiled {
+		if err := register(files, fd); err != nil {
+			return nil, err
+		}
+	}
+
+	return newRegistry(files)
+}
+
+func register(files *protoregistry.Files, fd protoreflect.FileDescriptor) error {
+	if _, err := files.FindFileByPath(fd.Path()); err == nil {
+		return nil
+	}
+
+	imports := fd.Imports()
+	for i := range imports.Len() {
+		if err := register(files, imports.Get(i).FileDescriptor); err != nil {
+			return err
+		}
+	}
+
+	return files.RegisterFile(fd)
+}
@@ -0,0 +1,140 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+type reflectionClient struct {
+	stream reflectionpb.ServerReflection_ServerReflectionInfoClient
+	protos map[string]*descriptorpb.FileDescriptorProto
+}
+
+func Reflect(ctx context.Context, conn grpc.ClientConnInterface) (*Registry, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrReflection, err)
+	}
+
+	c := &reflectionClient{stream: stream, protos: map[string]*descriptorpb.FileDescriptorProto{}}
+
+	res, err := c.do(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{ListServices: "*"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, service := range res.GetListServicesResponse().GetService() {
+		if strings.HasPrefix(service.GetName(), reflectionPackage) {
+			continue
+		}
+
+		err := c.fetch(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{
+				FileContainingSymbol: service.GetName(),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.resolveDependencies(); err != nil {
+		return nil, err
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, fd := range c.protos {
+		set.File = append(set.File, fd)
+	}
+
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrReflection, err)
+	}
+
+	return newRegistry(files)
+}
+
+func (c *reflectionClient) do(req *reflectionpb.ServerReflectionRequest) (*reflectionpb.ServerReflectionResponse, error) {
+	if err := c.stream.Send(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrReflection, err)
+	}
+
+	res, err := c.stream.Recv()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrReflection, err)
+	}
+
+	if e := res.GetErrorResponse(); e != nil {
+		return nil, fmt.Errorf("%w: %s", ErrReflection, e.GetErrorMessage())
+	}
+
+	return res, nil
+}
+
+func (c *reflectionClient) fetch(req *reflectionpb.ServerReflectionRequest) error {
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+
+	for _, data := range res.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		fd := &descriptorpb.FileDescriptorProto{}
+		if err := proto.Unmarshal(data, fd); err != nil {
+			return fmt.Errorf("%w: %w", ErrReflection, err)
+		}
+		c.protos[fd.GetName()] = fd
+	}
+
+	return nil
+}
+
+func (c *reflectionClient) resolveDependencies() error {
+	for {
+		missing := c.missing()
+		if missing == "" {
+			return nil
+		}
+
+		if fd, err := protoregistry.GlobalFiles.FindFileByPath(missing); err == nil {
+			c.protos[missing] = protodesc.ToFileDescriptorProto(fd)
+			continue
+		}
+
+		err := c.fetch(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: missing},
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, ok := c.protos[missing]; !ok {
+			return fmt.Errorf("%w: missing file %s", ErrReflection, missing)
+		}
+	}
+}
+
+func (c *reflectionClient) missing() string {
+	for _, fd := range c.protos {
+		for _, dep := range fd.GetDependency() {
+			if _, ok := c.protos[dep]; !ok {
+				return dep
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func newRegistry(files *protoregistry.Files) (*Registry, error) {
+	r := &Registry{files: files}
+
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		services := fd.Services()
+		for i := range services.Len() {
+			sd := services.Get(i)
+			if strings.HasPrefix(string(sd.FullName()), reflectionPackage) {
+				continue
+			}
+
+			methods := sd.Methods()
+			for j := range methods.Len() {
+				r.methods = append(r.methods, newMethod(methods.Get(j)))
+			}
+		}
+		return true
+	})
+
+	if len(r.methods) == 0 {
+		return nil, ErrNoServices
+	}
+
+	slices.SortFunc(r.methods, func(a, b Method) int {
+		return strings.Compare(a.FullName(), b.FullName())
+	})
+
+	return r, nil
+}
+
+func newMethod(md protoreflect.MethodDescriptor) Method {
+	kind := KindUnary
+	switch {
+	case md.IsStreamingClient() && md.IsStreamingServer():
+		kind = KindBidiStreaming
+	case md.IsStreamingClient():
+		kind = KindClientStreaming
+	case md.IsStreamingServer():
+		kind = KindServerStreaming
+	}
+
+	return Method{
+		Service: string(md.Parent().FullName()),
+		Name:    string(md.Name()),
+		Kind:    kind,
+		Input:   md.Input(),
+		Output:  md.Output(),
+	}
+}
+
+func (m Method) FullName() string {
+	return m.Service + "/" + m.Name
+}
+
+func (m Method) ClientStreaming() bool {
+	return m.Kind == KindClientStreaming || m.Kind == KindBidiStreaming
+}
+
+func (m Method) ServerStreaming() bool {
+	return m.Kind == KindServerStreaming || m.Kind == KindBidiStreaming
+}
+
+func (r *Registry) Methods() []Method {
+	return r.methods
+}
+
+func (r *Registry) Find(name string) (Method, error) {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "/")
+	if i := strings.LastIndex(name, "/"); i < 0 {
+		if j := strings.LastIndex(name, "."); j >= 0 {
+			name = name[:j] + "/" + name[j+1:]
+		}
+	}
+
+	for _, m := range r.methods {
+		if m.FullName() == name {
+			return m, nil
+		}
+	}
+
+	return Method{}, fmt.Errorf("%w: %s", ErrMethodNotFound, name)
+}
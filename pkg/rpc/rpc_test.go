@@ -0,0 +1,337 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func loadTestRegistry(t *testing.T) *Registry {
+	registry, err := LoadProtos(context.Background(), []string{"testdata/greeter.proto"})
+	require.NoError(t, err)
+
+	return registry
+}
+
+func startServer(t *testing.T, withReflection bool) *grpc.ClientConn {
+	registry := loadTestRegistry(t)
+	server := grpc.NewServer()
+
+	method := func(name string) Method {
+		m, err := registry.Find("greeter.v1.Greeter/" + name)
+		require.NoError(t, err)
+		return m
+	}
+
+	reply := func(m Method, text string) *dynamicpb.Message {
+		msg := dynamicpb.NewMessage(m.Output)
+		msg.Set(m.Output.Fields().ByName("message"), protoreflect.ValueOfString(text))
+		return msg
+	}
+
+	name := func(msg *dynamicpb.Message) string {
+		return msg.Get(msg.Descriptor().Fields().ByName("name")).String()
+	}
+
+	sayHello, streamHellos, collectHellos, chat := method("SayHello"), method("StreamHellos"), method("CollectHellos"), method("Chat")
+
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "greeter.v1.Greeter",
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "SayHello",
+			Handler: func(_ any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+				in := dynamicpb.NewMessage(sayHello.Input)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				if name(in) == "" {
+					return nil, status.Error(codes.InvalidArgument, "name is required")
+				}
+
+				md, _ := metadata.FromIncomingContext(ctx)
+				grpc.SetHeader(ctx, metadata.Pairs("x-greeting", "hi"))
+				grpc.SetTrailer(ctx, metadata.Pairs("x-user", strings.Join(md.Get("x-user"), ",")))
+
+				return reply(sayHello, "hello "+name(in)), nil
+			},
+		}},
+		Streams: []grpc.StreamDesc{
+			{
+				StreamName:    "StreamHellos",
+				ServerStreams: true,
+				Handler: func(_ any, stream grpc.ServerStream) error {
+					in := dynamicpb.NewMessage(streamHellos.Input)
+					if err := stream.RecvMsg(in); err != nil {
+						return err
+					}
+
+					count := in.Get(streamHellos.Input.Fields().ByName("count")).Int()
+					for range count {
+						if err := stream.SendMsg(reply(streamHellos, "hello "+name(in))); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			},
+			{
+				StreamName:    "CollectHellos",
+				ClientStreams: true,
+				Handler: func(_ any, stream grpc.ServerStream) error {
+					var names []string
+					for {
+						in := dynamicpb.NewMessage(collectHellos.Input)
+						err := stream.RecvMsg(in)
+						if errors.Is(err, io.EOF) {
+							break
+						}
+						if err != nil {
+							return err
+						}
+						names = append(names, name(in))
+					}
+					return stream.SendMsg(reply(collectHellos, "hello "+strings.Join(names, ", ")))
+				},
+			},
+			{
+				StreamName:    "Chat",
+				ClientStreams: true,
+				ServerStreams: true,
+				Handler: func(_ any, stream grpc.ServerStream) error {
+					for {
+						in := dynamicpb.NewMessage(chat.Input)
+						err := stream.RecvMsg(in)
+						if errors.Is(err, io.EOF) {
+							return nil
+						}
+						if err != nil {
+							return err
+						}
+						if err := stream.SendMsg(reply(chat, "hi "+name(in))); err != nil {
+							return err
+						}
+					}
+				},
+			},
+		},
+	}, struct{}{})
+
+	if withReflection {
+		reflectionpb.RegisterServerReflectionServer(server, reflection.NewServerV1(reflection.ServerOptions{
+			Services:           server,
+			DescriptorResolver: registry.files,
+		}))
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := Dial("grpc://" + lis.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func TestLoadProtos(t *testing.T) {
+	t.Run("should list the methods of every service", func(t *testing.T) {
+		registry := loadTestRegistry(t)
+
+		var names []string
+		for _, m := range registry.Methods() {
+			names = append(names, m.FullName()+" "+string(m.Kind))
+		}
+
+		assert.Equal(t, []string{
+			"greeter.v1.Greeter/Chat bidi streaming",
+			"greeter.v1.Greeter/CollectHellos client streaming",
+			"greeter.v1.Greeter/SayHello unary",
+			"greeter.v1.Greeter/StreamHellos server streaming",
+		}, names)
+	})
+
+	t.Run("should fail on a missing file", func(t *testing.T) {
+		_, err := LoadProtos(context.Background(), []string{"testdata/missing.proto"})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail without services", func(t *testing.T) {
+		_, err := LoadProtos(context.Background(), []string{"testdata/types.proto"})
+
+		assert.ErrorIs(t, err, ErrNoServices)
+	})
+}
+
+func TestRegistryFind(t *testing.T) {
+	registry := loadTestRegistry(t)
+
+	for _, name := range []string{"greeter.v1.Greeter/SayHello", "/greeter.v1.Greeter/SayHello", "greeter.v1.Greeter.SayHello"} {
+		m, err := registry.Find(name)
+
+		require.NoError(t, err, name)
+		assert.Equal(t, "SayHello", m.Name)
+	}
+
+	_, err := registry.Find("greeter.v1.Greeter/Missing")
+	assert.ErrorIs(t, err, ErrMethodNotFound)
+}
+
+func TestReflect(t *testing.T) {
+	t.Run("should discover services through server reflection", func(t *testing.T) {
+		conn := startServer(t, true)
+
+		registry, err := Reflect(context.Background(), conn)
+		require.NoError(t, err)
+
+		m, err := registry.Find("greeter.v1.Greeter/SayHello")
+		require.NoError(t, err)
+		assert.Equal(t, KindUnary, m.Kind)
+		assert.Len(t, registry.Methods(), 4)
+	})
+
+	t.Run("should fail when reflection is not available", func(t *testing.T) {
+		conn := startServer(t, false)
+
+		_, err := Reflect(context.Background(), conn)
+
+		assert.ErrorIs(t, err, ErrReflection)
+	})
+}
+
+func TestTemplate(t *testing.T) {
+	registry := loadTestRegistry(t)
+	m, err := registry.Find("greeter.v1.Greeter/SayHello")
+	require.NoError(t, err)
+
+	assert.Equal(t, `{
+  "name": "",
+  "count": 0,
+  "mood": "MOOD_UNSPECIFIED",
+  "address": {
+    "city": "",
+    "previous": [
+      {
+        "city": "",
+        "previous": [
+          {
+            "city": "",
+            "previous": [
+              {
+                "city": "",
+                "previous": []
+              }
+            ]
+          }
+        ]
+      }
+    ]
+  },
+  "tags": [
+    ""
+  ],
+  "email": "",
+  "at": null,
+  "labels": {}
+}`, Template(m.Input))
+}
+
+func TestInvoke(t *testing.T) {
+	conn := startServer(t, false)
+	registry := loadTestRegistry(t)
+
+	find := func(name string) Method {
+		m, err := registry.Find("greeter.v1.Greeter/" + name)
+		require.NoError(t, err)
+		return m
+	}
+
+	t.Run("should make unary calls with metadata", func(t *testing.T) {
+		md := http.Header{"X-User": {"bob", "alice"}}
+		res, err := Invoke(context.Background(), conn, find("SayHello"), md, `{"name": "world"}`, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, codes.OK, res.Code)
+		assert.Equal(t, []string{"{\n  \"message\": \"hello world\"\n}"}, res.Responses)
+		assert.Equal(t, []string{"hi"}, res.Headers.Get("x-greeting"))
+		assert.Equal(t, []string{"bob,alice"}, res.Trailers.Get("x-user"))
+	})
+
+	t.Run("should report status codes", func(t *testing.T) {
+		res, err := Invoke(context.Background(), conn, find("SayHello"), nil, "", nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, codes.InvalidArgument, res.Code)
+		assert.Equal(t, "name is required", res.Message)
+		assert.Empty(t, res.Responses)
+	})
+
+	t.Run("should stream server responses as they arrive", func(t *testing.T) {
+		var received []string
+
+		res, err := Invoke(context.Background(), conn, find("StreamHellos"), nil, `{"name": "a", "count": 3}`, func(s string) {
+			received = append(received, s)
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, codes.OK, res.Code)
+		assert.Len(t, res.Responses, 3)
+		assert.Equal(t, res.Responses, received)
+	})
+
+	t.Run("should send a JSON array as a client stream", func(t *testing.T) {
+		res, err := Invoke(context.Background(), conn, find("CollectHellos"), nil, `[{"name": "a"}, {"name": "b"}]`, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"{\n  \"message\": \"hello a, b\"\n}"}, res.Responses)
+	})
+
+	t.Run("should handle bidirectional streams", func(t *testing.T) {
+		res, err := Invoke(context.Background(), conn, find("Chat"), nil, `[{"name": "a"}, {"name": "b"}]`, nil)
+		require.NoError(t, err)
+
+		assert.Len(t, res.Responses, 2)
+	})
+
+	t.Run("should reject invalid messages", func(t *testing.T) {
+		_, err := Invoke(context.Background(), conn, find("SayHello"), nil, `{"nope": 1}`, nil)
+		assert.ErrorIs(t, err, ErrInvalidMessage)
+
+		_, err = Invoke(context.Background(), conn, find("CollectHellos"), nil, `[1, 2`, nil)
+		assert.ErrorIs(t, err, ErrInvalidMessage)
+	})
+
+	t.Run("should report unreachable servers as unavailable", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := lis.Addr().String()
+		lis.Close()
+
+		closed, err := Dial(addr)
+		require.NoError(t, err)
+		defer closed.Close()
+
+		res, err := Invoke(context.Background(), closed, find("SayHello"), nil, `{"name": "a"}`, nil)
+		require.NoError(t, err)
+		assert.Equal(t, codes.Unavailable, res.Code)
+	})
+}
@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func Template(desc protoreflect.MessageDescriptor) string {
+	msg := dynamicpb.NewMessage(desc)
+	fill(msg, templateDepth)
+
+	data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(msg)
+	if err != nil {
+		return "{}"
+	}
+
+	return indent(data)
+}
+
+func fill(msg protoreflect.Message, depth int) {
+	fields := msg.Descriptor().Fields()
+	seen := map[protoreflect.FullName]bool{}
+
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+
+		if oneof := fd.ContainingOneof(); oneof != nil {
+			if seen[oneof.FullName()] {
+				continue
+			}
+			seen[oneof.FullName()] = true
+		}
+
+		switch {
+		case fd.IsMap():
+			continue
+		case fd.IsList():
+			if fd.Message() != nil && !fillable(fd.Message(), depth) {
+				continue
+			}
+			list := msg.Mutable(fd).List()
+			list.Append(element(list.NewElement(), fd, depth))
+		case fd.Message() != nil:
+			if fillable(fd.Message(), depth) {
+				fill(msg.Mutable(fd).Message(), depth-1)
+			}
+		case fd.ContainingOneof() != nil:
+			msg.Set(fd, fd.Default())
+		}
+	}
+}
+
+func element(v protoreflect.Value, fd protoreflect.FieldDescriptor, depth int) protoreflect.Value {
+	if fd.Message() != nil {
+		fill(v.Message(), depth-1)
+		return v
+	}
+
+	if fd.Kind() == protoreflect.EnumKind {
+		return protoreflect.ValueOfEnum(fd.Enum().Values().Get(0).Number())
+	}
+
+	return v
+}
+
+func fillable(desc protoreflect.MessageDescriptor, depth int) bool {
+	return depth > 0 && !strings.HasPrefix(string(desc.FullName()), wellKnownPackage)
+}
@@ -0,0 +1,54 @@
+package rpc
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+var (
+	ErrNoServices     = errors.New("no services found")
+	ErrMethodNotFound = errors.New("method not found")
+	ErrInvalidMessage = errors.New("invalid message")
+	ErrReflection     = errors.New("server reflection failed")
+)
+
+const (
+	templateDepth     = 4
+	reflectionPackage = "grpc.reflection."
+	wellKnownPackage  = "google.protobuf."
+)
+
+type Kind string
+
+const (
+	KindUnary           Kind = "unary"
+	KindServerStreaming Kind = "server streaming"
+	KindClientStreaming Kind = "client streaming"
+	KindBidiStreaming   Kind = "bidi streaming"
+)
+
+type Method struct {
+	Service string
+	Name    string
+	Kind    Kind
+	Input   protoreflect.MessageDescriptor
+	Output  protoreflect.MessageDescriptor
+}
+
+type Registry struct {
+	files   *protoregistry.Files
+	methods []Method
+}
+
+type Result struct {
+	Code      codes.Code
+	Message   string
+	Headers   metadata.MD
+	Trailers  metadata.MD
+	Responses []string
+	TimeTaken int64
+}
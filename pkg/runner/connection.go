@@ -0,0 +1,79 @@
+package runner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+const (
+	connectionVersion   = "version"
+	connectionKeepAlive = "keep-alive"
+	connectionMaxIdle   = "max-idle"
+)
+
+func ParseConnectionRule(c *storage.Connection, line string) error {
+	kind, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return fmt.Errorf("%w: %q needs a value", ErrInvalidConnection, kind)
+	}
+
+	switch strings.ToLower(kind) {
+	case connectionVersion:
+		version := request.ParseHTTPVersion(value)
+		if version == request.HTTPVersionAuto && value != "auto" {
+			return fmt.Errorf("%w: unknown version %q, expected auto, http/1.1, h2 or h2c", ErrInvalidConnection, value)
+		}
+		c.HTTPVersion = string(version)
+	case connectionKeepAlive:
+		switch value {
+		case "on", "true", "yes":
+			c.DisableKeepAlives = false
+		case "off", "false", "no":
+			c.DisableKeepAlives = true
+		default:
+			return fmt.Errorf("%w: keep-alive expects on or off", ErrInvalidConnection)
+		}
+	case connectionMaxIdle:
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("%w: invalid max-idle %q", ErrInvalidConnection, value)
+		}
+		c.MaxIdleConns = n
+	default:
+		return fmt.Errorf("%w: unknown setting %q", ErrInvalidConnection, kind)
+	}
+
+	return nil
+}
+
+func ParseConnection(lines []string) storage.Connection {
+	var c storage.Connection
+	for _, line := range lines {
+		_ = ParseConnectionRule(&c, line)
+	}
+
+	return c
+}
+
+func FormatConnection(c storage.Connection) []string {
+	var lines []string
+
+	if c.HTTPVersion != "" {
+		lines = append(lines, connectionVersion+" "+c.HTTPVersion)
+	}
+
+	if c.DisableKeepAlives {
+		lines = append(lines, connectionKeepAlive+" off")
+	}
+
+	if c.MaxIdleConns > 0 {
+		lines = append(lines, fmt.Sprintf("%s %d", connectionMaxIdle, c.MaxIdleConns))
+	}
+
+	return lines
+}
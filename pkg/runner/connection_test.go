@@ -0,0 +1,31 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConnection(t *testing.T) {
+	t.Run("should parse every setting", func(t *testing.T) {
+		c := ParseConnection([]string{"version HTTP/2", "keep-alive off", "max-idle 8"})
+
+		assert.Equal(t, storage.Connection{HTTPVersion: "h2", DisableKeepAlives: true, MaxIdleConns: 8}, c)
+		assert.Equal(t, []string{"version h2", "keep-alive off", "max-idle 8"}, FormatConnection(c))
+	})
+
+	t.Run("should reset settings to their defaults", func(t *testing.T) {
+		c := ParseConnection([]string{"version h2c", "keep-alive off", "version auto", "keep-alive on"})
+
+		assert.Equal(t, storage.Connection{}, c)
+		assert.Empty(t, FormatConnection(c))
+	})
+
+	t.Run("should reject invalid settings", func(t *testing.T) {
+		for _, line := range []string{"version", "version h3", "keep-alive maybe", "max-idle -1", "proxy on"} {
+			var c storage.Connection
+			assert.ErrorIs(t, ParseConnectionRule(&c, line), ErrInvalidConnection, line)
+		}
+	})
+}
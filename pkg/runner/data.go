@@ -0,0 +1,83 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/jsonpath"
+)
+
+func LoadData(path string) ([]map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ParseCSV(data)
+	case ".json":
+		return ParseJSON(data)
+	}
+
+	return nil, fmt.Errorf("%w: %s is neither .csv nor .json", ErrInvalidData, path)
+}
+
+func ParseCSV(data []byte) ([]map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\uFEFF"))))
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidData, err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: missing header row", ErrInvalidData)
+	}
+
+	header := records[0]
+	for i, name := range header {
+		header[i] = strings.TrimSpace(name)
+		if header[i] == "" {
+			return nil, fmt.Errorf("%w: empty column name in column %d", ErrInvalidData, i+1)
+		}
+	}
+
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, name := range header {
+			row[name] = record[i]
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func ParseJSON(data []byte) ([]map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var objects []map[string]any
+	if err := decoder.Decode(&objects); err != nil {
+		return nil, fmt.Errorf("%w: expected an array of objects: %v", ErrInvalidData, err)
+	}
+
+	rows := make([]map[string]string, 0, len(objects))
+	for _, object := range objects {
+		row := make(map[string]string, len(object))
+		for key, value := range object {
+			row[key] = jsonpath.String(value)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,73 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	t.Run("should map rows by header", func(t *testing.T) {
+		rows, err := ParseCSV([]byte("\uFEFFuser, id\nalice,1\n\"bob, jr\",2\n"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []map[string]string{
+			{"user": "alice", "id": "1"},
+			{"user": "bob, jr", "id": "2"},
+		}, rows)
+	})
+
+	t.Run("should reject malformed files", func(t *testing.T) {
+		_, err := ParseCSV(nil)
+		assert.ErrorIs(t, err, ErrInvalidData)
+
+		_, err = ParseCSV([]byte("a,b\n1\n"))
+		assert.ErrorIs(t, err, ErrInvalidData)
+
+		_, err = ParseCSV([]byte("a,\n1,2\n"))
+		assert.ErrorIs(t, err, ErrInvalidData)
+	})
+}
+
+func TestParseJSON(t *testing.T) {
+	t.Run("should stringify values", func(t *testing.T) {
+		rows, err := ParseJSON([]byte(`[{"user": "alice", "id": 12345678901234567890, "admin": true, "tags": ["a"]}]`))
+		require.NoError(t, err)
+
+		assert.Equal(t, []map[string]string{
+			{"user": "alice", "id": "12345678901234567890", "admin": "true", "tags": `["a"]`},
+		}, rows)
+	})
+
+	t.Run("should require an array of objects", func(t *testing.T) {
+		_, err := ParseJSON([]byte(`{"user": "alice"}`))
+		assert.ErrorIs(t, err, ErrInvalidData)
+	})
+}
+
+func TestLoadData(t *testing.T) {
+	dir := t.TempDir()
+
+	csvPath := filepath.Join(dir, "rows.CSV")
+	require.NoError(t, os.WriteFile(csvPath, []byte("id\n1\n2\n"), 0o644))
+	rows, err := LoadData(csvPath)
+	require.NoError(t, err)
+	assert.Len(t, rows, 2)
+
+	jsonPath := filepath.Join(dir, "rows.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`[{"id": 1}]`), 0o644))
+	rows, err = LoadData(jsonPath)
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{{"id": "1"}}, rows)
+
+	txtPath := filepath.Join(dir, "rows.txt")
+	require.NoError(t, os.WriteFile(txtPath, []byte("id"), 0o644))
+	_, err = LoadData(txtPath)
+	assert.ErrorIs(t, err, ErrInvalidData)
+
+	_, err = LoadData(filepath.Join(dir, "missing.csv"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,129 @@
+package runner
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+const (
+	retryAttempts   = "attempts"
+	retryStatus     = "status"
+	retryError      = "error"
+	retryBackoff    = "backoff"
+	retryMaxBackoff = "max-backoff"
+)
+
+func RetryPolicy(collection *storage.Collection, req *storage.Request) request.RetryPolicy {
+	p := req.Retry
+	if p.Attempts == 0 && collection != nil {
+		p = collection.Retry
+	}
+
+	backoff, _ := time.ParseDuration(p.Backoff)
+	maxBackoff, _ := time.ParseDuration(p.MaxBackoff)
+
+	return request.RetryPolicy{
+		MaxAttempts: p.Attempts,
+		Statuses:    slices.Clone(p.Statuses),
+		Errors:      slices.Clone(p.Errors),
+		Backoff:     backoff,
+		MaxBackoff:  maxBackoff,
+	}
+}
+
+func ParseRetryRule(p *storage.RetryPolicy, line string) error {
+	kind, rest, _ := strings.Cut(strings.TrimSpace(line), " ")
+	values := strings.Fields(strings.ReplaceAll(rest, ",", " "))
+	if len(values) == 0 {
+		return fmt.Errorf("%w: %q needs a value", ErrInvalidRetry, kind)
+	}
+
+	switch strings.ToLower(kind) {
+	case retryAttempts:
+		n, err := strconv.Atoi(values[0])
+		if err != nil || n < 1 || len(values) > 1 {
+			return fmt.Errorf("%w: invalid attempts %q", ErrInvalidRetry, rest)
+		}
+		p.Attempts = n
+	case retryStatus:
+		statuses := make([]int, 0, len(values))
+		for _, v := range values {
+			code, err := strconv.Atoi(v)
+			if err != nil || code < 100 || code > 599 {
+				return fmt.Errorf("%w: invalid status %q", ErrInvalidRetry, v)
+			}
+			statuses = append(statuses, code)
+		}
+		p.Statuses = statuses
+	case retryError:
+		kinds := make([]string, 0, len(values))
+		for _, v := range values {
+			v = strings.ToLower(v)
+			if !slices.Contains(request.RetryErrors, v) {
+				return fmt.Errorf("%w: unknown error %q, expected one of %s",
+					ErrInvalidRetry, v, strings.Join(request.RetryErrors, ", "))
+			}
+			kinds = append(kinds, v)
+		}
+		p.Errors = kinds
+	case retryBackoff, retryMaxBackoff:
+		d, err := time.ParseDuration(values[0])
+		if err != nil || d <= 0 || len(values) > 1 {
+			return fmt.Errorf("%w: invalid duration %q", ErrInvalidRetry, rest)
+		}
+		if strings.ToLower(kind) == retryBackoff {
+			p.Backoff = d.String()
+		} else {
+			p.MaxBackoff = d.String()
+		}
+	default:
+		return fmt.Errorf("%w: unknown setting %q", ErrInvalidRetry, kind)
+	}
+
+	return nil
+}
+
+func ParseRetry(lines []string) storage.RetryPolicy {
+	var p storage.RetryPolicy
+	for _, line := range lines {
+		_ = ParseRetryRule(&p, line)
+	}
+
+	return p
+}
+
+func FormatRetry(p storage.RetryPolicy) []string {
+	var lines []string
+
+	if p.Attempts > 0 {
+		lines = append(lines, fmt.Sprintf("%s %d", retryAttempts, p.Attempts))
+	}
+
+	if len(p.Statuses) > 0 {
+		codes := make([]string, len(p.Statuses))
+		for i, code := range p.Statuses {
+			codes[i] = strconv.Itoa(code)
+		}
+		lines = append(lines, retryStatus+" "+strings.Join(codes, " "))
+	}
+
+	if len(p.Errors) > 0 {
+		lines = append(lines, retryError+" "+strings.Join(p.Errors, " "))
+	}
+
+	if p.Backoff != "" {
+		lines = append(lines, retryBackoff+" "+p.Backoff)
+	}
+
+	if p.MaxBackoff != "" {
+		lines = append(lines, retryMaxBackoff+" "+p.MaxBackoff)
+	}
+
+	return lines
+}
@@ -0,0 +1,100 @@
+package runner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	collection := &storage.Collection{Retry: storage.RetryPolicy{Attempts: 2, Backoff: "1s"}}
+
+	t.Run("should prefer the request policy", func(t *testing.T) {
+		req := &storage.Request{Retry: storage.RetryPolicy{
+			Attempts: 4, Statuses: []int{500}, Errors: []string{"timeout"}, Backoff: "200ms", MaxBackoff: "2s",
+		}}
+
+		assert.Equal(t, request.RetryPolicy{
+			MaxAttempts: 4,
+			Statuses:    []int{500},
+			Errors:      []string{"timeout"},
+			Backoff:     200 * time.Millisecond,
+			MaxBackoff:  2 * time.Second,
+		}, RetryPolicy(collection, req))
+	})
+
+	t.Run("should fall back to the collection policy", func(t *testing.T) {
+		p := RetryPolicy(collection, &storage.Request{})
+
+		assert.Equal(t, 2, p.MaxAttempts)
+		assert.Equal(t, time.Second, p.Backoff)
+		assert.False(t, RetryPolicy(nil, &storage.Request{}).Enabled())
+	})
+}
+
+func TestParseRetry(t *testing.T) {
+	t.Run("should parse every setting", func(t *testing.T) {
+		p := ParseRetry([]string{
+			"attempts 3",
+			"status 502, 503 504",
+			"error reset REFUSED",
+			"backoff 250ms",
+			"max-backoff 1m",
+		})
+
+		assert.Equal(t, storage.RetryPolicy{
+			Attempts:   3,
+			Statuses:   []int{502, 503, 504},
+			Errors:     []string{"reset", "refused"},
+			Backoff:    "250ms",
+			MaxBackoff: "1m0s",
+		}, p)
+		assert.Equal(t, []string{
+			"attempts 3",
+			"status 502 503 504",
+			"error reset refused",
+			"backoff 250ms",
+			"max-backoff 1m0s",
+		}, FormatRetry(p))
+	})
+
+	t.Run("should reject invalid settings", func(t *testing.T) {
+		for _, line := range []string{"attempts", "attempts 0", "status 99", "error bogus", "backoff soon", "jitter 1s"} {
+			var p storage.RetryPolicy
+			assert.ErrorIs(t, ParseRetryRule(&p, line), ErrInvalidRetry, line)
+		}
+	})
+
+	t.Run("should format an empty policy as no lines", func(t *testing.T) {
+		assert.Empty(t, FormatRetry(storage.RetryPolicy{}))
+	})
+}
+
+func TestRunRetry(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	collection := &storage.Collection{Retry: storage.RetryPolicy{Attempts: 3, Backoff: "1ms"}}
+	requests := []*storage.Request{{Name: "Flaky", Method: "GET", URL: server.URL}}
+
+	summary := Run(context.Background(), collection, requests, Options{}, nil)
+
+	require.Len(t, summary.Results, 1)
+	assert.True(t, summary.OK())
+	assert.Len(t, summary.Results[0].Response.Attempts, 2)
+}
@@ -0,0 +1,326 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/Yalaouf/gostman/pkg/assertion"
+	"github.com/Yalaouf/gostman/pkg/extraction"
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/script"
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/Yalaouf/gostman/pkg/variables"
+)
+
+func Build(req *storage.Request, vars map[string]string) *request.Model {
+	return newModel(req).ResolveVariables(vars)
+}
+
+func Resolved(req *storage.Request, model *request.Model) *storage.Request {
+	resolved := req.Copy()
+	resolved.Method = model.MethodString()
+	resolved.URL = model.URL
+	resolved.Body = model.Body
+
+	resolved.Headers = make(storage.Headers, 0, len(model.Headers))
+	for _, h := range model.Headers {
+		resolved.Headers = append(resolved.Headers, storage.Header{Key: h.Key, Value: h.Value})
+	}
+
+	if model.Form != nil {
+		resolved.Form = make([]storage.FormField, 0, len(model.Form))
+		for _, f := range model.Form {
+			field := storage.FormField{Key: f.Key, Value: f.Value, ContentType: f.ContentType, Filename: f.Filename}
+			if f.File {
+				field.Type = storage.FormFieldFile
+			}
+			resolved.Form = append(resolved.Form, field)
+		}
+	}
+
+	return resolved
+}
+
+func Prepare(
+	collection *storage.Collection,
+	req *storage.Request,
+	vars map[string]string,
+) (*request.Model, *script.Context, error) {
+	sc := &script.Context{Request: Build(req, vars), Variables: vars}
+	sc.Request.SetRetry(RetryPolicy(collection, req))
+
+	if req.IsWebSocket() || req.IsGRPC() {
+		return sc.Request.ResolveVariables(vars), sc, fmt.Errorf("%w: %s", ErrUnsupportedProtocol, req.Protocol)
+	}
+
+	for _, s := range scripts(collection, req, script.PhasePreRequest) {
+		if err := script.Run(s.name, s.src, sc); err != nil {
+			return sc.Request.ResolveVariables(sc.Variables), sc, err
+		}
+	}
+
+	return sc.Request.ResolveVariables(sc.Variables), sc, nil
+}
+
+func Finish(
+	collection *storage.Collection,
+	req *storage.Request,
+	sc *script.Context,
+	res *request.Response,
+) error {
+	sc.Response = res
+
+	for _, s := range scripts(collection, req, script.PhasePostResponse) {
+		if err := script.Run(s.name, s.src, sc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type namedScript struct {
+	name string
+	src  string
+}
+
+func scripts(collection *storage.Collection, req *storage.Request, phase string) []namedScript {
+	pick := func(s storage.Scripts) string {
+		if phase == script.PhasePreRequest {
+			return s.PreRequest
+		}
+		return s.PostResponse
+	}
+
+	var result []namedScript
+
+	if collection != nil && pick(collection.Scripts) != "" {
+		result = append(result, namedScript{name: "collection " + phase, src: pick(collection.Scripts)})
+	}
+
+	if pick(req.Scripts) != "" {
+		result = append(result, namedScript{name: phase, src: pick(req.Scripts)})
+	}
+
+	return result
+}
+
+func newModel(req *storage.Request) *request.Model {
+	model := request.NewModel()
+
+	model.SetURL(strings.TrimSpace(req.URL))
+	model.SetMethod(request.HTTPMethod(req.Method))
+	model.SetBody(req.Body)
+	model.SetBodyType(request.ParseBodyType(req.BodyType))
+	model.SetCompression(request.ParseCompression(req.Compression))
+	model.SetDisableDecompression(req.DisableDecompression)
+	model.SetRetry(RetryPolicy(nil, req))
+	model.SetHTTPVersion(request.ParseHTTPVersion(req.Connection.HTTPVersion))
+	model.SetDisableKeepAlives(req.Connection.DisableKeepAlives)
+	model.SetMaxIdleConns(req.Connection.MaxIdleConns)
+	model.SetTimeout(request.DefaultTimeout)
+
+	for _, h := range req.Headers {
+		if h.Disabled {
+			continue
+		}
+		model.AddHeader(strings.TrimSpace(h.Key), strings.TrimSpace(h.Value))
+	}
+
+	if len(req.Form) > 0 {
+		model.SetForm(formFields(req.Form))
+	}
+
+	return model
+}
+
+func formFields(form []storage.FormField) []request.FormField {
+	fields := make([]request.FormField, 0, len(form))
+	for _, field := range form {
+		if field.Disabled {
+			continue
+		}
+
+		fields = append(fields, request.FormField{
+			Key:         field.Key,
+			Value:       field.Value,
+			File:        field.IsFile(),
+			ContentType: field.ContentType,
+			Filename:    field.Filename,
+		})
+	}
+
+	return fields
+}
+
+func Variables(
+	collection *storage.Collection,
+	environment *storage.Environment,
+	overrides map[string]string,
+) map[string]string {
+	var collectionVars, environmentVars map[string]string
+
+	if collection != nil {
+		collectionVars = collection.Variables
+	}
+
+	if environment != nil {
+		environmentVars = environment.Variables
+	}
+
+	return variables.Merge(collectionVars, environmentVars, overrides)
+}
+
+func Run(
+	ctx context.Context,
+	collection *storage.Collection,
+	requests []*storage.Request,
+	opts Options,
+	onResult func(*Result),
+) *Summary {
+	rows := opts.Data
+	if len(rows) == 0 {
+		rows = []map[string]string{nil}
+	}
+
+	summary := &Summary{
+		Collection: collection,
+		Iterations: len(rows),
+		Data:       opts.Data,
+		StartedAt:  time.Now(),
+	}
+
+	sent := 0
+	stopped := false
+
+	for i, row := range rows {
+		vars := Variables(collection, opts.Environment, variables.Merge(row, opts.Variables))
+		summary.Variables = vars
+		summary.IterationVariables = append(summary.IterationVariables, vars)
+
+		for _, req := range requests {
+			if sent > 0 && !wait(ctx, opts.Delay) {
+				stopped = true
+				break
+			}
+
+			if ctx.Err() != nil {
+				stopped = true
+				break
+			}
+
+			result := send(ctx, collection, req, vars, opts)
+			result.Iteration = i + 1
+			summary.Results = append(summary.Results, result)
+			sent++
+
+			if !result.Failed() {
+				result.Extractions = extraction.Extract(req.Extractions, result.Response)
+				maps.Copy(vars, extraction.Variables(result.Extractions, ""))
+			}
+
+			if onResult != nil {
+				onResult(result)
+			}
+
+			if opts.StopOnFailure && result.Failed() {
+				stopped = true
+				break
+			}
+		}
+
+		if stopped {
+			break
+		}
+	}
+
+	summary.Duration = time.Since(summary.StartedAt)
+
+	return summary
+}
+
+func send(
+	ctx context.Context,
+	collection *storage.Collection,
+	req *storage.Request,
+	vars map[string]string,
+	opts Options,
+) *Result {
+	model, sc, err := Prepare(collection, req, vars)
+
+	result := &Result{Request: req, URL: model.URL}
+	defer func() {
+		result.Logs = sc.Logs
+	}()
+
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	model.SetContext(ctx)
+	model.SetTimeout(opts.Timeout)
+	model.SetMaxBodySize(opts.MaxBodySize)
+	model.SetClient(opts.Client)
+
+	result.Response, result.Err = request.SendRequest(model)
+	if result.Err != nil {
+		return result
+	}
+	defer result.Response.Remove()
+
+	result.Err = Finish(collection, req, sc, result.Response)
+	result.Assertions = assertion.Evaluate(req.Assertions, result.Response)
+
+	return result
+}
+
+func wait(ctx context.Context, delay time.Duration) bool {
+	if delay <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func (r *Result) Failed() bool {
+	if r.Err != nil || r.Response == nil {
+		return true
+	}
+
+	if len(r.Request.Assertions) > 0 {
+		return !assertion.Passed(r.Assertions)
+	}
+
+	return r.Response.StatusCode >= 400
+}
+
+func (s *Summary) Passed() int {
+	passed := 0
+	for _, r := range s.Results {
+		if !r.Failed() {
+			passed++
+		}
+	}
+
+	return passed
+}
+
+func (s *Summary) Failed() int {
+	return len(s.Results) - s.Passed()
+}
+
+func (s *Summary) OK() bool {
+	return s.Failed() == 0
+}
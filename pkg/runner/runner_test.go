@@ -0,0 +1,420 @@
+package runner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fail":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/echo":
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("X-Token", r.Header.Get("X-Token"))
+			for _, key := range []string{"X-Signature", "X-Trace"} {
+				if value := r.Header.Get(key); value != "" {
+					w.Header().Set(key, value)
+				}
+			}
+			w.Write(body)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestBuild(t *testing.T) {
+	req := &storage.Request{
+		Method: "POST",
+		URL:    "  {{host}}/users  ",
+		Headers: storage.Headers{
+			{Key: " X-Token ", Value: " {{token}} "},
+			{Key: "X-Debug", Value: "1", Disabled: true},
+			{Key: "Accept", Value: "text/html"},
+			{Key: "Accept", Value: "application/json"},
+		},
+		Body:     `{"name":"{{name}}"}`,
+		BodyType: "json",
+	}
+
+	model := Build(req, map[string]string{"host": "http://localhost", "token": "abc", "name": "bob"})
+
+	assert.Equal(t, request.POST, model.Method)
+	assert.Equal(t, "http://localhost/users", model.URL)
+	assert.Equal(t, []request.Header{
+		{Key: "X-Token", Value: "abc"},
+		{Key: "Accept", Value: "text/html"},
+		{Key: "Accept", Value: "application/json"},
+	}, model.Headers)
+	assert.Equal(t, `{"name":"bob"}`, model.Body)
+	assert.Equal(t, request.BodyTypeJSON, model.BodyType)
+	assert.Equal(t, request.DefaultTimeout, model.Timeout)
+}
+
+func TestBuildForm(t *testing.T) {
+	req := &storage.Request{
+		Method:   "POST",
+		URL:      "http://localhost/upload",
+		BodyType: "form-data",
+		Form: []storage.FormField{
+			{Key: "name", Value: "{{name}}"},
+			{Key: "avatar", Value: "{{dir}}/avatar.png", Type: storage.FormFieldFile, ContentType: "image/png"},
+			{Key: "draft", Value: "yes", Disabled: true},
+		},
+	}
+
+	model := Build(req, map[string]string{"name": "bob", "dir": "/tmp"})
+
+	assert.Equal(t, []request.FormField{
+		{Key: "name", Value: "bob"},
+		{Key: "avatar", Value: "/tmp/avatar.png", File: true, ContentType: "image/png"},
+	}, model.Form)
+
+	req.Form = []storage.FormField{{Key: "draft", Value: "yes", Disabled: true}}
+	req.Body = `{"legacy":"body"}`
+
+	assert.Equal(t, []request.FormField{}, Build(req, nil).Form)
+}
+
+func TestResolved(t *testing.T) {
+	req := &storage.Request{
+		ID: "r1", Name: "Upload", Method: "POST", URL: "{{host}}/upload", Body: "{{note}}",
+		Headers: storage.Headers{{Key: "X-Token", Value: "{{token}}"}, {Key: "X-Debug", Value: "1", Disabled: true}},
+		Form: []storage.FormField{
+			{Key: "avatar", Value: "{{file}}", Type: storage.FormFieldFile},
+			{Key: "draft", Value: "yes", Disabled: true},
+		},
+	}
+	vars := map[string]string{"host": "http://localhost", "token": "abc", "note": "hi", "file": "./me.png"}
+
+	resolved := Resolved(req, Build(req, vars))
+
+	assert.Equal(t, "r1", resolved.ID)
+	assert.Equal(t, "http://localhost/upload", resolved.URL)
+	assert.Equal(t, "hi", resolved.Body)
+	assert.Equal(t, storage.Headers{{Key: "X-Token", Value: "abc"}}, resolved.Headers)
+	assert.Equal(t, []storage.FormField{{Key: "avatar", Value: "./me.png", Type: storage.FormFieldFile}}, resolved.Form)
+	assert.Equal(t, "{{host}}/upload", req.URL)
+}
+
+func TestBuildConnection(t *testing.T) {
+	req := &storage.Request{URL: "http://localhost", Connection: storage.Connection{
+		HTTPVersion: "h2c", DisableKeepAlives: true, MaxIdleConns: 4,
+	}}
+
+	model := Build(req, nil)
+
+	assert.Equal(t, request.HTTPVersionH2C, model.HTTPVersion)
+	assert.True(t, model.DisableKeepAlives)
+	assert.Equal(t, 4, model.MaxIdleConns)
+}
+
+func TestBuildCompression(t *testing.T) {
+	req := &storage.Request{Method: "POST", URL: "http://localhost", Compression: "br", DisableDecompression: true}
+
+	model := Build(req, nil)
+
+	assert.Equal(t, request.CompressionBrotli, model.Compression)
+	assert.True(t, model.DisableDecompression)
+	assert.Equal(t, request.CompressionNone, Build(&storage.Request{URL: "http://localhost"}, nil).Compression)
+}
+
+func TestVariables(t *testing.T) {
+	collection := &storage.Collection{Variables: map[string]string{"host": "collection", "a": "1"}}
+	environment := &storage.Environment{Variables: map[string]string{"host": "environment", "b": "2"}}
+
+	t.Run("should layer collection, environment and overrides", func(t *testing.T) {
+		vars := Variables(collection, environment, map[string]string{"b": "override"})
+
+		assert.Equal(t, map[string]string{"host": "environment", "a": "1", "b": "override"}, vars)
+	})
+
+	t.Run("should accept nil layers", func(t *testing.T) {
+		vars := Variables(nil, nil, nil)
+
+		assert.Empty(t, vars)
+	})
+}
+
+func TestRun(t *testing.T) {
+	server := newTestServer(t)
+
+	collection := &storage.Collection{Name: "Smoke", Variables: map[string]string{"host": server.URL}}
+
+	t.Run("should run every request in order", func(t *testing.T) {
+		requests := []*storage.Request{
+			{Name: "Ping", Method: "GET", URL: "{{host}}/ping"},
+			{Name: "Echo", Method: "POST", URL: "{{host}}/echo", Headers: storage.Headers{{Key: "X-Token", Value: "{{token}}"}}},
+		}
+
+		var seen []string
+		summary := Run(context.Background(), collection, requests, Options{
+			Environment: &storage.Environment{Variables: map[string]string{"token": "secret"}},
+		}, func(r *Result) {
+			seen = append(seen, r.Request.Name)
+		})
+
+		require.Len(t, summary.Results, 2)
+		assert.Equal(t, []string{"Ping", "Echo"}, seen)
+		assert.True(t, summary.OK())
+		assert.Equal(t, 2, summary.Passed())
+		assert.Equal(t, server.URL+"/ping", summary.Results[0].URL)
+		assert.Equal(t, "secret", summary.Results[1].Response.Headers["X-Token"][0])
+	})
+
+	t.Run("should report failed statuses and errors", func(t *testing.T) {
+		requests := []*storage.Request{
+			{Name: "Fail", Method: "GET", URL: "{{host}}/fail"},
+			{Name: "Broken", Method: "GET", URL: "http://127.0.0.1:0"},
+			{Name: "Ping", Method: "GET", URL: "{{host}}/ping"},
+		}
+
+		summary := Run(context.Background(), collection, requests, Options{}, nil)
+
+		require.Len(t, summary.Results, 3)
+		assert.True(t, summary.Results[0].Failed())
+		assert.Error(t, summary.Results[1].Err)
+		assert.False(t, summary.Results[2].Failed())
+		assert.Equal(t, 2, summary.Failed())
+		assert.False(t, summary.OK())
+	})
+
+	t.Run("should fail websocket and grpc requests instead of sending them", func(t *testing.T) {
+		requests := []*storage.Request{
+			{Name: "Feed", Protocol: storage.ProtocolWebSocket, Method: "GET", URL: "ws://{{host}}/feed"},
+			{Name: "Greet", Protocol: storage.ProtocolGRPC, URL: "localhost:50051", RPC: "greeter.v1.Greeter/SayHello"},
+		}
+
+		summary := Run(context.Background(), collection, requests, Options{}, nil)
+
+		require.Len(t, summary.Results, 2)
+		assert.ErrorIs(t, summary.Results[0].Err, ErrUnsupportedProtocol)
+		assert.ErrorIs(t, summary.Results[1].Err, ErrUnsupportedProtocol)
+	})
+
+	t.Run("should use assertions to decide failures", func(t *testing.T) {
+		requests := []*storage.Request{
+			{
+				Name: "Expected failure", Method: "GET", URL: "{{host}}/fail",
+				Assertions: []storage.Assertion{{Type: "status", Operator: "equals", Value: "500"}},
+			},
+			{
+				Name: "Wrong body", Method: "GET", URL: "{{host}}/ping",
+				Assertions: []storage.Assertion{
+					{Type: "status", Operator: "equals", Value: "200"},
+					{Type: "json", Target: "$.id", Operator: "exists"},
+				},
+			},
+		}
+
+		summary := Run(context.Background(), collection, requests, Options{}, nil)
+
+		require.Len(t, summary.Results, 2)
+		assert.False(t, summary.Results[0].Failed())
+		assert.True(t, summary.Results[1].Failed())
+		require.Len(t, summary.Results[1].Assertions, 2)
+		assert.True(t, summary.Results[1].Assertions[0].Passed)
+		assert.False(t, summary.Results[1].Assertions[1].Passed)
+	})
+
+	t.Run("should chain extracted variables into later requests", func(t *testing.T) {
+		requests := []*storage.Request{
+			{
+				Name: "Login", Method: "POST", URL: "{{host}}/echo", Body: `{"token": "abc"}`, BodyType: "json",
+				Extractions: []storage.Extraction{{Source: "json", Expression: "$.token", Variable: "token"}},
+			},
+			{Name: "Me", Method: "GET", URL: "{{host}}/echo", Headers: storage.Headers{{Key: "X-Token", Value: "{{token}}"}}},
+		}
+
+		summary := Run(context.Background(), collection, requests, Options{}, nil)
+
+		require.Len(t, summary.Results, 2)
+		require.Len(t, summary.Results[0].Extractions, 1)
+		assert.Equal(t, "abc", summary.Results[0].Extractions[0].Value)
+		assert.Equal(t, "abc", summary.Results[1].Response.Headers["X-Token"][0])
+		assert.Equal(t, "abc", summary.Variables["token"])
+	})
+
+	t.Run("should run once per data row with row variables over the environment", func(t *testing.T) {
+		requests := []*storage.Request{
+			{Name: "Echo", Method: "GET", URL: "{{host}}/echo", Headers: storage.Headers{{Key: "X-Token", Value: "{{token}}"}}},
+		}
+
+		summary := Run(context.Background(), collection, requests, Options{
+			Environment: &storage.Environment{Variables: map[string]string{"token": "env"}},
+			Data:        []map[string]string{{"token": "row-1"}, {}, {"token": "row-3"}},
+		}, nil)
+
+		require.Len(t, summary.Results, 3)
+		assert.Equal(t, 3, summary.Iterations)
+
+		for i, expected := range []string{"row-1", "env", "row-3"} {
+			assert.Equal(t, i+1, summary.Results[i].Iteration)
+			assert.Equal(t, expected, summary.Results[i].Response.Headers["X-Token"][0])
+		}
+	})
+
+	t.Run("should keep the variables of every iteration", func(t *testing.T) {
+		requests := []*storage.Request{
+			{
+				Name: "Login", Method: "POST", URL: "{{host}}/echo", Body: `{"token": "{{seed}}"}`, BodyType: "json",
+				Extractions: []storage.Extraction{{Source: "json", Expression: "$.token", Variable: "sessionToken"}},
+			},
+		}
+
+		summary := Run(context.Background(), collection, requests, Options{
+			Data: []map[string]string{{"seed": "row-1-token"}, {"seed": ""}},
+		}, nil)
+
+		require.Len(t, summary.IterationVariables, 2)
+		assert.Equal(t, "row-1-token", summary.IterationVariables[0]["sessionToken"])
+		assert.Empty(t, summary.IterationVariables[1]["sessionToken"])
+		assert.Equal(t, summary.IterationVariables[1], summary.Variables)
+	})
+
+	t.Run("should run collection and request scripts around each request", func(t *testing.T) {
+		scripted := &storage.Collection{
+			Name:      "Scripted",
+			Variables: collection.Variables,
+			Scripts: storage.Scripts{
+				PreRequest:   `vars.set("token", "collection")`,
+				PostResponse: `log("collection saw", response.status)`,
+			},
+		}
+
+		requests := []*storage.Request{
+			{
+				Name: "Login", Method: "GET", URL: "{{host}}/echo", Headers: storage.Headers{{Key: "X-Token", Value: "{{token}}"}},
+				Scripts: storage.Scripts{
+					PreRequest:   `vars.set("token", vars.get("token") + "-request")`,
+					PostResponse: `vars.set("seen", response.headers["X-Token"])`,
+				},
+			},
+			{Name: "Me", Method: "GET", URL: "{{host}}/echo", Headers: storage.Headers{{Key: "X-Token", Value: "{{seen}}"}}},
+		}
+
+		summary := Run(context.Background(), scripted, requests, Options{}, nil)
+
+		require.Len(t, summary.Results, 2)
+		require.NoError(t, summary.Results[0].Err)
+		assert.Equal(t, "collection-request", summary.Results[0].Response.Headers["X-Token"][0])
+		assert.Equal(t, []string{"[collection post-response] collection saw 200"}, summary.Results[0].Logs)
+		assert.Equal(t, "collection-request", summary.Results[1].Response.Headers["X-Token"][0])
+	})
+
+	t.Run("should run pre-request scripts on the resolved request", func(t *testing.T) {
+		requests := []*storage.Request{
+			{
+				Name: "Signed", Method: "POST", URL: "{{host}}/echo", Body: `{"id":"{{id}}"}`, BodyType: "json",
+				Headers: storage.Headers{{Key: "X-Trace", Value: "{{trace}}"}},
+				Scripts: storage.Scripts{PreRequest: `
+request.set_header("X-Signature", crypto.sha256(request.body))
+vars.set("trace", "t-" + request.url[-4:])
+`},
+			},
+		}
+
+		summary := Run(context.Background(), collection, requests, Options{Variables: map[string]string{"id": "42"}}, nil)
+
+		require.Len(t, summary.Results, 1)
+		require.NoError(t, summary.Results[0].Err)
+
+		res := summary.Results[0].Response
+		sum := sha256.Sum256([]byte(res.Body))
+		assert.JSONEq(t, `{"id":"42"}`, res.Body)
+		assert.Equal(t, hex.EncodeToString(sum[:]), res.Headers["X-Signature"][0])
+		assert.Equal(t, "t-echo", res.Headers["X-Trace"][0])
+	})
+
+	t.Run("should fail requests whose scripts fail", func(t *testing.T) {
+		requests := []*storage.Request{
+			{Name: "Pre", Method: "GET", URL: "{{host}}/ping", Scripts: storage.Scripts{PreRequest: `fail("no nonce")`}},
+			{Name: "Post", Method: "GET", URL: "{{host}}/ping", Scripts: storage.Scripts{PostResponse: `fail("bad body")`}},
+		}
+
+		summary := Run(context.Background(), collection, requests, Options{}, nil)
+
+		require.Len(t, summary.Results, 2)
+		assert.ErrorContains(t, summary.Results[0].Err, "no nonce")
+		assert.Nil(t, summary.Results[0].Response)
+		assert.ErrorContains(t, summary.Results[1].Err, "bad body")
+		assert.NotNil(t, summary.Results[1].Response)
+		assert.Equal(t, 2, summary.Failed())
+	})
+
+	t.Run("should stop on the first failure", func(t *testing.T) {
+		requests := []*storage.Request{
+			{Name: "Fail", Method: "GET", URL: "{{host}}/fail"},
+			{Name: "Ping", Method: "GET", URL: "{{host}}/ping"},
+		}
+
+		summary := Run(context.Background(), collection, requests, Options{StopOnFailure: true}, nil)
+
+		assert.Len(t, summary.Results, 1)
+	})
+
+	t.Run("should wait between requests", func(t *testing.T) {
+		requests := []*storage.Request{
+			{Name: "A", Method: "GET", URL: "{{host}}/a"},
+			{Name: "B", Method: "GET", URL: "{{host}}/b"},
+		}
+
+		summary := Run(context.Background(), collection, requests, Options{Delay: 50 * time.Millisecond}, nil)
+
+		assert.Len(t, summary.Results, 2)
+		assert.GreaterOrEqual(t, summary.Duration, 50*time.Millisecond)
+	})
+
+	t.Run("should stop when the context is cancelled", func(t *testing.T) {
+		requests := []*storage.Request{
+			{Name: "A", Method: "GET", URL: "{{host}}/a"},
+			{Name: "B", Method: "GET", URL: "{{host}}/b"},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		summary := Run(ctx, collection, requests, Options{Delay: time.Second}, func(*Result) {
+			cancel()
+		})
+
+		assert.Len(t, summary.Results, 1)
+	})
+	t.Run("should apply connection settings to an injected client", func(t *testing.T) {
+		h2c := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(r.Proto))
+		}))
+		h2c.Config.Protocols = new(http.Protocols)
+		h2c.Config.Protocols.SetHTTP1(true)
+		h2c.Config.Protocols.SetUnencryptedHTTP2(true)
+		h2c.Start()
+		defer h2c.Close()
+
+		requests := []*storage.Request{
+			{Name: "Forced", Method: "GET", URL: h2c.URL, Connection: storage.Connection{HTTPVersion: "h2c"}},
+			{Name: "Default", Method: "GET", URL: h2c.URL},
+		}
+
+		summary := Run(context.Background(), collection, requests, Options{Client: h2c.Client()}, nil)
+
+		require.Len(t, summary.Results, 2)
+		require.NoError(t, summary.Results[0].Err)
+		assert.Equal(t, "HTTP/2.0", summary.Results[0].Response.Proto)
+		require.NoError(t, summary.Results[1].Err)
+		assert.Equal(t, "HTTP/1.1", summary.Results[1].Response.Proto)
+	})
+}
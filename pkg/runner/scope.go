@@ -0,0 +1,40 @@
+package runner
+
+import (
+	"github.com/Yalaouf/gostman/pkg/script"
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+var Scopes = []string{script.ScopeEnvironment, script.ScopeCollection}
+
+func ScriptVariables(updates []script.Variable, scope string) map[string]string {
+	vars := map[string]string{}
+	for _, update := range updates {
+		if update.Scope == scope {
+			vars[update.Name] = update.Value
+		}
+	}
+
+	return vars
+}
+
+func SaveVariables(
+	s *storage.Storage,
+	collection *storage.Collection,
+	environment *storage.Environment,
+	scope string,
+	vars map[string]string,
+) (bool, error) {
+	if len(vars) == 0 {
+		return true, nil
+	}
+
+	switch {
+	case environment != nil && (scope != script.ScopeCollection || collection == nil):
+		return true, s.SetEnvironmentVariables(environment.ID, vars)
+	case collection != nil:
+		return true, s.SetCollectionVariables(collection.ID, vars)
+	}
+
+	return false, nil
+}
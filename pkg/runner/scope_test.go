@@ -0,0 +1,69 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/script"
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptVariables(t *testing.T) {
+	updates := []script.Variable{
+		{Scope: script.ScopeEnvironment, Name: "token", Value: "a"},
+		{Scope: script.ScopeCollection, Name: "orderId", Value: "7"},
+		{Scope: script.ScopeEnvironment, Name: "token", Value: "b"},
+	}
+
+	assert.Equal(t, map[string]string{"token": "b"}, ScriptVariables(updates, script.ScopeEnvironment))
+	assert.Equal(t, map[string]string{"orderId": "7"}, ScriptVariables(updates, script.ScopeCollection))
+}
+
+func TestSaveVariables(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	s, err := storage.New()
+	require.NoError(t, err)
+
+	collection, err := s.CreateCollection("API")
+	require.NoError(t, err)
+	environment, err := s.SaveEnvironment("dev", nil)
+	require.NoError(t, err)
+
+	t.Run("should save each scope where it belongs", func(t *testing.T) {
+		saved, err := SaveVariables(s, collection, environment, script.ScopeEnvironment, map[string]string{"token": "a"})
+		require.NoError(t, err)
+		assert.True(t, saved)
+
+		saved, err = SaveVariables(s, collection, environment, script.ScopeCollection, map[string]string{"orderId": "7"})
+		require.NoError(t, err)
+		assert.True(t, saved)
+
+		env, _ := s.GetEnvironment(environment.ID)
+		c, _ := s.GetCollection(collection.ID)
+		assert.Equal(t, map[string]string{"token": "a"}, env.Variables)
+		assert.Equal(t, map[string]string{"orderId": "7"}, c.Variables)
+	})
+
+	t.Run("should fall back to whichever target exists", func(t *testing.T) {
+		saved, err := SaveVariables(s, nil, environment, script.ScopeCollection, map[string]string{"fallback": "env"})
+		require.NoError(t, err)
+		assert.True(t, saved)
+
+		saved, err = SaveVariables(s, collection, nil, script.ScopeEnvironment, map[string]string{"fallback": "collection"})
+		require.NoError(t, err)
+		assert.True(t, saved)
+
+		env, _ := s.GetEnvironment(environment.ID)
+		c, _ := s.GetCollection(collection.ID)
+		assert.Equal(t, "env", env.Variables["fallback"])
+		assert.Equal(t, "collection", c.Variables["fallback"])
+	})
+
+	t.Run("should report when there is nowhere to save", func(t *testing.T) {
+		saved, err := SaveVariables(s, nil, nil, script.ScopeEnvironment, map[string]string{"token": "a"})
+		require.NoError(t, err)
+		assert.False(t, saved)
+	})
+}
@@ -0,0 +1,53 @@
+package runner
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Yalaouf/gostman/pkg/assertion"
+	"github.com/Yalaouf/gostman/pkg/extraction"
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/storage"
+)
+
+var (
+	ErrInvalidData         = errors.New("invalid data file")
+	ErrUnsupportedProtocol = errors.New("unsupported protocol")
+	ErrInvalidRetry        = errors.New("invalid retry setting")
+	ErrInvalidConnection   = errors.New("invalid connection setting")
+)
+
+type Options struct {
+	Environment   *storage.Environment
+	Variables     map[string]string
+	Data          []map[string]string
+	StopOnFailure bool
+	Delay         time.Duration
+	Timeout       int64
+	MaxBodySize   int64
+	Client        *http.Client
+}
+
+type Result struct {
+	Iteration   int
+	Request     *storage.Request
+	URL         string
+	Response    *request.Response
+	Assertions  []assertion.Result
+	Extractions []extraction.Result
+	Logs        []string
+	Err         error
+}
+
+type Summary struct {
+	Collection *storage.Collection
+	Results    []*Result
+	Iterations int
+	Data       []map[string]string
+	Variables  map[string]string
+	StartedAt  time.Time
+	Duration   time.Duration
+
+	IterationVariables []map[string]string
+}
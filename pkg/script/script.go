@@ -0,0 +1,101 @@
+package script
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.starlark.net/lib/json"
+	starlarktime "go.starlark.net/lib/time"
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+var fileOptions = &syntax.FileOptions{
+	Set:             true,
+	While:           true,
+	TopLevelControl: true,
+	GlobalReassign:  true,
+}
+
+func Run(name, src string, ctx *Context) error {
+	if strings.TrimSpace(src) == "" {
+		return nil
+	}
+
+	if ctx.Variables == nil {
+		ctx.Variables = map[string]string{}
+	}
+
+	thread := &starlark.Thread{
+		Name: name,
+		Print: func(_ *starlark.Thread, msg string) {
+			ctx.log(name, msg)
+		},
+	}
+	thread.SetMaxExecutionSteps(maxSteps)
+
+	timer := time.AfterFunc(timeout, func() {
+		thread.Cancel(fmt.Sprintf("timed out after %s", timeout))
+	})
+	defer timer.Stop()
+
+	var response starlark.Value = starlark.None
+	if ctx.Response != nil {
+		response = newResponse(ctx.Response)
+	}
+
+	predeclared := starlark.StringDict{
+		"request":  &requestValue{model: ctx.Request},
+		"response": response,
+		"vars":     newVars(ctx),
+		"log":      starlark.NewBuiltin("log", logBuiltin(ctx, name)),
+		"json":     json.Module,
+		"time":     starlarktime.Module,
+		"crypto":   cryptoModule,
+		"base64":   base64Module,
+		"uuid":     starlark.NewBuiltin("uuid", uuidBuiltin),
+	}
+
+	if _, err := starlark.ExecFileOptions(fileOptions, thread, name, src, predeclared); err != nil {
+		if evalErr, ok := err.(*starlark.EvalError); ok {
+			return fmt.Errorf("%w: %s", ErrScript, evalErr.Backtrace())
+		}
+		return fmt.Errorf("%w: %v", ErrScript, err)
+	}
+
+	return nil
+}
+
+func (c *Context) log(name, msg string) {
+	c.Logs = append(c.Logs, fmt.Sprintf("[%s] %s", name, msg))
+}
+
+func (c *Context) set(scope, name, value string) {
+	c.Variables[name] = value
+	c.Updates = append(c.Updates, Variable{Scope: scope, Name: name, Value: value})
+}
+
+func logBuiltin(ctx *Context, name string) func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+	return func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if len(kwargs) > 0 {
+			return nil, fmt.Errorf("%s: unexpected keyword arguments", b.Name())
+		}
+
+		parts := make([]string, len(args))
+		for i, arg := range args {
+			parts[i] = toString(arg)
+		}
+
+		ctx.log(name, strings.Join(parts, " "))
+		return starlark.None, nil
+	}
+}
+
+func toString(v starlark.Value) string {
+	if s, ok := starlark.AsString(v); ok {
+		return s
+	}
+
+	return v.String()
+}
@@ -0,0 +1,139 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newContext() *Context {
+	model := request.NewModel().
+		SetMethod(request.GET).
+		SetURL("http://localhost/orders?id={{orderId}}").
+		SetBody(`{"total": 10}`).
+		AddHeader("Accept", "application/json")
+
+	return &Context{Request: model, Variables: map[string]string{"secret": "k3y"}}
+}
+
+func TestRun(t *testing.T) {
+	t.Run("should ignore empty scripts", func(t *testing.T) {
+		ctx := newContext()
+		require.NoError(t, Run(PhasePreRequest, "  \n", ctx))
+		assert.Empty(t, ctx.Logs)
+	})
+
+	t.Run("should modify the pending request", func(t *testing.T) {
+		ctx := newContext()
+
+		err := Run(PhasePreRequest, `
+request.method = "post"
+request.url = request.url.replace("orders", "invoices")
+request.body = json.encode({"total": json.decode(request.body)["total"] * 2})
+request.set_header("accept", "text/plain")
+request.set_header("X-Signature", crypto.hmac_sha256(vars.get("secret"), request.body))
+request.remove_header("Missing")
+request.add_header("Cookie", "a=1")
+request.add_header("Cookie", "b=2")
+`, ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, request.POST, ctx.Request.Method)
+		assert.Equal(t, "http://localhost/invoices?id={{orderId}}", ctx.Request.URL)
+		assert.Equal(t, `{"total":20}`, ctx.Request.Body)
+		assert.Equal(t, []request.Header{
+			{Key: "Accept", Value: "text/plain"},
+			{Key: "X-Signature", Value: "d3ada22744ef0f7253f6f8118125615e03e32c2588924cffc1c35ce64bf4f66a"},
+			{Key: "Cookie", Value: "a=1"},
+			{Key: "Cookie", Value: "b=2"},
+		}, ctx.Request.Headers)
+	})
+
+	t.Run("should read the response and set variables", func(t *testing.T) {
+		ctx := newContext()
+		ctx.Response = &request.Response{
+			StatusCode: 201,
+			Headers:    map[string][]string{"x-request-id": {"req-1"}},
+			Body:       `{"id": 42, "items": ["a", "b"]}`,
+			TimeTaken:  12,
+		}
+
+		err := Run(PhasePostResponse, `
+data = response.json()
+if response.status == 201:
+    vars.set("orderId", data["id"])
+    vars.set("requestId", response.headers["X-Request-Id"], scope = "collection")
+log("items:", len(data["items"]), "in", response.time_ms, "ms")
+print("done")
+`, ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, "42", ctx.Variables["orderId"])
+		assert.Equal(t, []Variable{
+			{Scope: ScopeEnvironment, Name: "orderId", Value: "42"},
+			{Scope: ScopeCollection, Name: "requestId", Value: "req-1"},
+		}, ctx.Updates)
+		assert.Equal(t, []string{"[post-response] items: 2 in 12 ms", "[post-response] done"}, ctx.Logs)
+	})
+
+	t.Run("should expose helpers", func(t *testing.T) {
+		ctx := newContext()
+
+		err := Run(PhasePreRequest, `
+vars.set("b64", base64.encode("hi"))
+vars.set("plain", base64.decode(base64.encode("hi")))
+vars.set("sha", crypto.sha256("hi"))
+vars.set("nonce", crypto.random_hex(8))
+vars.set("id", uuid())
+vars.set("ts", time.now().unix)
+vars.set("fallback", vars.get("missing", "default"))
+vars.set("response", str(response))
+`, ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, "aGk=", ctx.Variables["b64"])
+		assert.Equal(t, "hi", ctx.Variables["plain"])
+		assert.Equal(t, "8f434346648f6b96df89dda901c5176b10a6d83961dd3c1ac88b59b2dc327aa4", ctx.Variables["sha"])
+		assert.Len(t, ctx.Variables["nonce"], 16)
+		assert.Len(t, ctx.Variables["id"], 36)
+		assert.NotEmpty(t, ctx.Variables["ts"])
+		assert.Equal(t, "default", ctx.Variables["fallback"])
+		assert.Equal(t, "None", ctx.Variables["response"])
+	})
+
+	t.Run("should report errors with a backtrace", func(t *testing.T) {
+		ctx := newContext()
+
+		err := Run(PhasePreRequest, `fail("bad signature")`, ctx)
+		assert.ErrorIs(t, err, ErrScript)
+		assert.ErrorContains(t, err, "bad signature")
+
+		err = Run(PhasePreRequest, `request.timeout = 5`, ctx)
+		assert.ErrorIs(t, err, ErrScript)
+
+		err = Run(PhasePreRequest, `vars.set("a", "b", scope = "global")`, ctx)
+		assert.ErrorContains(t, err, `unknown scope "global"`)
+
+		err = Run(PhasePreRequest, `if`, ctx)
+		assert.ErrorIs(t, err, ErrScript)
+	})
+
+	t.Run("should stop runaway scripts", func(t *testing.T) {
+		ctx := newContext()
+
+		err := Run(PhasePreRequest, `
+while True:
+    pass
+`, ctx)
+		assert.ErrorIs(t, err, ErrScript)
+	})
+
+	t.Run("should not expose the filesystem or network", func(t *testing.T) {
+		ctx := newContext()
+
+		err := Run(PhasePreRequest, `load("os", "open")`, ctx)
+		assert.ErrorIs(t, err, ErrScript)
+	})
+}
@@ -0,0 +1,39 @@
+package script
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+)
+
+var ErrScript = errors.New("script failed")
+
+const (
+	PhasePreRequest   = "pre-request"
+	PhasePostResponse = "post-response"
+)
+
+const (
+	ScopeEnvironment = "environment"
+	ScopeCollection  = "collection"
+)
+
+const (
+	maxSteps = 10_000_000
+	timeout  = 5 * time.Second
+)
+
+type Variable struct {
+	Scope string
+	Name  string
+	Value string
+}
+
+type Context struct {
+	Request   *request.Model
+	Response  *request.Response
+	Variables map[string]string
+	Updates   []Variable
+	Logs      []string
+}
@@ -0,0 +1,317 @@
+package script
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/google/uuid"
+	"go.starlark.net/lib/json"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+type requestValue struct {
+	model *request.Model
+}
+
+var requestAttrs = []string{
+	"add_header",
+	"body",
+	"header",
+	"headers",
+	"method",
+	"remove_header",
+	"set_header",
+	"url",
+}
+
+func (r *requestValue) String() string {
+	return fmt.Sprintf("<request %s %s>", r.model.Method, r.model.URL)
+}
+
+func (r *requestValue) Type() string {
+	return "request"
+}
+
+func (r *requestValue) Freeze() {}
+
+func (r *requestValue) Truth() starlark.Bool {
+	return starlark.True
+}
+
+func (r *requestValue) Hash() (uint32, error) {
+	return 0, fmt.Errorf("unhashable type: request")
+}
+
+func (r *requestValue) AttrNames() []string {
+	return requestAttrs
+}
+
+func (r *requestValue) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "method":
+		return starlark.String(r.model.Method), nil
+	case "url":
+		return starlark.String(r.model.URL), nil
+	case "body":
+		return starlark.String(r.model.Body), nil
+	case "headers":
+		headers := starlark.NewDict(len(r.model.Headers))
+		for _, h := range r.model.Headers {
+			if _, found, _ := headers.Get(starlark.String(h.Key)); !found {
+				headers.SetKey(starlark.String(h.Key), starlark.String(h.Value))
+			}
+		}
+		return headers, nil
+	case "header":
+		return starlark.NewBuiltin("header", r.header), nil
+	case "add_header":
+		return starlark.NewBuiltin("add_header", r.addHeader), nil
+	case "set_header":
+		return starlark.NewBuiltin("set_header", r.setHeader), nil
+	case "remove_header":
+		return starlark.NewBuiltin("remove_header", r.removeHeader), nil
+	}
+
+	return nil, nil
+}
+
+func (r *requestValue) SetField(name string, val starlark.Value) error {
+	s, ok := starlark.AsString(val)
+	if !ok {
+		return fmt.Errorf("request.%s must be a string, got %s", name, val.Type())
+	}
+
+	switch name {
+	case "method":
+		r.model.SetMethod(request.HTTPMethod(strings.ToUpper(s)))
+	case "url":
+		r.model.SetURL(s)
+	case "body":
+		r.model.SetBody(s)
+	default:
+		return starlark.NoSuchAttrError(fmt.Sprintf("request has no settable field .%s", name))
+	}
+
+	return nil
+}
+
+func (r *requestValue) headerKey(name string) string {
+	for _, h := range r.model.Headers {
+		if strings.EqualFold(h.Key, name) {
+			return h.Key
+		}
+	}
+
+	return name
+}
+
+func (r *requestValue) header(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var name string
+	var def starlark.Value = starlark.None
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &name, "default?", &def); err != nil {
+		return nil, err
+	}
+
+	if value, ok := r.model.HeaderValue(name); ok {
+		return starlark.String(value), nil
+	}
+
+	return def, nil
+}
+
+func (r *requestValue) setHeader(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var name string
+	var value starlark.Value
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &name, "value", &value); err != nil {
+		return nil, err
+	}
+
+	r.model.SetHeader(r.headerKey(name), toString(value))
+	return starlark.None, nil
+}
+
+func (r *requestValue) addHeader(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var name string
+	var value starlark.Value
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &name, "value", &value); err != nil {
+		return nil, err
+	}
+
+	r.model.AddHeader(name, toString(value))
+	return starlark.None, nil
+}
+
+func (r *requestValue) removeHeader(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var name string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &name); err != nil {
+		return nil, err
+	}
+
+	r.model.RemoveHeader(name)
+	return starlark.None, nil
+}
+
+func newResponse(res *request.Response) starlark.Value {
+	headers := starlark.NewDict(len(res.Headers))
+	for key, values := range res.Headers {
+		if len(values) > 0 {
+			headers.SetKey(starlark.String(http.CanonicalHeaderKey(key)), starlark.String(values[0]))
+		}
+	}
+
+	decode := json.Module.Members["decode"]
+
+	return starlarkstruct.FromStringDict(starlark.String("response"), starlark.StringDict{
+		"status":  starlark.MakeInt(res.StatusCode),
+		"headers": headers,
+		"body":    starlark.String(res.Body),
+		"time_ms": starlark.MakeInt64(res.TimeTaken),
+		"json": starlark.NewBuiltin("json", func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+				return nil, err
+			}
+			return starlark.Call(thread, decode, starlark.Tuple{starlark.String(res.Body)}, nil)
+		}),
+	})
+}
+
+func newVars(ctx *Context) starlark.Value {
+	get := func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var name string
+		var def starlark.Value = starlark.None
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &name, "default?", &def); err != nil {
+			return nil, err
+		}
+
+		if value, ok := ctx.Variables[name]; ok {
+			return starlark.String(value), nil
+		}
+		return def, nil
+	}
+
+	set := func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var name string
+		var value starlark.Value
+		scope := ScopeEnvironment
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &name, "value", &value, "scope?", &scope); err != nil {
+			return nil, err
+		}
+
+		if name == "" {
+			return nil, fmt.Errorf("%s: variable name is empty", b.Name())
+		}
+
+		if !slices.Contains([]string{ScopeEnvironment, ScopeCollection}, scope) {
+			return nil, fmt.Errorf("%s: unknown scope %q", b.Name(), scope)
+		}
+
+		ctx.set(scope, name, toString(value))
+		return starlark.None, nil
+	}
+
+	return starlarkstruct.FromStringDict(starlark.String("vars"), starlark.StringDict{
+		"get": starlark.NewBuiltin("get", get),
+		"set": starlark.NewBuiltin("set", set),
+	})
+}
+
+var cryptoModule = &starlarkstruct.Module{
+	Name: "crypto",
+	Members: starlark.StringDict{
+		"md5":         digestBuiltin("md5", md5.New),
+		"sha1":        digestBuiltin("sha1", sha1.New),
+		"sha256":      digestBuiltin("sha256", sha256.New),
+		"hmac_sha256": starlark.NewBuiltin("hmac_sha256", hmacSHA256),
+		"random_hex":  starlark.NewBuiltin("random_hex", randomHex),
+	},
+}
+
+var base64Module = &starlarkstruct.Module{
+	Name: "base64",
+	Members: starlark.StringDict{
+		"encode": starlark.NewBuiltin("encode", base64Encode),
+		"decode": starlark.NewBuiltin("decode", base64Decode),
+	},
+}
+
+func digestBuiltin(name string, newHash func() hash.Hash) *starlark.Builtin {
+	return starlark.NewBuiltin(name, func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var data string
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs, "data", &data); err != nil {
+			return nil, err
+		}
+
+		h := newHash()
+		h.Write([]byte(data))
+		return starlark.String(hex.EncodeToString(h.Sum(nil))), nil
+	})
+}
+
+func hmacSHA256(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var key, data string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "key", &key, "data", &data); err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(data))
+	return starlark.String(hex.EncodeToString(mac.Sum(nil))), nil
+}
+
+func randomHex(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	n := 16
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "n?", &n); err != nil {
+		return nil, err
+	}
+
+	if n <= 0 || n > 1024 {
+		return nil, fmt.Errorf("%s: n must be between 1 and 1024", b.Name())
+	}
+
+	buf := make([]byte, n)
+	rand.Read(buf)
+	return starlark.String(hex.EncodeToString(buf)), nil
+}
+
+func base64Encode(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var data string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "data", &data); err != nil {
+		return nil, err
+	}
+
+	return starlark.String(base64.StdEncoding.EncodeToString([]byte(data))), nil
+}
+
+func base64Decode(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var data string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "data", &data); err != nil {
+		return nil, err
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", b.Name(), err)
+	}
+
+	return starlark.String(decoded), nil
+}
+
+func uuidBuiltin(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+		return nil, err
+	}
+
+	return starlark.String(uuid.NewString()), nil
+}
@@ -0,0 +1,67 @@
+package socket
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+var closeCodes = map[int]string{
+	websocket.CloseNormalClosure:           "normal closure",
+	websocket.CloseGoingAway:               "going away",
+	websocket.CloseProtocolError:           "protocol error",
+	websocket.CloseUnsupportedData:         "unsupported data",
+	websocket.CloseNoStatusReceived:        "no status",
+	websocket.CloseAbnormalClosure:         "abnormal closure",
+	websocket.CloseInvalidFramePayloadData: "invalid payload",
+	websocket.ClosePolicyViolation:         "policy violation",
+	websocket.CloseMessageTooBig:           "message too big",
+	websocket.CloseMandatoryExtension:      "mandatory extension",
+	websocket.CloseInternalServerErr:       "internal error",
+	websocket.CloseServiceRestart:          "service restart",
+	websocket.CloseTryAgainLater:           "try again later",
+	websocket.CloseTLSHandshake:            "TLS handshake",
+}
+
+func CloseText(code int) string {
+	if text, ok := closeCodes[code]; ok {
+		return text
+	}
+	return "unknown"
+}
+
+func FormatHex(data []byte) string {
+	encoded := hex.EncodeToString(data)
+
+	var b strings.Builder
+	for i := 0; i < len(encoded); i += 2 {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(encoded[i : i+2])
+	}
+
+	return b.String()
+}
+
+func (e Event) Payload() string {
+	switch e.Kind {
+	case KindBinary:
+		return FormatHex(e.Data)
+	case KindClose:
+		text := fmt.Sprintf("%d %s", e.Code, CloseText(e.Code))
+		if len(e.Data) > 0 {
+			text += ": " + string(e.Data)
+		}
+		return text
+	case KindError:
+		if e.Err != nil {
+			return e.Err.Error()
+		}
+		return ""
+	default:
+		return string(e.Data)
+	}
+}
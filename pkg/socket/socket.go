@@ -0,0 +1,214 @@
+package socket
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func Dial(ctx context.Context, url string, headers http.Header) (*Conn, error) {
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: handshakeTimeout,
+	}
+
+	header := http.Header{}
+	for key, values := range headers {
+		if strings.EqualFold(key, "Sec-WebSocket-Protocol") {
+			for _, protocol := range strings.Split(strings.Join(values, ","), ",") {
+				if protocol = strings.TrimSpace(protocol); protocol != "" {
+					dialer.Subprotocols = append(dialer.Subprotocols, protocol)
+				}
+			}
+			continue
+		}
+		header[key] = append(header[key], values...)
+	}
+
+	conn, resp, err := dialer.DialContext(ctx, url, header)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("%w: %s", ErrHandshake, resp.Status)
+		}
+		return nil, err
+	}
+
+	c := &Conn{
+		conn:     conn,
+		protocol: conn.Subprotocol(),
+		events:   make(chan Event, eventBuffer),
+		done:     make(chan struct{}),
+		stop:     make(chan struct{}),
+	}
+
+	conn.SetPingHandler(func(data string) error {
+		c.emit(Event{Direction: DirectionReceived, Kind: KindPing, Data: []byte(data)})
+		err := conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(closeTimeout))
+		if errors.Is(err, websocket.ErrCloseSent) {
+			return nil
+		}
+		return err
+	})
+
+	conn.SetPongHandler(func(data string) error {
+		c.emit(Event{Direction: DirectionReceived, Kind: KindPong, Data: []byte(data)})
+		return nil
+	})
+
+	go c.read()
+
+	return c, nil
+}
+
+func (c *Conn) Protocol() string {
+	return c.protocol
+}
+
+func (c *Conn) Events() <-chan Event {
+	return c.events
+}
+
+func (c *Conn) Send(typ MessageType, body string) (Event, error) {
+	messageType, data, err := Encode(typ, body)
+	if err != nil {
+		return Event{}, err
+	}
+
+	if c.closing.Load() {
+		return Event{}, ErrClosed
+	}
+
+	c.mutex.Lock()
+	err = c.conn.WriteMessage(messageType, data)
+	c.mutex.Unlock()
+	if err != nil {
+		return Event{}, err
+	}
+
+	kind := KindText
+	if messageType == websocket.BinaryMessage {
+		kind = KindBinary
+	}
+
+	return Event{Time: time.Now(), Direction: DirectionSent, Kind: kind, Data: data}, nil
+}
+
+func (c *Conn) Ping(data string) (Event, error) {
+	if c.closing.Load() {
+		return Event{}, ErrClosed
+	}
+
+	if err := c.conn.WriteControl(websocket.PingMessage, []byte(data), time.Now().Add(closeTimeout)); err != nil {
+		return Event{}, err
+	}
+
+	return Event{Time: time.Now(), Direction: DirectionSent, Kind: KindPing, Data: []byte(data)}, nil
+}
+
+func (c *Conn) Close(code int, reason string) (Event, error) {
+	if !c.closing.CompareAndSwap(false, true) {
+		return Event{}, ErrClosed
+	}
+	defer c.conn.Close()
+	close(c.stop)
+
+	message := websocket.FormatCloseMessage(code, reason)
+	if err := c.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(closeTimeout)); err != nil {
+		return Event{}, err
+	}
+
+	select {
+	case <-c.done:
+	case <-time.After(closeTimeout):
+	}
+
+	return Event{Time: time.Now(), Direction: DirectionSent, Kind: KindClose, Data: []byte(reason), Code: code}, nil
+}
+
+func (c *Conn) read() {
+	defer close(c.events)
+	defer close(c.done)
+
+	for {
+		messageType, data, err := c.conn.ReadMessage()
+		if err != nil {
+			var closeErr *websocket.CloseError
+			switch {
+			case errors.As(err, &closeErr):
+				c.emit(Event{
+					Direction: DirectionReceived,
+					Kind:      KindClose,
+					Data:      []byte(closeErr.Text),
+					Code:      closeErr.Code,
+				})
+			case !c.closing.Load():
+				c.emit(Event{Direction: DirectionReceived, Kind: KindError, Err: err})
+			}
+
+			c.closing.Store(true)
+			c.conn.Close()
+			return
+		}
+
+		kind := KindText
+		if messageType == websocket.BinaryMessage {
+			kind = KindBinary
+		}
+		c.emit(Event{Direction: DirectionReceived, Kind: kind, Data: data})
+	}
+}
+
+func (c *Conn) emit(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	select {
+	case c.events <- e:
+		return
+	default:
+	}
+
+	select {
+	case c.events <- e:
+	case <-c.stop:
+	}
+}
+
+func Encode(typ MessageType, body string) (int, []byte, error) {
+	switch typ {
+	case TypeJSON:
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, []byte(body)); err != nil {
+			return 0, nil, fmt.Errorf("%w: %w", ErrInvalidMessage, err)
+		}
+		return websocket.TextMessage, buf.Bytes(), nil
+	case TypeBinary:
+		data, err := hex.DecodeString(strings.Join(strings.Fields(body), ""))
+		if err != nil {
+			return 0, nil, fmt.Errorf("%w: %w", ErrInvalidMessage, err)
+		}
+		return websocket.BinaryMessage, data, nil
+	default:
+		return websocket.TextMessage, []byte(body), nil
+	}
+}
+
+func ParseMessageType(s string) MessageType {
+	switch MessageType(s) {
+	case TypeJSON:
+		return TypeJSON
+	case TypeBinary:
+		return TypeBinary
+	default:
+		return TypeText
+	}
+}
@@ -0,0 +1,229 @@
+package socket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEchoServer(t *testing.T) *httptest.Server {
+	upgrader := websocket.Upgrader{Subprotocols: []string{"chat.v2", "chat.v1"}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			switch string(data) {
+			case "flood":
+				for i := range 2 * eventBuffer {
+					conn.WriteMessage(websocket.TextMessage, []byte(strconv.Itoa(i)))
+				}
+			case "ping me":
+				conn.WriteControl(websocket.PingMessage, []byte("hello"), time.Now().Add(time.Second))
+			case "bye":
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(4001, "server done"))
+			default:
+				conn.WriteMessage(messageType, data)
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func dial(t *testing.T, server *httptest.Server) *Conn {
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	conn, err := Dial(context.Background(), url, http.Header{
+		"Authorization":          {"Bearer token"},
+		"Sec-WebSocket-Protocol": {"chat.v1", "chat.v2"},
+	})
+	require.NoError(t, err)
+
+	return conn
+}
+
+func next(t *testing.T, conn *Conn) Event {
+	select {
+	case e, ok := <-conn.Events():
+		require.True(t, ok, "events channel closed")
+		return e
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for an event")
+		return Event{}
+	}
+}
+
+func TestDial(t *testing.T) {
+	t.Run("should negotiate a subprotocol", func(t *testing.T) {
+		conn := dial(t, newEchoServer(t))
+		defer conn.Close(websocket.CloseNormalClosure, "")
+
+		assert.Equal(t, "chat.v2", conn.Protocol())
+	})
+
+	t.Run("should report a failed handshake", func(t *testing.T) {
+		server := newEchoServer(t)
+
+		_, err := Dial(context.Background(), "ws"+strings.TrimPrefix(server.URL, "http"), nil)
+
+		assert.ErrorIs(t, err, ErrHandshake)
+		assert.ErrorContains(t, err, "401")
+	})
+}
+
+func TestConn(t *testing.T) {
+	t.Run("should send and receive text, JSON and binary messages", func(t *testing.T) {
+		conn := dial(t, newEchoServer(t))
+		defer conn.Close(websocket.CloseNormalClosure, "")
+
+		sent, err := conn.Send(TypeJSON, "{\n  \"a\": 1\n}")
+		require.NoError(t, err)
+		assert.Equal(t, DirectionSent, sent.Direction)
+		assert.Equal(t, `{"a":1}`, string(sent.Data))
+
+		e := next(t, conn)
+		assert.Equal(t, DirectionReceived, e.Direction)
+		assert.Equal(t, KindText, e.Kind)
+		assert.Equal(t, `{"a":1}`, e.Payload())
+
+		_, err = conn.Send(TypeBinary, "de ad BE EF")
+		require.NoError(t, err)
+
+		e = next(t, conn)
+		assert.Equal(t, KindBinary, e.Kind)
+		assert.Equal(t, "de ad be ef", e.Payload())
+	})
+
+	t.Run("should answer server pings and record pongs", func(t *testing.T) {
+		conn := dial(t, newEchoServer(t))
+		defer conn.Close(websocket.CloseNormalClosure, "")
+
+		_, err := conn.Send(TypeText, "ping me")
+		require.NoError(t, err)
+
+		e := next(t, conn)
+		assert.Equal(t, KindPing, e.Kind)
+		assert.Equal(t, "hello", string(e.Data))
+
+		sent, err := conn.Ping("are you there")
+		require.NoError(t, err)
+		assert.Equal(t, KindPing, sent.Kind)
+
+		e = next(t, conn)
+		assert.Equal(t, KindPong, e.Kind)
+		assert.Equal(t, "are you there", string(e.Data))
+	})
+
+	t.Run("should close cleanly with a code", func(t *testing.T) {
+		conn := dial(t, newEchoServer(t))
+
+		sent, err := conn.Close(websocket.CloseNormalClosure, "done")
+		require.NoError(t, err)
+		assert.Equal(t, "1000 normal closure: done", sent.Payload())
+
+		e := next(t, conn)
+		assert.Equal(t, KindClose, e.Kind)
+		assert.Equal(t, websocket.CloseNormalClosure, e.Code)
+
+		_, err = conn.Send(TypeText, "late")
+		assert.ErrorIs(t, err, ErrClosed)
+	})
+
+	t.Run("should surface close codes sent by the server", func(t *testing.T) {
+		conn := dial(t, newEchoServer(t))
+
+		_, err := conn.Send(TypeText, "bye")
+		require.NoError(t, err)
+
+		e := next(t, conn)
+		assert.Equal(t, KindClose, e.Kind)
+		assert.Equal(t, "4001 unknown: server done", e.Payload())
+
+		_, ok := <-conn.Events()
+		assert.False(t, ok)
+
+		_, err = conn.Close(websocket.CloseNormalClosure, "")
+		assert.ErrorIs(t, err, ErrClosed)
+	})
+
+	t.Run("should deliver every message once the buffer is full", func(t *testing.T) {
+		conn := dial(t, newEchoServer(t))
+		defer conn.Close(websocket.CloseNormalClosure, "")
+
+		_, err := conn.Send(TypeText, "flood")
+		require.NoError(t, err)
+
+		assert.Eventually(t, func() bool { return len(conn.events) == eventBuffer }, 2*time.Second, 10*time.Millisecond)
+
+		for i := range 2 * eventBuffer {
+			e := next(t, conn)
+			require.Equal(t, strconv.Itoa(i), string(e.Data))
+		}
+	})
+
+	t.Run("should release a blocked reader on close", func(t *testing.T) {
+		conn := dial(t, newEchoServer(t))
+
+		_, err := conn.Send(TypeText, "flood")
+		require.NoError(t, err)
+
+		assert.Eventually(t, func() bool { return len(conn.events) == eventBuffer }, 2*time.Second, 10*time.Millisecond)
+
+		start := time.Now()
+		conn.Close(websocket.CloseNormalClosure, "")
+		assert.Less(t, time.Since(start), closeTimeout)
+
+		select {
+		case <-conn.done:
+		case <-time.After(closeTimeout):
+			t.Fatal("reader still blocked after close")
+		}
+	})
+}
+
+func TestEncode(t *testing.T) {
+	t.Run("should reject invalid JSON and hex", func(t *testing.T) {
+		_, _, err := Encode(TypeJSON, "{nope")
+		assert.ErrorIs(t, err, ErrInvalidMessage)
+
+		_, _, err = Encode(TypeBinary, "zz")
+		assert.ErrorIs(t, err, ErrInvalidMessage)
+	})
+
+	t.Run("should send text as is", func(t *testing.T) {
+		messageType, data, err := Encode(TypeText, " raw {text} ")
+
+		require.NoError(t, err)
+		assert.Equal(t, websocket.TextMessage, messageType)
+		assert.Equal(t, " raw {text} ", string(data))
+	})
+
+	t.Run("should parse message types with a text fallback", func(t *testing.T) {
+		assert.Equal(t, TypeBinary, ParseMessageType("binary"))
+		assert.Equal(t, TypeText, ParseMessageType("unknown"))
+	})
+}
@@ -0,0 +1,69 @@
+package socket
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+var (
+	ErrHandshake      = errors.New("websocket handshake failed")
+	ErrInvalidMessage = errors.New("invalid message")
+	ErrClosed         = errors.New("connection closed")
+)
+
+const (
+	handshakeTimeout = 30 * time.Second
+	closeTimeout     = 5 * time.Second
+	eventBuffer      = 256
+)
+
+type MessageType string
+
+const (
+	TypeText   MessageType = "text"
+	TypeJSON   MessageType = "json"
+	TypeBinary MessageType = "binary"
+)
+
+var AllTypes = []MessageType{TypeText, TypeJSON, TypeBinary}
+
+type Direction string
+
+const (
+	DirectionSent     Direction = "sent"
+	DirectionReceived Direction = "received"
+)
+
+type Kind string
+
+const (
+	KindText   Kind = "text"
+	KindBinary Kind = "binary"
+	KindPing   Kind = "ping"
+	KindPong   Kind = "pong"
+	KindClose  Kind = "close"
+	KindError  Kind = "error"
+)
+
+type Event struct {
+	Time      time.Time
+	Direction Direction
+	Kind      Kind
+	Data      []byte
+	Code      int
+	Err       error
+}
+
+type Conn struct {
+	conn     *websocket.Conn
+	protocol string
+	events   chan Event
+	done     chan struct{}
+	stop     chan struct{}
+	closing  atomic.Bool
+	mutex    sync.Mutex
+}
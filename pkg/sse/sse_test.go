@@ -0,0 +1,76 @@
+package sse
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAll(t *testing.T, stream string) []Event {
+	var events []Event
+	require.NoError(t, Read(strings.NewReader(stream), func(e Event) {
+		e.Time = time.Time{}
+		events = append(events, e)
+	}))
+	return events
+}
+
+func TestRead(t *testing.T) {
+	t.Run("should parse fields and dispatch on blank lines", func(t *testing.T) {
+		events := readAll(t, "event: update\nid: 7\nretry: 1500\ndata: {\"a\":1}\n\ndata: second\n\n")
+
+		assert.Equal(t, []Event{
+			{ID: "7", Type: "update", Data: `{"a":1}`, Retry: 1500 * time.Millisecond},
+			{ID: "7", Type: "message", Data: "second", Retry: 1500 * time.Millisecond},
+		}, events)
+	})
+
+	t.Run("should join multi-line data", func(t *testing.T) {
+		events := readAll(t, "data: line one\ndata:line two\ndata\n\n")
+
+		require.Len(t, events, 1)
+		assert.Equal(t, "line one\nline two\n", events[0].Data)
+	})
+
+	t.Run("should ignore comments, unknown fields and empty events", func(t *testing.T) {
+		events := readAll(t, ": keep-alive\nfoo: bar\n\nevent: ignored\n\ndata: ok\n\n")
+
+		require.Len(t, events, 1)
+		assert.Equal(t, Event{Type: "message", Data: "ok"}, events[0])
+	})
+
+	t.Run("should handle CRLF and CR line endings and a leading BOM", func(t *testing.T) {
+		events := readAll(t, "\uFEFFdata: a\r\n\r\ndata: b\r\rdata: c\n\n")
+
+		require.Len(t, events, 3)
+		assert.Equal(t, []string{"a", "b", "c"}, []string{events[0].Data, events[1].Data, events[2].Data})
+	})
+
+	t.Run("should ignore invalid retry and ids with NUL", func(t *testing.T) {
+		events := readAll(t, "id: 1\n\nid: bad\x00id\nretry: soon\ndata: x\n\n")
+
+		require.Len(t, events, 1)
+		assert.Equal(t, "1", events[0].ID)
+		assert.Zero(t, events[0].Retry)
+	})
+
+	t.Run("should drop an unterminated event at EOF", func(t *testing.T) {
+		assert.Empty(t, readAll(t, "data: partial"))
+	})
+
+	t.Run("should return read errors", func(t *testing.T) {
+		err := Read(errReader{}, func(Event) {})
+
+		assert.ErrorContains(t, err, "boom")
+	})
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("boom")
+}
@@ -0,0 +1,17 @@
+package sse
+
+import "time"
+
+const (
+	DefaultEventType = "message"
+	LastEventID      = "Last-Event-ID"
+	maxLineSize      = 4 << 20
+)
+
+type Event struct {
+	ID    string
+	Type  string
+	Data  string
+	Retry time.Duration
+	Time  time.Time
+}
@@ -1,7 +1,9 @@
 package storage
 
 import (
+	"maps"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,9 +20,18 @@ func (s *Storage) findCollectionIndex(id string) int {
 }
 
 func (c *Collection) Copy() *Collection {
+	var vars map[string]string
+	if c.Variables != nil {
+		vars = make(map[string]string, len(c.Variables))
+		maps.Copy(vars, c.Variables)
+	}
+
 	return &Collection{
 		ID:        c.ID,
 		Name:      c.Name,
+		Variables: vars,
+		Scripts:   c.Scripts,
+		Retry:     c.Retry.Copy(),
 		CreatedAt: c.CreatedAt,
 		UpdatedAt: c.UpdatedAt,
 	}
@@ -62,6 +73,25 @@ func (s *Storage) GetCollection(id string) (*Collection, error) {
 	return nil, ErrCollectionNotFound
 }
 
+func (s *Storage) FindCollection(nameOrID string) (*Collection, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, c := range s.store.Collections {
+		if c.ID == nameOrID {
+			return c.Copy(), nil
+		}
+	}
+
+	for _, c := range s.store.Collections {
+		if strings.EqualFold(c.Name, nameOrID) {
+			return c.Copy(), nil
+		}
+	}
+
+	return nil, ErrCollectionNotFound
+}
+
 func (s *Storage) ListCollections() []*Collection {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
@@ -98,6 +128,32 @@ func (s *Storage) UpdateCollection(id, name string) (*Collection, error) {
 	return s.store.Collections[i].Copy(), nil
 }
 
+func (s *Storage) SetCollectionVariables(id string, variables map[string]string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	i := s.findCollectionIndex(id)
+	if i == -1 {
+		return ErrCollectionNotFound
+	}
+
+	oldCollection := s.store.Collections[i]
+	collection := oldCollection.Copy()
+	if collection.Variables == nil {
+		collection.Variables = make(map[string]string, len(variables))
+	}
+	maps.Copy(collection.Variables, variables)
+	collection.UpdatedAt = time.Now()
+	s.store.Collections[i] = collection
+
+	if err := s.save(); err != nil {
+		s.store.Collections[i] = oldCollection
+		return err
+	}
+
+	return nil
+}
+
 func (s *Storage) DeleteCollection(id string, force bool) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
@@ -182,3 +238,119 @@ func (s *Storage) MoveRequest(requestID, collectionID string) error {
 
 	return nil
 }
+
+func validateRequests(requests []*Request) error {
+	for _, req := range requests {
+		if req.URL == "" {
+			return ErrEmptyURL
+		}
+
+		if req.Name == "" {
+			return ErrEmptyName
+		}
+	}
+
+	return nil
+}
+
+func (s *Storage) appendRequests(collectionID string, requests []*Request, now time.Time) {
+	for _, req := range requests {
+		newRequest := req.Copy()
+		newRequest.ID = uuid.NewString()
+		newRequest.CollectionID = collectionID
+		newRequest.CreatedAt = now
+		newRequest.UpdatedAt = now
+		s.store.Requests = append(s.store.Requests, newRequest)
+	}
+}
+
+func (s *Storage) ImportCollection(
+	name string,
+	variables map[string]string,
+	requests []*Request,
+) (*Collection, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.importCollection(name, variables, requests)
+}
+
+func (s *Storage) importCollection(
+	name string,
+	variables map[string]string,
+	requests []*Request,
+) (*Collection, error) {
+	if err := validateRequests(requests); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	collection := &Collection{
+		ID:        uuid.NewString(),
+		Name:      name,
+		Variables: maps.Clone(variables),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	originalRequests := s.store.Requests
+
+	s.appendRequests(collection.ID, requests, now)
+	s.store.Collections = append(s.store.Collections, collection)
+
+	if err := s.save(); err != nil {
+		s.store.Collections = s.store.Collections[:len(s.store.Collections)-1]
+		s.store.Requests = originalRequests
+		return nil, err
+	}
+
+	return collection.Copy(), nil
+}
+
+func (s *Storage) SyncCollection(
+	name string,
+	variables map[string]string,
+	requests []*Request,
+) (*Collection, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	i := -1
+	for idx, c := range s.store.Collections {
+		if c.Name == name {
+			i = idx
+			break
+		}
+	}
+
+	if i == -1 {
+		return s.importCollection(name, variables, requests)
+	}
+
+	if err := validateRequests(requests); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	oldCollection := s.store.Collections[i]
+	originalRequests := s.store.Requests
+
+	collection := oldCollection.Copy()
+	collection.Variables = maps.Clone(variables)
+	collection.UpdatedAt = now
+
+	s.store.Requests = filterRequests(s.store.Requests, func(r *Request) bool {
+		return r.CollectionID != collection.ID
+	})
+	s.appendRequests(collection.ID, requests, now)
+	s.store.Collections[i] = collection
+
+	if err := s.save(); err != nil {
+		s.store.Collections[i] = oldCollection
+		s.store.Requests = originalRequests
+		return nil, err
+	}
+
+	return collection.Copy(), nil
+}
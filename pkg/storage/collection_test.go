@@ -643,3 +643,168 @@ func TestCollectionCopy(t *testing.T) {
 		assert.Equal(t, "Test", original.Name)
 	})
 }
+
+func TestFindCollection(t *testing.T) {
+	t.Run("should find a collection by ID", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		created, err := s.CreateCollection("My API")
+		require.NoError(t, err)
+
+		c, err := s.FindCollection(created.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, created.ID, c.ID)
+	})
+
+	t.Run("should find a collection by name ignoring case", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		created, err := s.CreateCollection("My API")
+		require.NoError(t, err)
+
+		c, err := s.FindCollection("my api")
+
+		assert.NoError(t, err)
+		assert.Equal(t, created.ID, c.ID)
+	})
+
+	t.Run("should return error when nothing matches", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		_, err := s.FindCollection("missing")
+
+		assert.ErrorIs(t, err, ErrCollectionNotFound)
+	})
+}
+
+func TestImportCollection(t *testing.T) {
+	t.Run("should create a collection with its requests", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		requests := []*Request{
+			{Name: "First", Method: "GET", URL: "http://localhost/1"},
+			{Name: "Second", Method: "POST", URL: "http://localhost/2"},
+		}
+
+		c, err := s.ImportCollection("Imported", map[string]string{"host": "localhost"}, requests)
+		require.NoError(t, err)
+
+		imported := s.ListRequestsByCollection(c.ID)
+		assert.Equal(t, "Imported", c.Name)
+		assert.Equal(t, "localhost", c.Variables["host"])
+		assert.Len(t, imported, 2)
+		assert.NotEmpty(t, imported[0].ID)
+		assert.Empty(t, requests[0].ID, "input requests should not be mutated")
+	})
+
+	t.Run("should persist the imported collection", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		c, err := s.ImportCollection("Imported", nil, []*Request{
+			{Name: "First", Method: "GET", URL: "http://localhost/1"},
+		})
+		require.NoError(t, err)
+
+		s2, err := New()
+		require.NoError(t, err)
+
+		assert.Len(t, s2.ListRequestsByCollection(c.ID), 1)
+	})
+
+	t.Run("should reject invalid requests", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		_, err := s.ImportCollection("Imported", nil, []*Request{{Name: "No URL"}})
+
+		assert.ErrorIs(t, err, ErrEmptyURL)
+		assert.Empty(t, s.ListCollections())
+	})
+}
+
+func TestSyncCollection(t *testing.T) {
+	t.Run("should create the collection when missing", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		c, err := s.SyncCollection("Synced", nil, []*Request{
+			{Name: "First", Method: "GET", URL: "http://localhost/1"},
+		})
+		require.NoError(t, err)
+
+		assert.Len(t, s.ListCollections(), 1)
+		assert.Len(t, s.ListRequestsByCollection(c.ID), 1)
+	})
+
+	t.Run("should replace requests and variables of an existing collection", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		first, err := s.SyncCollection("Synced", map[string]string{"a": "1"}, []*Request{
+			{Name: "First", Method: "GET", URL: "http://localhost/1"},
+			{Name: "Second", Method: "GET", URL: "http://localhost/2"},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, s.SaveRequest(&Request{Name: "Other", Method: "GET", URL: "http://other"}))
+
+		second, err := s.SyncCollection("Synced", map[string]string{"b": "2"}, []*Request{
+			{Name: "Third", Method: "POST", URL: "http://localhost/3"},
+		})
+		require.NoError(t, err)
+
+		requests := s.ListRequestsByCollection(second.ID)
+		assert.Equal(t, first.ID, second.ID)
+		assert.Equal(t, map[string]string{"b": "2"}, second.Variables)
+		require.Len(t, requests, 1)
+		assert.Equal(t, "Third", requests[0].Name)
+		assert.Len(t, s.ListRequests(), 2, "requests of other collections should be kept")
+	})
+
+	t.Run("should reject invalid requests without changes", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		c, err := s.SyncCollection("Synced", nil, []*Request{
+			{Name: "First", Method: "GET", URL: "http://localhost/1"},
+		})
+		require.NoError(t, err)
+
+		_, err = s.SyncCollection("Synced", nil, []*Request{{Name: "No URL"}})
+
+		assert.ErrorIs(t, err, ErrEmptyURL)
+		assert.Len(t, s.ListRequestsByCollection(c.ID), 1)
+	})
+}
+
+func TestSetCollectionVariables(t *testing.T) {
+	t.Run("should merge variables", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		c, err := s.ImportCollection("API", map[string]string{"host": "a", "token": "old"}, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, s.SetCollectionVariables(c.ID, map[string]string{"token": "new", "id": "1"}))
+
+		updated, err := s.GetCollection(c.ID)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"host": "a", "token": "new", "id": "1"}, updated.Variables)
+	})
+
+	t.Run("should initialize empty variables", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		c, err := s.CreateCollection("API")
+		require.NoError(t, err)
+
+		require.NoError(t, s.SetCollectionVariables(c.ID, map[string]string{"id": "1"}))
+
+		updated, err := s.GetCollection(c.ID)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"id": "1"}, updated.Variables)
+	})
+
+	t.Run("should return an error for unknown collections", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		err := s.SetCollectionVariables("missing", nil)
+		assert.ErrorIs(t, err, ErrCollectionNotFound)
+	})
+}
@@ -0,0 +1,382 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+func (s *Storage) loadDirectory() error {
+	store := &Store{
+		Collections: []*Collection{},
+		Requests:    []*Request{},
+	}
+
+	collections, collectionsErr := os.ReadDir(filepath.Join(s.dir, collectionsDir))
+	if collectionsErr != nil && !os.IsNotExist(collectionsErr) {
+		return collectionsErr
+	}
+
+	for _, entry := range collections {
+		if !entry.IsDir() {
+			continue
+		}
+
+		dir := filepath.Join(s.dir, collectionsDir, entry.Name())
+
+		collection, err := loadCollectionFile(dir, entry.Name())
+		if err != nil {
+			return err
+		}
+		store.Collections = append(store.Collections, collection)
+
+		requests, err := loadRequestFiles(dir, collection.ID)
+		if err != nil {
+			return err
+		}
+		store.Requests = append(store.Requests, requests...)
+	}
+
+	requests, requestsErr := loadRequestFiles(filepath.Join(s.dir, uncategorizedDir), "")
+	if requestsErr != nil && !os.IsNotExist(requestsErr) {
+		return requestsErr
+	}
+	store.Requests = append(store.Requests, requests...)
+
+	environments, environmentsErr := loadEnvironmentFiles(filepath.Join(s.dir, environmentsDir))
+	if environmentsErr != nil && !os.IsNotExist(environmentsErr) {
+		return environmentsErr
+	}
+	store.Environments = environments
+
+	if os.IsNotExist(collectionsErr) && os.IsNotExist(requestsErr) && os.IsNotExist(environmentsErr) {
+		return collectionsErr
+	}
+
+	s.store = store
+
+	return nil
+}
+
+func loadCollectionFile(dir, name string) (*Collection, error) {
+	collection := &Collection{}
+
+	modTime, err := readYAML(filepath.Join(dir, collectionFile), collection)
+	if os.IsNotExist(err) {
+		modTime, err = time.Now(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if collection.ID == "" {
+		collection.ID = uuid.New().String()
+	}
+	if collection.Name == "" {
+		collection.Name = name
+	}
+	collection.CreatedAt = modTime
+	collection.UpdatedAt = modTime
+
+	return collection, nil
+}
+
+func loadRequestFiles(dir, collectionID string) ([]*Request, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var requests []*Request
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Name() == collectionFile || !isYAMLFile(entry.Name()) {
+			continue
+		}
+
+		req := &Request{}
+
+		modTime, err := readYAML(filepath.Join(dir, entry.Name()), req)
+		if err != nil {
+			return nil, err
+		}
+
+		if req.ID == "" {
+			req.ID = uuid.New().String()
+		}
+		req.CollectionID = collectionID
+		req.CreatedAt = modTime
+		req.UpdatedAt = modTime
+
+		requests = append(requests, req)
+	}
+
+	return requests, nil
+}
+
+func loadEnvironmentFiles(dir string) ([]*Environment, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var environments []*Environment
+	for _, entry := range entries {
+		if entry.IsDir() || !isYAMLFile(entry.Name()) {
+			continue
+		}
+
+		environment := &Environment{}
+
+		modTime, err := readYAML(filepath.Join(dir, entry.Name()), environment)
+		if err != nil {
+			return nil, err
+		}
+
+		if environment.ID == "" {
+			environment.ID = uuid.New().String()
+		}
+		if environment.Name == "" {
+			environment.Name = strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		}
+		environment.CreatedAt = modTime
+		environment.UpdatedAt = modTime
+
+		environments = append(environments, environment)
+	}
+
+	return environments, nil
+}
+
+func readYAML(path string, v any) (time.Time, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if err := yaml.Unmarshal(data, v); err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return info.ModTime(), nil
+}
+
+func (s *Storage) saveDirectory() error {
+	files, err := s.directoryFiles()
+	if err != nil {
+		return err
+	}
+
+	staged := map[string]string{}
+	discard := func() {
+		for _, tmpFile := range staged {
+			os.Remove(tmpFile)
+		}
+	}
+
+	for path, data := range files {
+		existing, err := os.ReadFile(path)
+		if err == nil && bytes.Equal(existing, data) {
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			discard()
+			return err
+		}
+
+		tmpFile, err := writeTempFile(path, data)
+		if err != nil {
+			discard()
+			return err
+		}
+		staged[path] = tmpFile
+	}
+
+	for path, tmpFile := range staged {
+		if err := os.Rename(tmpFile, path); err != nil {
+			discard()
+			return err
+		}
+		delete(staged, path)
+	}
+
+	return s.pruneDirectory(files)
+}
+
+func (s *Storage) removeDirectory() error {
+	return s.pruneDirectory(map[string][]byte{})
+}
+
+func (s *Storage) directoryFiles() (map[string][]byte, error) {
+	files := map[string][]byte{}
+	known := map[string]bool{}
+	collectionSlugs := map[string]bool{}
+
+	for _, c := range s.store.Collections {
+		known[c.ID] = true
+
+		dir := filepath.Join(s.dir, collectionsDir, entrySlug(c.Name, c.ID, collectionSlugs))
+		if err := addYAMLFile(files, filepath.Join(dir, collectionFile), c); err != nil {
+			return nil, err
+		}
+
+		requestSlugs := map[string]bool{strings.TrimSuffix(collectionFile, ".yaml"): true}
+		for _, r := range s.store.Requests {
+			if r.CollectionID != c.ID {
+				continue
+			}
+
+			path := filepath.Join(dir, entrySlug(r.Name, r.ID, requestSlugs)+".yaml")
+			if err := addYAMLFile(files, path, r); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	requestSlugs := map[string]bool{}
+	for _, r := range s.store.Requests {
+		if known[r.CollectionID] {
+			continue
+		}
+
+		path := filepath.Join(s.dir, uncategorizedDir, entrySlug(r.Name, r.ID, requestSlugs)+".yaml")
+		if err := addYAMLFile(files, path, r); err != nil {
+			return nil, err
+		}
+	}
+
+	environmentSlugs := map[string]bool{}
+	for _, e := range s.store.Environments {
+		path := filepath.Join(s.dir, environmentsDir, entrySlug(e.Name, e.ID, environmentSlugs)+".yaml")
+		if err := addYAMLFile(files, path, e); err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+func addYAMLFile(files map[string][]byte, path string, v any) error {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	files[path] = buf.Bytes()
+
+	return nil
+}
+
+func (s *Storage) pruneDirectory(keep map[string][]byte) error {
+	for _, root := range []string{collectionsDir, uncategorizedDir, environmentsDir} {
+		root = filepath.Join(s.dir, root)
+
+		if err := pruneYAMLFiles(root, keep); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func pruneYAMLFiles(dir string, keep map[string][]byte) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		if entry.IsDir() {
+			if err := pruneYAMLFiles(path, keep); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, ok := keep[path]; ok || !isYAMLFile(entry.Name()) {
+			continue
+		}
+
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	entries, err = os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		return os.Remove(dir)
+	}
+
+	return nil
+}
+
+func isYAMLFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+func slugify(name string) string {
+	var b strings.Builder
+
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	slug := strings.TrimSuffix(b.String(), "-")
+	if slug == "" {
+		return "untitled"
+	}
+
+	return slug
+}
+
+func uniqueSlug(name string, used map[string]bool) string {
+	base := slugify(name)
+
+	slug := base
+	for i := 2; used[slug]; i++ {
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+	used[slug] = true
+
+	return slug
+}
+
+func entrySlug(name, id string, used map[string]bool) string {
+	return uniqueSlug(name+" "+id[:min(len(id), shortIDLength)], used)
+}
@@ -0,0 +1,253 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func entryName(name, id string) string {
+	return slugify(name) + "-" + id[:shortIDLength]
+}
+
+func TestSaveDirectory(t *testing.T) {
+	t.Run("should write one stable file per request", func(t *testing.T) {
+		s := setupDirectoryStorage(t)
+
+		req := &Request{
+			Name:    "Create user",
+			Method:  "POST",
+			URL:     "http://localhost/users",
+			Headers: Headers{{Key: "X-B", Value: "2"}, {Key: "X-A", Value: "1", Disabled: true}},
+			Body:    "{\n  \"name\": \"test\"\n}",
+		}
+		require.NoError(t, s.SaveRequest(req))
+		req = s.ListRequests()[0]
+
+		path := filepath.Join(s.dir, uncategorizedDir, "create-user-"+req.ID[:8]+".yaml")
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		expected := "id: " + req.ID + "\n" +
+			"name: Create user\n" +
+			"method: POST\n" +
+			"url: http://localhost/users\n" +
+			"headers:\n" +
+			"  - key: X-B\n" +
+			"    value: \"2\"\n" +
+			"  - key: X-A\n" +
+			"    value: \"1\"\n" +
+			"    disabled: true\n" +
+			"body: |-\n" +
+			"  {\n" +
+			"    \"name\": \"test\"\n" +
+			"  }\n"
+		assert.Equal(t, expected, string(data))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+
+		require.NoError(t, s.SaveRequest(req))
+
+		info2, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, info.ModTime(), info2.ModTime())
+	})
+
+	t.Run("should keep file names stable when siblings change", func(t *testing.T) {
+		s := setupDirectoryStorage(t)
+
+		require.NoError(t, s.SaveRequest(&Request{Name: "Ping", Method: "GET", URL: "http://a"}))
+		require.NoError(t, s.SaveRequest(&Request{Name: "ping", Method: "GET", URL: "http://b"}))
+
+		requests := s.ListRequests()
+		require.Len(t, requests, 2)
+		first := filepath.Join(s.dir, uncategorizedDir, entryName("Ping", requests[0].ID)+".yaml")
+		second := filepath.Join(s.dir, uncategorizedDir, entryName("ping", requests[1].ID)+".yaml")
+		assert.FileExists(t, first)
+		assert.FileExists(t, second)
+
+		require.NoError(t, s.DeleteRequest(requests[0].ID))
+
+		assert.NoFileExists(t, first)
+		assert.FileExists(t, second)
+	})
+
+	t.Run("should leave the tree untouched when a write fails", func(t *testing.T) {
+		s := setupDirectoryStorage(t)
+
+		require.NoError(t, s.SaveRequest(&Request{Name: "Ping", Method: "GET", URL: "http://a"}))
+		req := s.ListRequests()[0]
+		path := filepath.Join(s.dir, uncategorizedDir, entryName("Ping", req.ID)+".yaml")
+		before, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		blocked := &Request{ID: "blocked0-id", Name: "Blocked", Method: "GET", URL: "http://b"}
+		require.NoError(t, os.MkdirAll(filepath.Join(s.dir, uncategorizedDir, entryName("Blocked", blocked.ID)+".yaml.tmp"), 0755))
+
+		updated := *req
+		updated.URL = "http://changed"
+		s.store.Requests = []*Request{&updated, blocked}
+		require.Error(t, s.saveDirectory())
+
+		after, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, string(before), string(after))
+		assert.NoFileExists(t, path+".tmp")
+	})
+
+	t.Run("should remove files of deleted requests and collections", func(t *testing.T) {
+		s := setupDirectoryStorage(t)
+
+		c, err := s.CreateCollection("Orders")
+		require.NoError(t, err)
+
+		req := &Request{Name: "Get order", Method: "GET", URL: "http://localhost", CollectionID: c.ID}
+		require.NoError(t, s.SaveRequest(req))
+
+		dir := filepath.Join(s.dir, collectionsDir, entryName("Orders", c.ID))
+		assert.FileExists(t, filepath.Join(dir, entryName("Get order", s.ListRequests()[0].ID)+".yaml"))
+
+		require.NoError(t, s.DeleteCollection(c.ID, true))
+
+		assert.NoDirExists(t, dir)
+	})
+
+	t.Run("should rename files when a request is renamed", func(t *testing.T) {
+		s := setupDirectoryStorage(t)
+
+		require.NoError(t, s.SaveRequest(&Request{Name: "Old", Method: "GET", URL: "http://localhost"}))
+
+		req := s.ListRequests()[0]
+		req.Name = "New"
+		require.NoError(t, s.SaveRequest(req))
+
+		assert.NoFileExists(t, filepath.Join(s.dir, uncategorizedDir, entryName("Old", req.ID)+".yaml"))
+		assert.FileExists(t, filepath.Join(s.dir, uncategorizedDir, entryName("New", req.ID)+".yaml"))
+	})
+}
+
+func TestLoadDirectory(t *testing.T) {
+	t.Run("should persist websocket message templates", func(t *testing.T) {
+		s := setupDirectoryStorage(t)
+
+		require.NoError(t, s.SaveRequest(&Request{
+			Name:     "Feed",
+			Protocol: ProtocolWebSocket,
+			Method:   "GET",
+			URL:      "ws://localhost/feed",
+			Messages: []Message{{Name: "subscribe", Type: "json", Body: `{"op":"subscribe"}`}},
+		}))
+
+		s2, err := New()
+		require.NoError(t, err)
+
+		requests := s2.ListRequests()
+		require.Len(t, requests, 1)
+		assert.True(t, requests[0].IsWebSocket())
+		assert.Equal(t, []Message{{Name: "subscribe", Type: "json", Body: `{"op":"subscribe"}`}}, requests[0].Messages)
+	})
+
+	t.Run("should persist form-data fields", func(t *testing.T) {
+		s := setupDirectoryStorage(t)
+		form := []FormField{
+			{Key: "name", Value: "bob"},
+			{Key: "avatar", Value: "./avatar.png", Type: FormFieldFile, ContentType: "image/png", Filename: "me.png"},
+		}
+
+		require.NoError(t, s.SaveRequest(&Request{
+			Name:     "Upload",
+			Method:   "POST",
+			URL:      "http://localhost/upload",
+			BodyType: "form-data",
+			Form:     form,
+		}))
+
+		s2, err := New()
+		require.NoError(t, err)
+
+		requests := s2.ListRequests()
+		require.Len(t, requests, 1)
+		assert.Equal(t, form, requests[0].Form)
+		assert.True(t, requests[0].Form[1].IsFile())
+	})
+
+	t.Run("should load hand-written files", func(t *testing.T) {
+		s := setupDirectoryStorage(t)
+
+		dir := filepath.Join(s.dir, collectionsDir, "auth")
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, collectionFile), []byte("name: Auth\nvariables:\n  host: localhost\nscripts:\n  pre_request: log(\"auth\")\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "login.yml"), []byte("name: Login\nmethod: POST\nurl: http://{{host}}/login\n"), 0644))
+
+		s2, err := New()
+		require.NoError(t, err)
+
+		collections := s2.ListCollections()
+		require.Len(t, collections, 1)
+		assert.Equal(t, "Auth", collections[0].Name)
+		assert.NotEmpty(t, collections[0].ID)
+		assert.Equal(t, "localhost", collections[0].Variables["host"])
+		assert.Equal(t, `log("auth")`, collections[0].Scripts.PreRequest)
+
+		requests := s2.ListRequestsByCollection(collections[0].ID)
+		require.Len(t, requests, 1)
+		assert.Equal(t, "Login", requests[0].Name)
+		assert.NotEmpty(t, requests[0].ID)
+		assert.False(t, requests[0].CreatedAt.IsZero())
+	})
+
+	t.Run("should migrate headers written as a mapping", func(t *testing.T) {
+		s := setupDirectoryStorage(t)
+
+		dir := filepath.Join(s.dir, uncategorizedDir)
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "me.yaml"), []byte("name: Me\nmethod: GET\nurl: http://localhost\nheaders:\n  X-B: 2\n  Accept: text/plain\n"), 0644))
+
+		s2, err := New()
+		require.NoError(t, err)
+
+		requests := s2.ListRequests()
+		require.Len(t, requests, 1)
+		assert.Equal(t, Headers{{Key: "X-B", Value: "2"}, {Key: "Accept", Value: "text/plain"}}, requests[0].Headers)
+	})
+
+	t.Run("should return an error on invalid files", func(t *testing.T) {
+		s := setupDirectoryStorage(t)
+
+		dir := filepath.Join(s.dir, uncategorizedDir)
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("name: [\n"), 0644))
+
+		_, err := New()
+		assert.ErrorContains(t, err, "broken.yaml")
+	})
+
+	t.Run("should return a not exist error when empty", func(t *testing.T) {
+		s := setupDirectoryStorage(t)
+
+		err := s.load()
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Create user", "create-user"},
+		{"GET /users/{{id}}", "get-users-id"},
+		{"  --  ", "untitled"},
+		{"Ünïcode", "n-code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, slugify(tt.input))
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (s *Storage) findEnvironmentIndex(id string) int {
+	for i, e := range s.store.Environments {
+		if e.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (e *Environment) Copy() *Environment {
+	return &Environment{
+		ID:        e.ID,
+		Name:      e.Name,
+		Variables: maps.Clone(e.Variables),
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+func (s *Storage) SaveEnvironment(name string, variables map[string]string) (*Environment, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+
+	now := time.Now()
+
+	for i, e := range s.store.Environments {
+		if !strings.EqualFold(e.Name, name) {
+			continue
+		}
+
+		oldEnvironment := e
+		environment := e.Copy()
+		environment.Variables = maps.Clone(variables)
+		environment.UpdatedAt = now
+		s.store.Environments[i] = environment
+
+		if err := s.save(); err != nil {
+			s.store.Environments[i] = oldEnvironment
+			return nil, err
+		}
+
+		return environment.Copy(), nil
+	}
+
+	environment := &Environment{
+		ID:        uuid.NewString(),
+		Name:      name,
+		Variables: maps.Clone(variables),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	s.store.Environments = append(s.store.Environments, environment)
+
+	if err := s.save(); err != nil {
+		s.store.Environments = s.store.Environments[:len(s.store.Environments)-1]
+		return nil, err
+	}
+
+	return environment.Copy(), nil
+}
+
+func (s *Storage) SetEnvironmentVariables(id string, variables map[string]string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	i := s.findEnvironmentIndex(id)
+	if i == -1 {
+		return ErrEnvironmentNotFound
+	}
+
+	oldEnvironment := s.store.Environments[i]
+	environment := oldEnvironment.Copy()
+	if environment.Variables == nil {
+		environment.Variables = make(map[string]string, len(variables))
+	}
+	maps.Copy(environment.Variables, variables)
+	environment.UpdatedAt = time.Now()
+	s.store.Environments[i] = environment
+
+	if err := s.save(); err != nil {
+		s.store.Environments[i] = oldEnvironment
+		return err
+	}
+
+	return nil
+}
+
+func (s *Storage) GetEnvironment(id string) (*Environment, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	i := s.findEnvironmentIndex(id)
+	if i == -1 {
+		return nil, ErrEnvironmentNotFound
+	}
+
+	return s.store.Environments[i].Copy(), nil
+}
+
+func (s *Storage) FindEnvironment(nameOrID string) (*Environment, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if i := s.findEnvironmentIndex(nameOrID); i != -1 {
+		return s.store.Environments[i].Copy(), nil
+	}
+
+	for _, e := range s.store.Environments {
+		if strings.EqualFold(e.Name, nameOrID) {
+			return e.Copy(), nil
+		}
+	}
+
+	return nil, ErrEnvironmentNotFound
+}
+
+func (s *Storage) ListEnvironments() []*Environment {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	result := make([]*Environment, len(s.store.Environments))
+	for i, e := range s.store.Environments {
+		result[i] = e.Copy()
+	}
+
+	return result
+}
+
+func (s *Storage) DeleteEnvironment(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	i := s.findEnvironmentIndex(id)
+	if i == -1 {
+		return ErrEnvironmentNotFound
+	}
+
+	deletedEnvironment := s.store.Environments[i]
+	s.store.Environments = slices.Delete(s.store.Environments, i, i+1)
+
+	if err := s.save(); err != nil {
+		s.store.Environments = slices.Insert(s.store.Environments, i, deletedEnvironment)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveEnvironment(t *testing.T) {
+	t.Run("should create an environment", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		env, err := s.SaveEnvironment("staging", map[string]string{"host": "staging.local"})
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, env.ID)
+		assert.Equal(t, "staging", env.Name)
+		assert.Len(t, s.ListEnvironments(), 1)
+	})
+
+	t.Run("should replace the variables of an existing environment", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		first, err := s.SaveEnvironment("staging", map[string]string{"host": "a"})
+		require.NoError(t, err)
+
+		second, err := s.SaveEnvironment("Staging", map[string]string{"host": "b"})
+		require.NoError(t, err)
+
+		assert.Equal(t, first.ID, second.ID)
+		assert.Equal(t, map[string]string{"host": "b"}, second.Variables)
+		assert.Len(t, s.ListEnvironments(), 1)
+	})
+
+	t.Run("should return an error on empty name", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		_, err := s.SaveEnvironment("", nil)
+		assert.ErrorIs(t, err, ErrEmptyName)
+	})
+
+	t.Run("should rollback on save failure", func(t *testing.T) {
+		s := setupTestStorage(t)
+		makeReadOnly(t, s)
+
+		_, err := s.SaveEnvironment("staging", nil)
+		if err == nil {
+			t.Skip("filesystem permissions are not enforced")
+		}
+
+		assert.Empty(t, s.ListEnvironments())
+	})
+}
+
+func TestFindEnvironment(t *testing.T) {
+	s := setupTestStorage(t)
+
+	env, err := s.SaveEnvironment("Production", map[string]string{"host": "prod"})
+	require.NoError(t, err)
+
+	t.Run("should find by ID", func(t *testing.T) {
+		found, err := s.FindEnvironment(env.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Production", found.Name)
+	})
+
+	t.Run("should find by name ignoring case", func(t *testing.T) {
+		found, err := s.FindEnvironment("production")
+		require.NoError(t, err)
+		assert.Equal(t, env.ID, found.ID)
+	})
+
+	t.Run("should return an error when missing", func(t *testing.T) {
+		_, err := s.FindEnvironment("dev")
+		assert.ErrorIs(t, err, ErrEnvironmentNotFound)
+
+		_, err = s.GetEnvironment("dev")
+		assert.ErrorIs(t, err, ErrEnvironmentNotFound)
+	})
+
+	t.Run("should return copies", func(t *testing.T) {
+		found, err := s.GetEnvironment(env.ID)
+		require.NoError(t, err)
+
+		found.Variables["host"] = "changed"
+
+		again, err := s.GetEnvironment(env.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "prod", again.Variables["host"])
+	})
+}
+
+func TestSetEnvironmentVariables(t *testing.T) {
+	s := setupTestStorage(t)
+
+	env, err := s.SaveEnvironment("dev", map[string]string{"host": "a"})
+	require.NoError(t, err)
+
+	require.NoError(t, s.SetEnvironmentVariables(env.ID, map[string]string{"token": "abc"}))
+
+	updated, err := s.GetEnvironment(env.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"host": "a", "token": "abc"}, updated.Variables)
+
+	assert.ErrorIs(t, s.SetEnvironmentVariables("missing", nil), ErrEnvironmentNotFound)
+}
+
+func TestDeleteEnvironment(t *testing.T) {
+	s := setupTestStorage(t)
+
+	env, err := s.SaveEnvironment("dev", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, s.DeleteEnvironment(env.ID))
+	assert.Empty(t, s.ListEnvironments())
+
+	assert.ErrorIs(t, s.DeleteEnvironment(env.ID), ErrEnvironmentNotFound)
+}
+
+func TestEnvironmentDirectoryLayout(t *testing.T) {
+	s := setupDirectoryStorage(t)
+
+	env, err := s.SaveEnvironment("Local Dev", map[string]string{"host": "localhost"})
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(s.dir, environmentsDir, entryName("Local Dev", env.ID)+".yaml"))
+
+	s2, err := New()
+	require.NoError(t, err)
+
+	env, err = s2.FindEnvironment("local dev")
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", env.Variables["host"])
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (r *HistoryResponse) Copy() *HistoryResponse {
+	var headers map[string][]string
+	if r.Headers != nil {
+		headers = make(map[string][]string, len(r.Headers))
+		for key, values := range r.Headers {
+			headers[key] = append([]string(nil), values...)
+		}
+	}
+
+	return &HistoryResponse{
+		StatusCode: r.StatusCode,
+		Headers:    headers,
+		Body:       r.Body,
+		TimeTaken:  r.TimeTaken,
+	}
+}
+
+func (e *HistoryEntry) Copy() *HistoryEntry {
+	entry := &HistoryEntry{
+		ID:     e.ID,
+		Error:  e.Error,
+		SentAt: e.SentAt,
+	}
+
+	if e.Request != nil {
+		entry.Request = e.Request.Copy()
+	}
+
+	if e.Response != nil {
+		entry.Response = e.Response.Copy()
+	}
+
+	return entry
+}
+
+func (s *Storage) AddHistory(entry *HistoryEntry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if entry.Request == nil || entry.Request.URL == "" {
+		return ErrEmptyURL
+	}
+
+	newEntry := entry.Copy()
+	newEntry.ID = uuid.NewString()
+	if newEntry.SentAt.IsZero() {
+		newEntry.SentAt = time.Now()
+	}
+
+	oldHistory := s.history
+	s.history = append(s.history, newEntry)
+	if len(s.history) > maxHistory {
+		s.history = s.history[len(s.history)-maxHistory:]
+	}
+
+	if err := s.saveHistory(); err != nil {
+		s.history = oldHistory
+		return err
+	}
+
+	return nil
+}
+
+func (s *Storage) ListHistory() []*HistoryEntry {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	result := make([]*HistoryEntry, len(s.history))
+	for i, e := range s.history {
+		result[i] = e.Copy()
+	}
+
+	return result
+}
+
+func (s *Storage) ClearHistory() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	oldHistory := s.history
+	s.history = []*HistoryEntry{}
+
+	if err := s.saveHistory(); err != nil {
+		s.history = oldHistory
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddHistory(t *testing.T) {
+	t.Run("should add an entry with an ID and a timestamp", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		err := s.AddHistory(&HistoryEntry{
+			Request:  &Request{Method: "GET", URL: "http://localhost"},
+			Response: &HistoryResponse{StatusCode: 200, TimeTaken: 12},
+		})
+		require.NoError(t, err)
+
+		history := s.ListHistory()
+		require.Len(t, history, 1)
+		assert.NotEmpty(t, history[0].ID)
+		assert.NotZero(t, history[0].SentAt)
+		assert.Equal(t, 200, history[0].Response.StatusCode)
+	})
+
+	t.Run("should return ErrEmptyURL without request URL", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		err := s.AddHistory(&HistoryEntry{Request: &Request{}})
+
+		assert.ErrorIs(t, err, ErrEmptyURL)
+	})
+
+	t.Run("should keep only the latest entries", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		for range maxHistory + 5 {
+			require.NoError(t, s.AddHistory(&HistoryEntry{
+				Request: &Request{Method: "GET", URL: "http://localhost"},
+			}))
+		}
+
+		assert.Len(t, s.ListHistory(), maxHistory)
+	})
+
+	t.Run("should persist history to storage", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		require.NoError(t, s.AddHistory(&HistoryEntry{
+			Request: &Request{Method: "GET", URL: "http://localhost"},
+			Error:   "connection refused",
+		}))
+
+		s2, err := New()
+		require.NoError(t, err)
+
+		require.Len(t, s2.ListHistory(), 1)
+		assert.Equal(t, "connection refused", s2.ListHistory()[0].Error)
+	})
+}
+
+func TestListHistory(t *testing.T) {
+	t.Run("should return copies, not internal pointers", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		require.NoError(t, s.AddHistory(&HistoryEntry{
+			Request: &Request{Method: "GET", URL: "http://localhost"},
+			Response: &HistoryResponse{
+				StatusCode: 200,
+				Headers:    map[string][]string{"X-Test": {"a"}},
+			},
+		}))
+
+		history := s.ListHistory()
+		history[0].Request.URL = "modified"
+		history[0].Response.Headers["X-Test"][0] = "modified"
+
+		assert.Equal(t, "http://localhost", s.history[0].Request.URL)
+		assert.Equal(t, "a", s.history[0].Response.Headers["X-Test"][0])
+	})
+}
+
+func TestClearHistory(t *testing.T) {
+	t.Run("should remove every entry", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		require.NoError(t, s.AddHistory(&HistoryEntry{
+			Request: &Request{Method: "GET", URL: "http://localhost"},
+		}))
+
+		err := s.ClearHistory()
+
+		assert.NoError(t, err)
+		assert.Empty(t, s.ListHistory())
+	})
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func ParseLayout(s string) (Layout, error) {
+	switch Layout(strings.ToLower(strings.TrimSpace(s))) {
+	case LayoutJSON, "":
+		return LayoutJSON, nil
+	case LayoutDirectory, "dir":
+		return LayoutDirectory, nil
+	}
+
+	return "", ErrUnknownLayout
+}
+
+func loadConfig(dir string) (*Config, error) {
+	config := &Config{Layout: LayoutJSON}
+
+	data, err := os.ReadFile(filepath.Join(dir, configFile))
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+
+	layout, err := ParseLayout(string(config.Layout))
+	if err != nil {
+		return nil, err
+	}
+	config.Layout = layout
+
+	return config, nil
+}
+
+func (s *Storage) Layout() Layout {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.layout
+}
+
+func (s *Storage) MaxBodySize() int64 {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.maxBodySize
+}
+
+func (s *Storage) SetLayout(layout Layout) error {
+	layout, err := ParseLayout(string(layout))
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if layout == s.layout {
+		return nil
+	}
+
+	previous := s.layout
+	s.layout = layout
+
+	if err := s.save(); err != nil {
+		s.layout = previous
+		return err
+	}
+
+	if err := writeJSON(filepath.Join(s.dir, configFile), &Config{Layout: layout, MaxBodySize: s.maxBodySize}); err != nil {
+		s.layout = previous
+		return err
+	}
+
+	if layout == LayoutDirectory {
+		if err := writeGitignore(s.dir); err != nil {
+			return err
+		}
+		return removeIfExists(s.path)
+	}
+
+	return s.removeDirectory()
+}
+
+func writeGitignore(dir string) error {
+	path := filepath.Join(dir, ".gitignore")
+
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+
+	return writeFile(path, []byte(historyFile+"\n*.tmp\n"))
+}
+
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDirectoryStorage(t *testing.T) *Storage {
+	s := setupTestStorage(t)
+	require.NoError(t, s.SetLayout(LayoutDirectory))
+
+	return s
+}
+
+func TestParseLayout(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Layout
+	}{
+		{"", LayoutJSON},
+		{"json", LayoutJSON},
+		{"Directory", LayoutDirectory},
+		{"dir", LayoutDirectory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			layout, err := ParseLayout(tt.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, layout)
+		})
+	}
+
+	t.Run("should return an error for unknown layouts", func(t *testing.T) {
+		_, err := ParseLayout("toml")
+
+		assert.ErrorIs(t, err, ErrUnknownLayout)
+	})
+}
+
+func TestSetLayout(t *testing.T) {
+	t.Run("should default to the json layout", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		assert.Equal(t, LayoutJSON, s.Layout())
+	})
+
+	t.Run("should migrate existing data to the directory layout", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		c, err := s.CreateCollection("Users API")
+		require.NoError(t, err)
+		require.NoError(t, s.SaveRequest(&Request{
+			Name:         "List users",
+			Method:       "GET",
+			URL:          "http://localhost/users",
+			CollectionID: c.ID,
+		}))
+
+		require.NoError(t, s.SetLayout(LayoutDirectory))
+
+		assert.Equal(t, LayoutDirectory, s.Layout())
+		assert.NoFileExists(t, s.path)
+		assert.FileExists(t, filepath.Join(s.dir, configFile))
+		assert.FileExists(t, filepath.Join(s.dir, ".gitignore"))
+		dir := filepath.Join(s.dir, collectionsDir, entryName("Users API", c.ID))
+		assert.FileExists(t, filepath.Join(dir, collectionFile))
+		assert.FileExists(t, filepath.Join(dir, entryName("List users", s.ListRequests()[0].ID)+".yaml"))
+
+		s2, err := New()
+		require.NoError(t, err)
+
+		assert.Equal(t, LayoutDirectory, s2.Layout())
+		require.Len(t, s2.ListRequestsByCollection(c.ID), 1)
+		assert.Equal(t, "List users", s2.ListRequestsByCollection(c.ID)[0].Name)
+	})
+
+	t.Run("should migrate back to the json layout", func(t *testing.T) {
+		s := setupDirectoryStorage(t)
+
+		require.NoError(t, s.SaveRequest(&Request{Name: "Ping", Method: "GET", URL: "http://localhost"}))
+		require.NoError(t, s.SetLayout(LayoutJSON))
+
+		assert.FileExists(t, s.path)
+		assert.NoDirExists(t, filepath.Join(s.dir, uncategorizedDir))
+
+		s2, err := New()
+		require.NoError(t, err)
+
+		assert.Equal(t, LayoutJSON, s2.Layout())
+		assert.Len(t, s2.ListRequests(), 1)
+	})
+
+	t.Run("should return an error for unknown layouts", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		assert.ErrorIs(t, s.SetLayout("toml"), ErrUnknownLayout)
+		assert.Equal(t, LayoutJSON, s.Layout())
+	})
+
+	t.Run("should fail on an invalid config file", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		require.NoError(t, os.WriteFile(filepath.Join(s.dir, configFile), []byte(`{"layout":"toml"}`), 0644))
+
+		_, err := New()
+		assert.ErrorIs(t, err, ErrUnknownLayout)
+	})
+}
+
+func TestMaxBodySize(t *testing.T) {
+	t.Run("should default to zero", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		assert.Zero(t, s.MaxBodySize())
+	})
+
+	t.Run("should load the limit from the config file and keep it on layout changes", func(t *testing.T) {
+		s := setupTestStorage(t)
+		path := filepath.Join(s.dir, configFile)
+		require.NoError(t, os.WriteFile(path, []byte(`{"max_body_size":1024}`), 0644))
+
+		s, err := New()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1024), s.MaxBodySize())
+
+		require.NoError(t, s.SetLayout(LayoutDirectory))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"max_body_size": 1024`)
+	})
+}
@@ -1,11 +1,14 @@
 package storage
 
 import (
-	"maps"
+	"bytes"
+	"encoding/json"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
 )
 
 func (s *Storage) findRequestIndex(id string) int {
@@ -18,24 +21,36 @@ func (s *Storage) findRequestIndex(id string) int {
 	return -1
 }
 
-func (r *Request) Copy() *Request {
-	var headers map[string]string
-	if r.Headers != nil {
-		headers = make(map[string]string, len(r.Headers))
-		maps.Copy(headers, r.Headers)
-	}
+func (p RetryPolicy) Copy() RetryPolicy {
+	p.Statuses = slices.Clone(p.Statuses)
+	p.Errors = slices.Clone(p.Errors)
+	return p
+}
 
+func (r *Request) Copy() *Request {
 	return &Request{
-		ID:           r.ID,
-		CollectionID: r.CollectionID,
-		Name:         r.Name,
-		Method:       r.Method,
-		URL:          r.URL,
-		Headers:      headers,
-		Body:         r.Body,
-		BodyType:     r.BodyType,
-		CreatedAt:    r.CreatedAt,
-		UpdatedAt:    r.UpdatedAt,
+		ID:                   r.ID,
+		CollectionID:         r.CollectionID,
+		Name:                 r.Name,
+		Protocol:             r.Protocol,
+		Method:               r.Method,
+		URL:                  r.URL,
+		Headers:              slices.Clone(r.Headers),
+		Body:                 r.Body,
+		BodyType:             r.BodyType,
+		Form:                 slices.Clone(r.Form),
+		Compression:          r.Compression,
+		DisableDecompression: r.DisableDecompression,
+		Assertions:           slices.Clone(r.Assertions),
+		Extractions:          slices.Clone(r.Extractions),
+		Scripts:              r.Scripts,
+		Retry:                r.Retry.Copy(),
+		Connection:           r.Connection,
+		Messages:             slices.Clone(r.Messages),
+		RPC:                  r.RPC,
+		ProtoFiles:           slices.Clone(r.ProtoFiles),
+		CreatedAt:            r.CreatedAt,
+		UpdatedAt:            r.UpdatedAt,
 	}
 }
 
@@ -99,6 +114,50 @@ func (s *Storage) GetRequest(id string) (*Request, error) {
 	return nil, ErrRequestNotFound
 }
 
+func (s *Storage) FindRequest(ref string) (*Request, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, req := range s.store.Requests {
+		if req.ID == ref {
+			return req.Copy(), nil
+		}
+	}
+
+	var matches []*Request
+
+	for _, c := range s.store.Collections {
+		prefix := c.Name + "/"
+		if len(ref) <= len(prefix) || !strings.EqualFold(ref[:len(prefix)], prefix) {
+			continue
+		}
+
+		name := ref[len(prefix):]
+		for _, req := range s.store.Requests {
+			if req.CollectionID == c.ID && (req.ID == name || strings.EqualFold(req.Name, name)) {
+				matches = append(matches, req)
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		for _, req := range s.store.Requests {
+			if strings.EqualFold(req.Name, ref) {
+				matches = append(matches, req)
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, ErrRequestNotFound
+	case 1:
+		return matches[0].Copy(), nil
+	}
+
+	return nil, ErrAmbiguousRequest
+}
+
 func (s *Storage) ListRequests() []*Request {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
@@ -129,3 +188,73 @@ func (s *Storage) DeleteRequest(id string) error {
 	}
 	return nil
 }
+
+func (r *Request) IsWebSocket() bool {
+	return r.Protocol == ProtocolWebSocket
+}
+
+func (r *Request) IsGRPC() bool {
+	return r.Protocol == ProtocolGRPC
+}
+
+func (f FormField) IsFile() bool {
+	return f.Type == FormFieldFile
+}
+
+func (h Headers) Get(key string) string {
+	for _, header := range h {
+		if !header.Disabled && strings.EqualFold(header.Key, key) {
+			return header.Value
+		}
+	}
+
+	return ""
+}
+
+func (h *Headers) UnmarshalJSON(data []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return json.Unmarshal(data, (*[]Header)(h))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+
+	headers := Headers{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		var value string
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+
+		headers = append(headers, Header{Key: token.(string), Value: value})
+	}
+
+	*h = headers
+	return nil
+}
+
+func (h *Headers) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return node.Decode((*[]Header)(h))
+	}
+
+	headers := make(Headers, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		var value string
+		if err := node.Content[i+1].Decode(&value); err != nil {
+			return err
+		}
+
+		headers = append(headers, Header{Key: node.Content[i].Value, Value: value})
+	}
+
+	*h = headers
+	return nil
+}
@@ -282,6 +282,53 @@ func TestStorageGetRequest(t *testing.T) {
 	})
 }
 
+func TestStorageFindRequest(t *testing.T) {
+	s := setupTestStorage(t)
+
+	api, err := s.ImportCollection("My API", nil, []*Request{
+		{Name: "Login", Method: "POST", URL: "http://localhost/login"},
+		{Name: "Me", Method: "GET", URL: "http://localhost/me"},
+	})
+	require.NoError(t, err)
+
+	_, err = s.ImportCollection("Admin", nil, []*Request{
+		{Name: "Login", Method: "POST", URL: "http://localhost/admin/login"},
+	})
+	require.NoError(t, err)
+
+	me := s.ListRequestsByCollection(api.ID)[1]
+
+	t.Run("should find a request by ID", func(t *testing.T) {
+		r, err := s.FindRequest(me.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Me", r.Name)
+	})
+
+	t.Run("should find a request by collection and name", func(t *testing.T) {
+		r, err := s.FindRequest("my api/login")
+		require.NoError(t, err)
+		assert.Equal(t, "http://localhost/login", r.URL)
+
+		r, err = s.FindRequest("Admin/Login")
+		require.NoError(t, err)
+		assert.Equal(t, "http://localhost/admin/login", r.URL)
+	})
+
+	t.Run("should find a request by unique name", func(t *testing.T) {
+		r, err := s.FindRequest("me")
+		require.NoError(t, err)
+		assert.Equal(t, me.ID, r.ID)
+	})
+
+	t.Run("should reject ambiguous and unknown references", func(t *testing.T) {
+		_, err := s.FindRequest("Login")
+		assert.ErrorIs(t, err, ErrAmbiguousRequest)
+
+		_, err = s.FindRequest("My API/Missing")
+		assert.ErrorIs(t, err, ErrRequestNotFound)
+	})
+}
+
 func TestListRequests(t *testing.T) {
 	t.Run("should return empty slice when no requests", func(t *testing.T) {
 		s := setupTestStorage(t)
@@ -449,16 +496,16 @@ func TestRequestCopy(t *testing.T) {
 			Name:    "Test",
 			Method:  "GET",
 			URL:     "http://localhost",
-			Headers: map[string]string{"Authorization": "Bearer token"},
+			Headers: Headers{{Key: "Authorization", Value: "Bearer token"}},
 		}
 
 		copied := original.Copy()
 
 		copied.Name = "Modified"
-		copied.Headers["Authorization"] = "Modified"
+		copied.Headers[0].Value = "Modified"
 
 		assert.Equal(t, "Test", original.Name)
-		assert.Equal(t, "Bearer token", original.Headers["Authorization"])
+		assert.Equal(t, "Bearer token", original.Headers[0].Value)
 	})
 
 	t.Run("should handle nil headers", func(t *testing.T) {
@@ -472,4 +519,92 @@ func TestRequestCopy(t *testing.T) {
 
 		assert.Nil(t, copied.Headers)
 	})
+
+	t.Run("should copy scripts", func(t *testing.T) {
+		original := &Request{
+			ID:      "test-id",
+			Scripts: Scripts{PreRequest: `log("pre")`, PostResponse: `log("post")`},
+		}
+
+		assert.Equal(t, original.Scripts, original.Copy().Scripts)
+	})
+
+	t.Run("should copy websocket messages", func(t *testing.T) {
+		original := &Request{
+			ID:       "test-id",
+			Protocol: ProtocolWebSocket,
+			Messages: []Message{{Name: "hello", Type: "json", Body: `{"op":"hello"}`}},
+		}
+
+		copied := original.Copy()
+		copied.Messages[0].Body = "changed"
+
+		assert.True(t, copied.IsWebSocket())
+		assert.Equal(t, `{"op":"hello"}`, original.Messages[0].Body)
+	})
+
+	t.Run("should copy grpc settings", func(t *testing.T) {
+		original := &Request{
+			ID:         "test-id",
+			Protocol:   ProtocolGRPC,
+			RPC:        "greeter.v1.Greeter/SayHello",
+			ProtoFiles: []string{"greeter.proto"},
+		}
+
+		copied := original.Copy()
+		copied.ProtoFiles[0] = "changed.proto"
+
+		assert.True(t, copied.IsGRPC())
+		assert.Equal(t, "greeter.v1.Greeter/SayHello", copied.RPC)
+		assert.Equal(t, []string{"greeter.proto"}, original.ProtoFiles)
+	})
+
+	t.Run("should copy form fields", func(t *testing.T) {
+		original := &Request{
+			ID:   "test-id",
+			Form: []FormField{{Key: "avatar", Value: "avatar.png", Type: FormFieldFile}},
+		}
+
+		copied := original.Copy()
+		copied.Form[0].Value = "changed.png"
+
+		assert.Equal(t, "avatar.png", original.Form[0].Value)
+	})
+
+	t.Run("should copy compression settings", func(t *testing.T) {
+		original := &Request{ID: "test-id", Compression: "zstd", DisableDecompression: true}
+
+		copied := original.Copy()
+
+		assert.Equal(t, "zstd", copied.Compression)
+		assert.True(t, copied.DisableDecompression)
+	})
+
+	t.Run("should copy connection settings", func(t *testing.T) {
+		original := &Request{ID: "test-id", Connection: Connection{HTTPVersion: "h2c", MaxIdleConns: 4}}
+
+		assert.Equal(t, original.Connection, original.Copy().Connection)
+	})
+
+	t.Run("should copy the retry policy", func(t *testing.T) {
+		original := &Request{ID: "test-id", Retry: RetryPolicy{Attempts: 3, Statuses: []int{503}}}
+
+		copied := original.Copy()
+		copied.Retry.Statuses[0] = 502
+
+		assert.Equal(t, 3, copied.Retry.Attempts)
+		assert.Equal(t, []int{503}, original.Retry.Statuses)
+	})
+
+	t.Run("should copy assertions", func(t *testing.T) {
+		original := &Request{
+			ID:         "test-id",
+			Assertions: []Assertion{{Type: "status", Operator: "equals", Value: "200"}},
+		}
+
+		copied := original.Copy()
+		copied.Assertions[0].Value = "404"
+
+		assert.Equal(t, "200", original.Assertions[0].Value)
+	})
 }
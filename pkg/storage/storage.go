@@ -7,6 +7,10 @@ import (
 )
 
 func (s *Storage) load() error {
+	if s.layout == LayoutDirectory {
+		return s.loadDirectory()
+	}
+
 	data, err := os.ReadFile(s.path)
 	if err != nil {
 		return err
@@ -15,61 +19,135 @@ func (s *Storage) load() error {
 	return json.Unmarshal(data, s.store)
 }
 
+func (s *Storage) loadHistory() error {
+	data, err := os.ReadFile(s.historyPath)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, &s.history)
+}
+
 func (s *Storage) save() error {
-	data, err := json.MarshalIndent(s.store, "", "  ")
+	if s.layout == LayoutDirectory {
+		return s.saveDirectory()
+	}
+
+	return writeJSON(s.path, s.store)
+}
+
+func (s *Storage) saveHistory() error {
+	return writeJSON(s.historyPath, s.history)
+}
+
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	tmpFile := s.path + ".tmp"
+	return writeFile(path, data)
+}
 
-	f, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+func writeFile(path string, data []byte) error {
+	tmpFile, err := writeTempFile(path, data)
 	if err != nil {
 		return err
 	}
 
+	return os.Rename(tmpFile, path)
+}
+
+func writeTempFile(path string, data []byte) (string, error) {
+	tmpFile := path + ".tmp"
+
+	f, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", err
+	}
+
 	_, err = f.Write(data)
 	if err != nil {
+		f.Close()
 		os.Remove(tmpFile)
-		return err
+		return "", err
 	}
 
 	err = f.Sync()
 	if err != nil {
+		f.Close()
 		os.Remove(tmpFile)
-		return err
+		return "", err
 	}
 
 	err = f.Close()
 	if err != nil {
 		os.Remove(tmpFile)
-		return err
+		return "", err
 	}
 
-	return os.Rename(tmpFile, s.path)
+	return tmpFile, nil
 }
 
 func New() (*Storage, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	if dir, ok := FindWorkspace(cwd); ok {
+		return Open(dir)
+	}
+
 	configDir, err := getConfigDir()
 	if err != nil {
 		return nil, err
 	}
 
-	if err := os.MkdirAll(configDir, 0755); err != nil {
+	return Open(configDir)
+}
+
+func Open(dir string) (*Storage, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	config, err := loadConfig(dir)
+	if err != nil {
 		return nil, err
 	}
 
 	s := &Storage{
-		path: filepath.Join(configDir, requestsFile),
+		dir:         dir,
+		path:        filepath.Join(dir, requestsFile),
+		historyPath: filepath.Join(dir, historyFile),
+		layout:      config.Layout,
+		maxBodySize: config.MaxBodySize,
 		store: &Store{
 			Collections: []*Collection{},
 			Requests:    []*Request{},
 		},
+		history: []*HistoryEntry{},
 	}
 
 	if err := s.load(); err != nil && !os.IsNotExist(err) {
 		return nil, err
 	}
 
+	if err := s.loadHistory(); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if !s.Workspace().Global {
+		if err := registerWorkspace(dir); err != nil {
+			return nil, err
+		}
+	}
+
 	return s, nil
 }
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -59,3 +60,43 @@ func TestNew(t *testing.T) {
 		assert.Equal(t, "Test", s2.ListRequests()[0].Name)
 	})
 }
+
+func TestMigrateHeaders(t *testing.T) {
+	t.Run("should read headers saved as an object and write them back as a list", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		legacy := `{"collections": [], "requests": [{"id": "1", "name": "Me", "method": "GET", "url": "http://localhost",` +
+			` "headers": {"X-B": "2", "Accept": "text/plain"}}]}`
+		require.NoError(t, os.WriteFile(s.path, []byte(legacy), 0600))
+
+		s2, err := New()
+		require.NoError(t, err)
+
+		req := s2.ListRequests()[0]
+		assert.Equal(t, Headers{{Key: "X-B", Value: "2"}, {Key: "Accept", Value: "text/plain"}}, req.Headers)
+
+		require.NoError(t, s2.SaveRequest(req))
+
+		data, err := os.ReadFile(s.path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"key": "X-B"`)
+	})
+
+	t.Run("should keep repeated and disabled headers", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		headers := Headers{
+			{Key: "Accept", Value: "text/html"},
+			{Key: "Accept", Value: "application/json"},
+			{Key: "X-Debug", Value: "1", Disabled: true},
+		}
+		require.NoError(t, s.SaveRequest(&Request{Name: "Me", Method: "GET", URL: "http://localhost", Headers: headers}))
+
+		s2, err := New()
+		require.NoError(t, err)
+
+		assert.Equal(t, headers, s2.ListRequests()[0].Headers)
+		assert.Equal(t, "text/html", headers.Get("accept"))
+		assert.Empty(t, headers.Get("X-Debug"))
+	})
+}
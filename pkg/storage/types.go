@@ -7,42 +7,187 @@ import (
 )
 
 var (
-	ErrCollectionNotFound = errors.New("collection not found")
-	ErrCollectionNotEmpty = errors.New("collection is not empty")
-	ErrRequestNotFound    = errors.New("request not found")
-	ErrEmptyURL           = errors.New("request URL is empty")
-	ErrEmptyName          = errors.New("request name is empty")
+	ErrCollectionNotFound  = errors.New("collection not found")
+	ErrCollectionNotEmpty  = errors.New("collection is not empty")
+	ErrRequestNotFound     = errors.New("request not found")
+	ErrAmbiguousRequest    = errors.New("request name is ambiguous")
+	ErrEnvironmentNotFound = errors.New("environment not found")
+	ErrEmptyURL            = errors.New("request URL is empty")
+	ErrEmptyName           = errors.New("request name is empty")
+	ErrUnknownLayout       = errors.New("unknown storage layout")
+	ErrWorkspaceExists     = errors.New("workspace already exists")
 )
 
-var requestsFile = "requests.json"
+var (
+	requestsFile     = "requests.json"
+	historyFile      = "history.json"
+	configFile       = "config.json"
+	collectionsDir   = "collections"
+	uncategorizedDir = "requests"
+	environmentsDir  = "environments"
+	collectionFile   = "collection.yaml"
+	workspaceDir     = ".gostman"
+	workspacesFile   = "workspaces.json"
+	maxHistory       = 100
+	shortIDLength    = 8
+)
+
+type Layout string
+
+const (
+	LayoutJSON      Layout = "json"
+	LayoutDirectory Layout = "directory"
+)
+
+const (
+	ProtocolHTTP      = "http"
+	ProtocolWebSocket = "websocket"
+	ProtocolGRPC      = "grpc"
+)
+
+const (
+	FormFieldText = "text"
+	FormFieldFile = "file"
+)
+
+type Config struct {
+	Layout      Layout `json:"layout,omitempty"`
+	MaxBodySize int64  `json:"max_body_size,omitempty"`
+}
+
+type Workspace struct {
+	Name   string
+	Dir    string
+	Global bool
+}
+
+type Scripts struct {
+	PreRequest   string `json:"pre_request,omitempty"   yaml:"pre_request,omitempty"`
+	PostResponse string `json:"post_response,omitempty" yaml:"post_response,omitempty"`
+}
+
+type RetryPolicy struct {
+	Attempts   int      `json:"attempts,omitempty"    yaml:"attempts,omitempty"`
+	Statuses   []int    `json:"statuses,omitempty"    yaml:"statuses,omitempty"`
+	Errors     []string `json:"errors,omitempty"      yaml:"errors,omitempty"`
+	Backoff    string   `json:"backoff,omitempty"     yaml:"backoff,omitempty"`
+	MaxBackoff string   `json:"max_backoff,omitempty" yaml:"max_backoff,omitempty"`
+}
+
+type Connection struct {
+	HTTPVersion       string `json:"http_version,omitempty"        yaml:"http_version,omitempty"`
+	DisableKeepAlives bool   `json:"disable_keep_alives,omitempty" yaml:"disable_keep_alives,omitempty"`
+	MaxIdleConns      int    `json:"max_idle_conns,omitempty"      yaml:"max_idle_conns,omitempty"`
+}
 
 type Collection struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        string            `json:"id"                  yaml:"id"`
+	Name      string            `json:"name"                yaml:"name"`
+	Variables map[string]string `json:"variables,omitempty" yaml:"variables,omitempty"`
+	Scripts   Scripts           `json:"scripts,omitzero"    yaml:"scripts,omitempty"`
+	Retry     RetryPolicy       `json:"retry,omitzero"      yaml:"retry,omitempty"`
+	CreatedAt time.Time         `json:"created_at"          yaml:"-"`
+	UpdatedAt time.Time         `json:"updated_at"          yaml:"-"`
+}
+
+type Environment struct {
+	ID        string            `json:"id"                  yaml:"id"`
+	Name      string            `json:"name"                yaml:"name"`
+	Variables map[string]string `json:"variables,omitempty" yaml:"variables,omitempty"`
+	CreatedAt time.Time         `json:"created_at"          yaml:"-"`
+	UpdatedAt time.Time         `json:"updated_at"          yaml:"-"`
+}
+
+type Assertion struct {
+	Type     string `json:"type"               yaml:"type"`
+	Target   string `json:"target,omitempty"   yaml:"target,omitempty"`
+	Operator string `json:"operator,omitempty" yaml:"operator,omitempty"`
+	Value    string `json:"value,omitempty"    yaml:"value,omitempty"`
+}
+
+type Extraction struct {
+	Source     string `json:"source"          yaml:"source"`
+	Expression string `json:"expression"      yaml:"expression"`
+	Variable   string `json:"variable"        yaml:"variable"`
+	Scope      string `json:"scope,omitempty" yaml:"scope,omitempty"`
+}
+
+type Message struct {
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	Type string `json:"type"           yaml:"type"`
+	Body string `json:"body"           yaml:"body"`
+}
+
+type Header struct {
+	Key      string `json:"key"                yaml:"key"`
+	Value    string `json:"value"              yaml:"value"`
+	Disabled bool   `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+}
+
+type Headers []Header
+
+type FormField struct {
+	Key         string `json:"key"                    yaml:"key"`
+	Value       string `json:"value,omitempty"        yaml:"value,omitempty"`
+	Type        string `json:"type,omitempty"         yaml:"type,omitempty"`
+	ContentType string `json:"content_type,omitempty" yaml:"content_type,omitempty"`
+	Filename    string `json:"filename,omitempty"     yaml:"filename,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"     yaml:"disabled,omitempty"`
 }
 
 type Request struct {
-	ID           string            `json:"id"`
-	CollectionID string            `json:"collection_id,omitempty"`
-	Name         string            `json:"name"`
-	Method       string            `json:"method"`
-	URL          string            `json:"url"`
-	Headers      map[string]string `json:"headers,omitempty"`
-	Body         string            `json:"body,omitempty"`
-	BodyType     string            `json:"body_type,omitempty"`
-	CreatedAt    time.Time         `json:"created_at"`
-	UpdatedAt    time.Time         `json:"updated_at"`
+	ID                   string       `json:"id"                      yaml:"id"`
+	CollectionID         string       `json:"collection_id,omitempty" yaml:"-"`
+	Name                 string       `json:"name"                    yaml:"name"`
+	Protocol             string       `json:"protocol,omitempty"      yaml:"protocol,omitempty"`
+	Method               string       `json:"method"                  yaml:"method"`
+	URL                  string       `json:"url"                     yaml:"url"`
+	Headers              Headers      `json:"headers,omitempty"       yaml:"headers,omitempty"`
+	Body                 string       `json:"body,omitempty"          yaml:"body,omitempty"`
+	BodyType             string       `json:"body_type,omitempty"     yaml:"body_type,omitempty"`
+	Form                 []FormField  `json:"form,omitempty"          yaml:"form,omitempty"`
+	Compression          string       `json:"compression,omitempty"   yaml:"compression,omitempty"`
+	DisableDecompression bool         `json:"disable_decompression,omitempty" yaml:"disable_decompression,omitempty"`
+	Assertions           []Assertion  `json:"assertions,omitempty"    yaml:"assertions,omitempty"`
+	Extractions          []Extraction `json:"extractions,omitempty"   yaml:"extractions,omitempty"`
+	Scripts              Scripts      `json:"scripts,omitzero"        yaml:"scripts,omitempty"`
+	Retry                RetryPolicy  `json:"retry,omitzero"          yaml:"retry,omitempty"`
+	Connection           Connection   `json:"connection,omitzero"     yaml:"connection,omitempty"`
+	Messages             []Message    `json:"messages,omitempty"      yaml:"messages,omitempty"`
+	RPC                  string       `json:"rpc,omitempty"           yaml:"rpc,omitempty"`
+	ProtoFiles           []string     `json:"proto_files,omitempty"   yaml:"proto_files,omitempty"`
+	CreatedAt            time.Time    `json:"created_at"              yaml:"-"`
+	UpdatedAt            time.Time    `json:"updated_at"              yaml:"-"`
+}
+
+type HistoryResponse struct {
+	StatusCode int                 `json:"status_code"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	Body       string              `json:"body,omitempty"`
+	TimeTaken  int64               `json:"time_taken"`
+}
+
+type HistoryEntry struct {
+	ID       string           `json:"id"`
+	Request  *Request         `json:"request"`
+	Response *HistoryResponse `json:"response,omitempty"`
+	Error    string           `json:"error,omitempty"`
+	SentAt   time.Time        `json:"sent_at"`
 }
 
 type Store struct {
-	Collections []*Collection `json:"collections"`
-	Requests    []*Request    `json:"requests"`
+	Collections  []*Collection  `json:"collections"`
+	Requests     []*Request     `json:"requests"`
+	Environments []*Environment `json:"environments,omitempty"`
 }
 
 type Storage struct {
-	mutex sync.RWMutex
-	path  string
-	store *Store
+	mutex       sync.RWMutex
+	dir         string
+	path        string
+	historyPath string
+	layout      Layout
+	maxBodySize int64
+	store       *Store
+	history     []*HistoryEntry
 }
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+func FindWorkspace(start string) (string, bool) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		candidate := filepath.Join(dir, workspaceDir)
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+func InitWorkspace(root string, layout Layout) (string, error) {
+	layout, err := ParseLayout(string(layout))
+	if err != nil {
+		return "", err
+	}
+
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(root, workspaceDir)
+	if _, err := os.Stat(dir); err == nil {
+		return "", ErrWorkspaceExists
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	if err := writeJSON(filepath.Join(dir, configFile), &Config{Layout: layout}); err != nil {
+		return "", err
+	}
+
+	if err := writeGitignore(dir); err != nil {
+		return "", err
+	}
+
+	if err := registerWorkspace(dir); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+func ListWorkspaces() ([]Workspace, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dirs, err := loadWorkspaces(configDir)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces := []Workspace{workspaceFor(configDir)}
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+
+		workspaces = append(workspaces, workspaceFor(dir))
+	}
+
+	return workspaces, nil
+}
+
+func (s *Storage) Workspace() Workspace {
+	return workspaceFor(s.dir)
+}
+
+func workspaceFor(dir string) Workspace {
+	if filepath.Base(dir) != workspaceDir {
+		return Workspace{Name: "global", Dir: dir, Global: true}
+	}
+
+	return Workspace{Name: filepath.Base(filepath.Dir(dir)), Dir: dir}
+}
+
+func loadWorkspaces(configDir string) ([]string, error) {
+	var dirs []string
+
+	data, err := os.ReadFile(filepath.Join(configDir, workspacesFile))
+	if os.IsNotExist(err) {
+		return dirs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &dirs); err != nil {
+		return nil, err
+	}
+
+	return dirs, nil
+}
+
+func registerWorkspace(dir string) error {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return err
+	}
+
+	dirs, err := loadWorkspaces(configDir)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range dirs {
+		if d == dir {
+			return nil
+		}
+	}
+
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return err
+	}
+
+	return writeJSON(filepath.Join(configDir, workspacesFile), append(dirs, dir))
+}
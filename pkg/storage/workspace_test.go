@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindWorkspace(t *testing.T) {
+	t.Run("should find a workspace in a parent directory", func(t *testing.T) {
+		root := t.TempDir()
+		nested := filepath.Join(root, "a", "b")
+		require.NoError(t, os.MkdirAll(nested, 0755))
+		require.NoError(t, os.Mkdir(filepath.Join(root, workspaceDir), 0755))
+
+		dir, ok := FindWorkspace(nested)
+
+		assert.True(t, ok)
+		assert.Equal(t, filepath.Join(root, workspaceDir), dir)
+	})
+
+	t.Run("should ignore a file named like a workspace", func(t *testing.T) {
+		root := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(root, workspaceDir), nil, 0644))
+
+		_, ok := FindWorkspace(root)
+
+		assert.False(t, ok)
+	})
+}
+
+func TestInitWorkspace(t *testing.T) {
+	t.Run("should create and register a workspace", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		root := t.TempDir()
+
+		dir, err := InitWorkspace(root, LayoutDirectory)
+		require.NoError(t, err)
+
+		assert.Equal(t, filepath.Join(root, workspaceDir), dir)
+		assert.FileExists(t, filepath.Join(dir, configFile))
+		assert.FileExists(t, filepath.Join(dir, ".gitignore"))
+
+		workspaces, err := ListWorkspaces()
+		require.NoError(t, err)
+		require.Len(t, workspaces, 2)
+		assert.True(t, workspaces[0].Global)
+		assert.Equal(t, filepath.Base(root), workspaces[1].Name)
+		assert.Equal(t, dir, workspaces[1].Dir)
+	})
+
+	t.Run("should fail when the workspace already exists", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		root := t.TempDir()
+
+		_, err := InitWorkspace(root, LayoutJSON)
+		require.NoError(t, err)
+
+		_, err = InitWorkspace(root, LayoutJSON)
+		assert.ErrorIs(t, err, ErrWorkspaceExists)
+	})
+}
+
+func TestNewWorkspace(t *testing.T) {
+	t.Run("should use the workspace of the current directory", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		root := t.TempDir()
+
+		dir, err := InitWorkspace(root, LayoutDirectory)
+		require.NoError(t, err)
+
+		nested := filepath.Join(root, "src")
+		require.NoError(t, os.Mkdir(nested, 0755))
+		t.Chdir(nested)
+
+		s, err := New()
+		require.NoError(t, err)
+
+		assert.Equal(t, dir, s.Workspace().Dir)
+		assert.False(t, s.Workspace().Global)
+		assert.Equal(t, LayoutDirectory, s.Layout())
+
+		require.NoError(t, s.SaveRequest(&Request{Name: "Ping", Method: "GET", URL: "http://localhost"}))
+		assert.FileExists(t, filepath.Join(dir, uncategorizedDir, entryName("Ping", s.ListRequests()[0].ID)+".yaml"))
+	})
+
+	t.Run("should fall back to the global config directory", func(t *testing.T) {
+		s := setupTestStorage(t)
+
+		assert.True(t, s.Workspace().Global)
+		assert.Equal(t, "global", s.Workspace().Name)
+	})
+
+	t.Run("should skip removed workspaces", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		root := t.TempDir()
+
+		dir, err := InitWorkspace(root, LayoutJSON)
+		require.NoError(t, err)
+		require.NoError(t, os.RemoveAll(dir))
+
+		workspaces, err := ListWorkspaces()
+		require.NoError(t, err)
+		assert.Len(t, workspaces, 1)
+	})
+}
@@ -15,10 +15,23 @@ func Gostman() {
 		os.Exit(1)
 	}
 
+	if err := run(New(s)); err != nil {
+		panic(err)
+	}
+}
+
+func Open(s *storage.Storage, collectionID string) error {
 	m := New(s)
+	if err := m.requestMenu.ShowCollection(collectionID); err != nil {
+		return err
+	}
+
+	return run(m)
+}
+
+func run(m Model) error {
 	p := tea.NewProgram(m, tea.WithAltScreen())
 
-	if _, err := p.Run(); err != nil {
-		panic(err)
-	}
+	_, err := p.Run()
+	return err
 }
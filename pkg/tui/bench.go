@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/Yalaouf/gostman/pkg/bench"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const benchTickInterval = 200 * time.Millisecond
+
+type benchTickMsg struct{}
+
+type benchDoneMsg struct {
+	report *bench.Report
+	err    error
+}
+
+func (m Model) startBench() (Model, tea.Cmd) {
+	opts, err := m.benchPopup.Options()
+	if err != nil {
+		m.benchPopup.SetError(err.Error())
+		return m, nil
+	}
+
+	model := m.buildRequestModel()
+	if model.URL == "" {
+		m.benchPopup.SetError("URL is empty")
+		return m, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sent := &atomic.Int64{}
+
+	m.benchCancel = cancel
+	m.benchSent = sent
+	m.benchPopup.Start()
+
+	run := func() tea.Msg {
+		defer cancel()
+
+		report, err := bench.Run(ctx, model, opts, func(bench.Sample) {
+			sent.Add(1)
+		})
+
+		return benchDoneMsg{report: report, err: err}
+	}
+
+	return m, tea.Batch(run, benchTick())
+}
+
+func (m Model) stopBench() {
+	if m.benchCancel != nil {
+		m.benchCancel()
+	}
+}
+
+func benchTick() tea.Cmd {
+	return tea.Tick(benchTickInterval, func(time.Time) tea.Msg {
+		return benchTickMsg{}
+	})
+}
+
+func (m Model) handleBenchTick() (Model, tea.Cmd) {
+	if !m.benchPopup.Running() {
+		return m, nil
+	}
+
+	m.benchPopup.SetProgress(m.benchSent.Load())
+	return m, benchTick()
+}
+
+func (m Model) handleBenchDone(msg benchDoneMsg) Model {
+	m.benchCancel = nil
+
+	if msg.err != nil {
+		m.benchPopup.SetError(msg.err.Error())
+		return m
+	}
+
+	m.benchPopup.SetReport(msg.report)
+	return m
+}
@@ -0,0 +1,152 @@
+package benchpopup
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yalaouf/gostman/pkg/bench"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	fieldRequests = iota
+	fieldDuration
+	fieldConcurrency
+	fieldRPS
+)
+
+var labels = []string{"Requests", "Duration", "Concurrency", "Target RPS"}
+
+type Model struct {
+	visible bool
+	running bool
+	focus   int
+	inputs  []textinput.Model
+	sent    int64
+	report  string
+	err     string
+}
+
+func New() Model {
+	placeholders := []string{
+		strconv.Itoa(bench.DefaultRequests),
+		"e.g. 30s",
+		strconv.Itoa(bench.DefaultConcurrency),
+		"unlimited",
+	}
+
+	inputs := make([]textinput.Model, len(labels))
+	for i := range inputs {
+		ti := textinput.New()
+		ti.Placeholder = placeholders[i]
+		ti.CharLimit = 16
+		ti.Width = 16
+		inputs[i] = ti
+	}
+
+	return Model{inputs: inputs}
+}
+
+func (m *Model) Show() tea.Cmd {
+	m.visible = true
+	m.running = false
+	m.report = ""
+	m.err = ""
+	return m.setFocus(fieldRequests)
+}
+
+func (m *Model) Hide() {
+	m.visible = false
+	m.running = false
+	for i := range m.inputs {
+		m.inputs[i].Blur()
+	}
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+func (m Model) Running() bool {
+	return m.running
+}
+
+func (m *Model) NextField() tea.Cmd {
+	return m.setFocus((m.focus + 1) % len(m.inputs))
+}
+
+func (m *Model) PrevField() tea.Cmd {
+	return m.setFocus((m.focus + len(m.inputs) - 1) % len(m.inputs))
+}
+
+func (m *Model) setFocus(field int) tea.Cmd {
+	m.inputs[m.focus].Blur()
+	m.focus = field
+	return m.inputs[field].Focus()
+}
+
+func (m Model) Options() (bench.Options, error) {
+	var opts bench.Options
+	var err error
+
+	value := func(field int) string {
+		return strings.TrimSpace(m.inputs[field].Value())
+	}
+
+	if v := value(fieldRequests); v != "" {
+		if opts.Requests, err = strconv.Atoi(v); err != nil {
+			return opts, fmt.Errorf("%w: requests must be a number", bench.ErrInvalidOptions)
+		}
+	}
+
+	if v := value(fieldDuration); v != "" {
+		if opts.Duration, err = time.ParseDuration(v); err != nil {
+			return opts, fmt.Errorf("%w: duration must look like 30s or 1m", bench.ErrInvalidOptions)
+		}
+	}
+
+	if v := value(fieldConcurrency); v != "" {
+		if opts.Concurrency, err = strconv.Atoi(v); err != nil {
+			return opts, fmt.Errorf("%w: concurrency must be a number", bench.ErrInvalidOptions)
+		}
+	}
+
+	if v := value(fieldRPS); v != "" {
+		if opts.RPS, err = strconv.ParseFloat(v, 64); err != nil {
+			return opts, fmt.Errorf("%w: target RPS must be a number", bench.ErrInvalidOptions)
+		}
+	}
+
+	return opts, opts.Validate()
+}
+
+func (m *Model) Start() {
+	m.running = true
+	m.sent = 0
+	m.report = ""
+	m.err = ""
+}
+
+func (m *Model) SetProgress(sent int64) {
+	m.sent = sent
+}
+
+func (m *Model) SetReport(report *bench.Report) {
+	m.running = false
+
+	var b strings.Builder
+	if err := bench.Format(&b, report); err != nil {
+		m.err = err.Error()
+		return
+	}
+
+	m.report = strings.TrimRight(b.String(), "\n")
+}
+
+func (m *Model) SetError(err string) {
+	m.running = false
+	m.err = err
+}
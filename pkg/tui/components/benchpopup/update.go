@@ -0,0 +1,13 @@
+package benchpopup
+
+import tea "github.com/charmbracelet/bubbletea"
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	if m.running {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.inputs[m.focus], cmd = m.inputs[m.focus].Update(msg)
+	return cmd
+}
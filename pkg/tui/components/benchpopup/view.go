@@ -0,0 +1,53 @@
+package benchpopup
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m Model) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(style.ColorOrange)
+	hintStyle := style.Unselected
+
+	title := titleStyle.Render("Load Test")
+
+	var fields []string
+	for i, label := range labels {
+		labelStyle := hintStyle
+		if i == m.focus && !m.running {
+			labelStyle = style.Selected
+		}
+		fields = append(fields, labelStyle.Render(fmt.Sprintf("%-12s", label))+" "+m.inputs[i].View())
+	}
+
+	content := title + "\n\n" + strings.Join(fields, "\n")
+
+	switch {
+	case m.running:
+		content += "\n\n" + style.Selected.Render(fmt.Sprintf("Running... %d requests sent", m.sent))
+	case m.report != "":
+		content += "\n\n" + style.TextInput.Render(m.report)
+	}
+
+	if m.err != "" {
+		content += "\n\n" + style.Error.Render(m.err)
+	}
+
+	hint := hintStyle.Render("[enter]run [tab]next field [esc]close")
+	if m.running {
+		hint = hintStyle.Render("[esc]stop")
+	}
+
+	content += "\n\n" + hint
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(style.ColorPurple).
+		Padding(1, 3).
+		Render(content)
+
+	return box
+}
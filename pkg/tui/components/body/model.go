@@ -1,6 +1,14 @@
 package body
 
 import (
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/graphql"
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/Yalaouf/gostman/pkg/tui/components/form"
 	"github.com/Yalaouf/gostman/pkg/tui/style"
 	"github.com/Yalaouf/gostman/pkg/tui/utils"
 	"github.com/charmbracelet/bubbles/textarea"
@@ -9,62 +17,165 @@ import (
 )
 
 type Model struct {
-	Editor   textarea.Model
-	Viewport viewport.Model
-	BodyType Type
-	Focused  bool
-	EditMode bool
-	height   int
+	Editor      textarea.Model
+	Variables   textarea.Model
+	Viewport    viewport.Model
+	Form        form.Model
+	BodyType    Type
+	Pane        Pane
+	Operation   string
+	Compression request.Compression
+	Focused     bool
+	EditMode    bool
+	height      int
+	schema      *graphql.Schema
+	completion  graphql.Completion
 }
 
 func New() Model {
+	ta := newEditor(`{"key": "value"}`)
+	vars := newEditor(`{"id": "1"}`)
+
+	vp := viewport.New(40, 4)
+
+	return Model{
+		Editor:    ta,
+		Variables: vars,
+		Viewport:  vp,
+		Form:      form.New(),
+		BodyType:  TypeNone,
+		Focused:   false,
+		EditMode:  false,
+	}
+}
+
+func newEditor(placeholder string) textarea.Model {
 	ta := textarea.New()
-	ta.Placeholder = `{"key": "value"}`
+	ta.Placeholder = placeholder
 	ta.ShowLineNumbers = false
 	ta.CharLimit = 0
 	ta.SetWidth(40)
 	ta.SetHeight(4)
 	ta.FocusedStyle.CursorLine = style.TextArea
 	ta.BlurredStyle.CursorLine = style.TextArea
-
-	vp := viewport.New(40, 4)
-
-	return Model{
-		Editor:   ta,
-		Viewport: vp,
-		BodyType: TypeNone,
-		Focused:  false,
-		EditMode: false,
-	}
+	return ta
 }
 
 func (m Model) Value() string {
-	if m.BodyType == TypeNone {
+	switch m.BodyType {
+	case TypeNone, TypeFormData, TypeURLEncoded:
 		return ""
+	case TypeGraphQL:
+		if m.Editor.Value() == "" && m.Variables.Value() == "" {
+			return ""
+		}
+		return request.GraphQL{
+			Query:         m.Editor.Value(),
+			Variables:     m.Variables.Value(),
+			OperationName: m.Operation,
+		}.String()
 	}
 	return m.Editor.Value()
 }
 
 func (m *Model) SetValue(value string) {
-	m.Editor.SetValue(value)
+	if m.BodyType == TypeGraphQL {
+		g := request.ParseGraphQL(value)
+		m.Editor.SetValue(g.Query)
+		m.Variables.SetValue(g.Variables)
+		m.Operation = g.OperationName
+	} else {
+		m.Editor.SetValue(value)
+	}
 	m.updateViewportContent()
 }
 
+func (m *Model) SetForm(fields []storage.FormField) {
+	if len(fields) == 0 && m.isForm() {
+		fields = parseForm(m.Editor.Value())
+	}
+
+	m.Form.SetFields(fields)
+}
+
+func (m Model) FormFields() []storage.FormField {
+	if !m.isForm() {
+		return nil
+	}
+
+	return m.Form.FormFields()
+}
+
+func parseForm(body string) []storage.FormField {
+	parsed, err := request.ParseFormFields(body)
+	if err != nil {
+		return nil
+	}
+
+	fields := make([]storage.FormField, 0, len(parsed))
+	for _, f := range parsed {
+		fields = append(fields, storage.FormField{Key: f.Key, Value: f.Value})
+	}
+
+	return fields
+}
+
+func (m Model) isForm() bool {
+	return m.BodyType == TypeFormData || m.BodyType == TypeURLEncoded
+}
+
 func (m *Model) SetType(t Type) {
 	m.BodyType = t
+	m.typeChanged()
+}
+
+func (m *Model) typeChanged() {
+	m.Form.AllowFiles = m.BodyType == TypeFormData
+	if m.isForm() && len(m.Form.Fields) == 0 {
+		m.Form.SetFields(parseForm(m.Editor.Value()))
+	}
+
+	m.Editor.Placeholder = `{"key": "value"}`
+	switch m.BodyType {
+	case TypeText, TypeCustom:
+		m.Editor.Placeholder = "Raw body"
+	case TypeXML:
+		m.Editor.Placeholder = `<?xml version="1.0"?>`
+	case TypeHTML:
+		m.Editor.Placeholder = "<html></html>"
+	case TypeBinary:
+		m.Editor.Placeholder = "/path/to/file"
+	}
+
+	m.resize()
+	m.updateViewportContent()
 }
 
 func (m *Model) SetSize(width, height int) {
 	m.height = height
 	m.Editor.SetWidth(width - 6)
-	m.Editor.SetHeight(height - 6)
+	m.Variables.SetWidth(width - 6)
 	m.Viewport.Width = width - 6
-	m.Viewport.Height = height - 6
+	m.resize()
+}
+
+func (m *Model) resize() {
+	height := m.height - 6
+	if m.BodyType == TypeGraphQL {
+		height--
+	}
+	height = max(height, 1)
+
+	m.Editor.SetHeight(height)
+	m.Variables.SetHeight(height)
+	m.Viewport.Height = height
+	m.Form.SetSize(m.Viewport.Width, height)
 }
 
 func (m *Model) Focus() tea.Cmd {
 	m.Focused = true
 	m.EditMode = false
+	m.Form.Focus()
 	return nil
 }
 
@@ -72,37 +183,188 @@ func (m *Model) Blur() {
 	m.Focused = false
 	m.EditMode = false
 	m.Editor.Blur()
+	m.Variables.Blur()
+	m.Form.Blur()
 }
 
 func (m Model) IsFocused() bool {
-	return m.EditMode && m.Editor.Focused()
+	if m.isForm() {
+		return m.Form.IsFocused()
+	}
+	return m.EditMode && (m.Editor.Focused() || m.Variables.Focused())
 }
 
 func (m *Model) NextType() {
 	idx := int(m.BodyType)
 	idx = (idx + 1) % len(AllTypes)
 	m.BodyType = AllTypes[idx]
+	m.typeChanged()
 }
 
 func (m *Model) EnterEditMode() tea.Cmd {
 	if m.BodyType == TypeNone {
 		return nil
 	}
+	if m.isForm() {
+		return m.Form.EnterEditMode()
+	}
 	m.EditMode = true
+	if m.BodyType == TypeGraphQL && m.Pane == PaneVariables {
+		return m.Variables.Focus()
+	}
+	m.updateCompletion()
 	return m.Editor.Focus()
 }
 
 func (m *Model) ExitEditMode() {
 	m.EditMode = false
 	m.Editor.Blur()
+	m.Variables.Blur()
+	m.Form.ExitEditMode()
+	m.completion = graphql.Completion{}
 	m.updateViewportContent()
 }
 
+func (m *Model) NextPane() {
+	if m.Pane == PaneQuery {
+		m.Pane = PaneVariables
+	} else {
+		m.Pane = PaneQuery
+	}
+}
+
+func (m *Model) NextOperation() {
+	options := append([]string{""}, graphql.Operations(m.Editor.Value())...)
+
+	idx := 0
+	for i, op := range options {
+		if op == m.Operation {
+			idx = (i + 1) % len(options)
+			break
+		}
+	}
+
+	m.Operation = options[idx]
+	m.updateViewportContent()
+}
+
+func (m *Model) NextCompression() {
+	idx := slices.Index(request.Compressions, m.Compression)
+	m.Compression = request.Compressions[(idx+1)%len(request.Compressions)]
+}
+
+func (m *Model) SetSchema(schema *graphql.Schema) {
+	m.schema = schema
+}
+
+func (m Model) Schema() *graphql.Schema {
+	return m.schema
+}
+
+func (m *Model) updateCompletion() {
+	m.completion = graphql.Completion{}
+	if m.schema == nil || m.BodyType != TypeGraphQL || m.Pane != PaneQuery {
+		return
+	}
+
+	m.completion = m.schema.Complete(m.Editor.Value(), m.cursorOffset())
+}
+
+func (m Model) cursorOffset() int {
+	lines := strings.Split(m.Editor.Value(), "\n")
+	row := min(m.Editor.Line(), len(lines)-1)
+
+	offset := 0
+	for _, line := range lines[:row] {
+		offset += len(line) + 1
+	}
+
+	info := m.Editor.LineInfo()
+	col := []rune(lines[row])
+	return offset + len(string(col[:min(info.StartColumn+info.ColumnOffset, len(col))]))
+}
+
+func (m *Model) complete() bool {
+	if len(m.completion.Fields) == 0 {
+		return false
+	}
+
+	for range []rune(m.completion.Prefix) {
+		m.Editor, _ = m.Editor.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	m.Editor.InsertString(m.completion.Fields[0].Name)
+	m.updateCompletion()
+	return true
+}
+
 func (m *Model) updateViewportContent() {
+	if m.BodyType == TypeGraphQL {
+		m.Viewport.SetContent(m.graphQLContent())
+		return
+	}
+
 	raw := m.Editor.Value()
-	if utils.IsJSON(raw) {
+	switch {
+	case m.BodyType == TypeBinary:
+		m.Viewport.SetContent(binaryContent(raw))
+	case m.BodyType == TypeXML:
+		m.Viewport.SetContent(xmlContent(raw))
+	case m.BodyType == TypeHTML:
+		m.Viewport.SetContent(utils.HighlightCode(raw, "html"))
+	case m.BodyType == TypeText:
+		m.Viewport.SetContent(raw)
+	case utils.IsJSON(raw):
 		m.Viewport.SetContent(utils.HighlightJSON(raw))
-	} else {
+	default:
 		m.Viewport.SetContent(raw)
 	}
 }
+
+func xmlContent(raw string) string {
+	if strings.TrimSpace(raw) == "" {
+		return ""
+	}
+
+	formatted, err := request.FormatXML(raw)
+	if err != nil {
+		return raw + "\n\n" + style.Error.Render(err.Error())
+	}
+
+	return utils.HighlightCode(formatted, "xml")
+}
+
+func binaryContent(raw string) string {
+	path := strings.TrimSpace(raw)
+	if path == "" {
+		return style.Unselected.Render("No file (press enter to type a path)")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return path + "\n\n" + style.Error.Render(err.Error())
+	}
+	if info.IsDir() {
+		return path + "\n\n" + style.Error.Render("is a directory")
+	}
+
+	return path + "\n\n" + style.Unselected.Render("size: ") + utils.FormatSize(info.Size()) +
+		style.Unselected.Render(" (streamed from disk)")
+}
+
+func (m Model) graphQLContent() string {
+	operation := m.Operation
+	if operation == "" {
+		operation = "auto"
+	}
+
+	content := style.Unselected.Render("operation: ") + operation + "\n\n" + m.Editor.Value()
+
+	if vars := m.Variables.Value(); vars != "" {
+		if utils.IsJSON(vars) {
+			vars = utils.HighlightJSON(vars)
+		}
+		content += "\n\n" + style.Unselected.Render("variables:") + "\n" + vars
+	}
+
+	return content
+}
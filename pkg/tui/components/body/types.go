@@ -7,6 +7,12 @@ const (
 	TypeJSON
 	TypeFormData
 	TypeURLEncoded
+	TypeGraphQL
+	TypeText
+	TypeXML
+	TypeHTML
+	TypeCustom
+	TypeBinary
 )
 
 func (t Type) String() string {
@@ -19,6 +25,18 @@ func (t Type) String() string {
 		return "form-data"
 	case TypeURLEncoded:
 		return "x-www-form-urlencoded"
+	case TypeGraphQL:
+		return "graphql"
+	case TypeText:
+		return "text"
+	case TypeXML:
+		return "xml"
+	case TypeHTML:
+		return "html"
+	case TypeCustom:
+		return "custom"
+	case TypeBinary:
+		return "binary"
 	default:
 		return "none"
 	}
@@ -26,15 +44,48 @@ func (t Type) String() string {
 
 func (t Type) ContentType() string {
 	switch t {
-	case TypeJSON:
+	case TypeJSON, TypeGraphQL:
 		return "application/json"
 	case TypeFormData:
 		return "multipart/form-data"
 	case TypeURLEncoded:
 		return "application/x-www-form-urlencoded"
+	case TypeText:
+		return "text/plain"
+	case TypeXML:
+		return "application/xml"
+	case TypeHTML:
+		return "text/html"
+	case TypeBinary:
+		return "application/octet-stream"
 	default:
 		return ""
 	}
 }
 
-var AllTypes = []Type{TypeNone, TypeJSON, TypeFormData, TypeURLEncoded}
+var AllTypes = []Type{
+	TypeNone,
+	TypeJSON,
+	TypeFormData,
+	TypeURLEncoded,
+	TypeGraphQL,
+	TypeText,
+	TypeXML,
+	TypeHTML,
+	TypeCustom,
+	TypeBinary,
+}
+
+type Pane uint
+
+const (
+	PaneQuery Pane = iota
+	PaneVariables
+)
+
+func (p Pane) String() string {
+	if p == PaneVariables {
+		return "variables"
+	}
+	return "query"
+}
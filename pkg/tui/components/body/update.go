@@ -1,12 +1,31 @@
 package body
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"github.com/Yalaouf/gostman/pkg/tui/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 func (m *Model) Update(msg tea.Msg) tea.Cmd {
-	if m.EditMode {
+	if m.isForm() {
+		return m.Form.Update(msg)
+	}
+
+	if !m.EditMode {
+		return nil
+	}
+
+	if m.BodyType == TypeGraphQL && m.Pane == PaneVariables {
 		var cmd tea.Cmd
-		m.Editor, cmd = m.Editor.Update(msg)
+		m.Variables, cmd = m.Variables.Update(msg)
 		return cmd
 	}
-	return nil
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == types.KeyTab && m.complete() {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.Editor, cmd = m.Editor.Update(msg)
+	m.updateCompletion()
+	return cmd
 }
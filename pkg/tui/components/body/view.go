@@ -3,39 +3,136 @@ package body
 import (
 	"strings"
 
+	"github.com/Yalaouf/gostman/pkg/request"
 	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/charmbracelet/lipgloss"
 )
 
+const maxSuggestions = 6
+
 func (m Model) View(width int) string {
-	tabs := m.renderTabs()
+	title := "Body"
+	if m.Compression != request.CompressionNone {
+		title += " (" + m.Compression.String() + ")"
+	}
+
+	return m.ViewWithTitle(title, width)
+}
+
+func (m Model) ViewWithTitle(title string, width int) string {
+	tabs := m.renderTabs(width - 6)
 
 	var content string
 	if m.BodyType == TypeNone {
 		content = style.Unselected.Render("No body")
+	} else if m.EditMode && m.BodyType == TypeGraphQL && m.Pane == PaneVariables {
+		content = m.Variables.View()
 	} else if m.EditMode {
 		content = m.Editor.View()
 	} else {
 		content = m.Viewport.View()
 	}
 
-	footer := style.Unselected.Render("[tab]switch type [enter]edit mode [esc]exit edit")
+	footer := style.Unselected.Render("[tab]switch type [enter]edit mode [esc]exit edit [z]compress")
+	if m.BodyType == TypeGraphQL {
+		tabs += "\n" + m.renderPanes()
+		footer = m.graphQLFooter()
+	}
+
+	if m.BodyType == TypeCustom {
+		footer = style.Unselected.Render("[tab]switch type [enter]edit mode  set Content-Type in headers")
+	}
+
+	if m.isForm() {
+		return style.SectionBox(title, tabs+"\n"+m.Form.View(), m.Focused, width, m.height-4)
+	}
 
 	body := tabs + "\n" + content + "\n" + footer
 
-	return style.SectionBox("Body", body, m.Focused, width, m.height-4)
+	return style.SectionBox(title, body, m.Focused, width, m.height-4)
 }
 
-func (m Model) renderTabs() string {
-	var tabs []string
+func (m Model) renderTabs(width int) string {
+	tabs := make([]string, 0, len(AllTypes))
+	selected := 0
 
-	for _, t := range AllTypes {
+	for i, t := range AllTypes {
 		label := t.String()
 		if t == m.BodyType {
 			tabs = append(tabs, style.Selected.Render("["+label+"]"))
+			selected = i
 		} else {
 			tabs = append(tabs, style.Unselected.Render(" "+label+" "))
 		}
 	}
 
-	return strings.Join(tabs, " ")
+	line := strings.Join(tabs, " ")
+	if lipgloss.Width(line) <= width {
+		return line
+	}
+
+	first, last := selected, selected
+	used := lipgloss.Width(tabs[selected])
+	available := width - 4
+
+	for grew := true; grew; {
+		grew = false
+		if last+1 < len(tabs) && used+1+lipgloss.Width(tabs[last+1]) <= available {
+			last++
+			used += 1 + lipgloss.Width(tabs[last])
+			grew = true
+		}
+		if first > 0 && used+1+lipgloss.Width(tabs[first-1]) <= available {
+			first--
+			used += 1 + lipgloss.Width(tabs[first])
+			grew = true
+		}
+	}
+
+	line = strings.Join(tabs[first:last+1], " ")
+	if first > 0 {
+		line = style.Unselected.Render("< ") + line
+	}
+	if last < len(tabs)-1 {
+		line += style.Unselected.Render(" >")
+	}
+
+	return line
+}
+
+func (m Model) renderPanes() string {
+	var panes []string
+
+	for _, p := range []Pane{PaneQuery, PaneVariables} {
+		label := p.String()
+		if p == m.Pane {
+			panes = append(panes, style.Selected.Render("["+label+"]"))
+		} else {
+			panes = append(panes, style.Unselected.Render(" "+label+" "))
+		}
+	}
+
+	operation := m.Operation
+	if operation == "" {
+		operation = "auto"
+	}
+
+	return strings.Join(panes, " ") + style.Unselected.Render("  operation: ") + operation
+}
+
+func (m Model) graphQLFooter() string {
+	if !m.EditMode {
+		return style.Unselected.Render("[tab]type [n]query/variables [o]operation [i]schema [enter]edit")
+	}
+
+	if len(m.completion.Fields) == 0 {
+		return style.Unselected.Render("[esc]exit edit")
+	}
+
+	names := make([]string, 0, maxSuggestions)
+	for _, f := range m.completion.Fields[:min(len(m.completion.Fields), maxSuggestions)] {
+		names = append(names, f.Name)
+	}
+
+	return style.Unselected.Render("[tab]complete ") + style.Selected.Render(strings.Join(names, " "))
 }
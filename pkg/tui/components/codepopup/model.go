@@ -0,0 +1,97 @@
+package codepopup
+
+import (
+	"github.com/Yalaouf/gostman/pkg/codegen"
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/tui/utils"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type Model struct {
+	visible  bool
+	index    int
+	request  *request.Model
+	code     string
+	err      string
+	copied   bool
+	viewport viewport.Model
+}
+
+func New() Model {
+	return Model{
+		viewport: viewport.New(60, 15),
+	}
+}
+
+func (m *Model) Show(req *request.Model) tea.Cmd {
+	m.visible = true
+	m.request = req
+	m.copied = false
+	m.generate()
+	return nil
+}
+
+func (m *Model) Hide() {
+	m.visible = false
+	m.request = nil
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.viewport.Width = max(width-20, 20)
+	m.viewport.Height = max(height-16, 5)
+}
+
+func (m Model) Language() codegen.Language {
+	return codegen.AllLanguages[m.index]
+}
+
+func (m Model) Code() string {
+	return m.code
+}
+
+func (m *Model) SetCopied(err error) {
+	if err != nil {
+		m.err = err.Error()
+		m.copied = false
+		return
+	}
+
+	m.err = ""
+	m.copied = true
+}
+
+func (m *Model) nextLanguage() {
+	m.index = (m.index + 1) % len(codegen.AllLanguages)
+	m.generate()
+}
+
+func (m *Model) previousLanguage() {
+	m.index = (m.index - 1 + len(codegen.AllLanguages)) % len(codegen.AllLanguages)
+	m.generate()
+}
+
+func (m *Model) generate() {
+	m.copied = false
+	m.code = ""
+	m.err = ""
+
+	if m.request == nil {
+		return
+	}
+
+	code, err := codegen.Generate(m.request, m.Language())
+	if err != nil {
+		m.err = err.Error()
+		m.viewport.SetContent("")
+		return
+	}
+
+	m.code = code
+	m.viewport.SetContent(utils.HighlightCode(code, m.Language().Lexer()))
+	m.viewport.GotoTop()
+}
@@ -0,0 +1,26 @@
+package codepopup
+
+import (
+	"github.com/Yalaouf/gostman/pkg/tui/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	switch keyMsg.String() {
+	case types.KeyTab, types.KeyL, types.KeyRight:
+		m.nextLanguage()
+	case types.KeyH, types.KeyLeft:
+		m.previousLanguage()
+	case types.KeyJ, types.KeyDown:
+		m.viewport.ScrollDown(1)
+	case types.KeyK, types.KeyUp:
+		m.viewport.ScrollUp(1)
+	}
+
+	return nil
+}
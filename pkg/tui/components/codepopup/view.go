@@ -0,0 +1,55 @@
+package codepopup
+
+import (
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/codegen"
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m Model) renderTabs() string {
+	var tabs []string
+
+	for i, lang := range codegen.AllLanguages {
+		label := lang.String()
+		if i == m.index {
+			tabs = append(tabs, style.Selected.Render("["+label+"]"))
+		} else {
+			tabs = append(tabs, style.Unselected.Render(" "+label+" "))
+		}
+	}
+
+	return strings.Join(tabs, " ")
+}
+
+func (m Model) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(style.ColorOrange)
+	hintStyle := style.Unselected
+
+	title := titleStyle.Render("Generate Code")
+
+	var content string
+	if m.err != "" {
+		content = style.Error.Render("Error: " + m.err)
+	} else {
+		content = m.viewport.View()
+	}
+
+	var status string
+	if m.copied {
+		status = "\n" + style.Selected.Render("Copied to clipboard")
+	}
+
+	hint := hintStyle.Render("[tab/h/l]language [j/k]scroll [enter/y]copy [esc]close")
+
+	body := title + "\n\n" + m.renderTabs() + "\n\n" + content + status + "\n\n" + hint
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(style.ColorPurple).
+		Padding(1, 3).
+		Render(body)
+
+	return box
+}
@@ -0,0 +1,154 @@
+package composer
+
+import (
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/socket"
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const maxTemplateName = 24
+
+type Model struct {
+	Editor    textarea.Model
+	Type      socket.MessageType
+	Templates []storage.Message
+	Focused   bool
+	EditMode  bool
+	template  int
+	height    int
+	err       string
+}
+
+func New() Model {
+	ta := textarea.New()
+	ta.Placeholder = `{"type": "subscribe"}`
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	ta.SetWidth(40)
+	ta.SetHeight(4)
+	ta.FocusedStyle.CursorLine = style.TextArea
+	ta.BlurredStyle.CursorLine = style.TextArea
+
+	return Model{
+		Editor:   ta,
+		Type:     socket.TypeText,
+		template: -1,
+	}
+}
+
+func (m Model) Value() string {
+	return m.Editor.Value()
+}
+
+func (m *Model) SetTemplates(messages []storage.Message) {
+	m.Templates = messages
+	m.template = -1
+	m.err = ""
+	m.Editor.SetValue("")
+	m.Type = socket.TypeText
+
+	if len(messages) > 0 {
+		m.load(0)
+	}
+}
+
+func (m *Model) SaveTemplate() {
+	body := m.Editor.Value()
+	if strings.TrimSpace(body) == "" {
+		m.err = "Message is empty"
+		return
+	}
+
+	message := storage.Message{Name: templateName(body), Type: string(m.Type), Body: body}
+
+	if m.template >= 0 && m.template < len(m.Templates) {
+		message.Name = m.Templates[m.template].Name
+		m.Templates[m.template] = message
+	} else {
+		m.Templates = append(m.Templates, message)
+		m.template = len(m.Templates) - 1
+	}
+	m.err = ""
+}
+
+func (m *Model) DeleteTemplate() {
+	if m.template < 0 || m.template >= len(m.Templates) {
+		return
+	}
+
+	m.Templates = append(m.Templates[:m.template:m.template], m.Templates[m.template+1:]...)
+	m.template = -1
+}
+
+func (m *Model) NextTemplate() {
+	if len(m.Templates) == 0 {
+		return
+	}
+	m.load((m.template + 1) % len(m.Templates))
+}
+
+func (m *Model) NextType() {
+	for i, t := range socket.AllTypes {
+		if t == m.Type {
+			m.Type = socket.AllTypes[(i+1)%len(socket.AllTypes)]
+			return
+		}
+	}
+	m.Type = socket.TypeText
+}
+
+func (m *Model) SetError(err string) {
+	m.err = err
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.height = height
+	m.Editor.SetWidth(width - 6)
+	m.Editor.SetHeight(height - 7)
+}
+
+func (m *Model) Focus() tea.Cmd {
+	m.Focused = true
+	m.EditMode = false
+	return nil
+}
+
+func (m *Model) Blur() {
+	m.Focused = false
+	m.EditMode = false
+	m.Editor.Blur()
+}
+
+func (m Model) IsFocused() bool {
+	return m.EditMode && m.Editor.Focused()
+}
+
+func (m *Model) EnterEditMode() tea.Cmd {
+	m.EditMode = true
+	m.template = -1
+	return m.Editor.Focus()
+}
+
+func (m *Model) ExitEditMode() {
+	m.EditMode = false
+	m.Editor.Blur()
+}
+
+func (m *Model) load(i int) {
+	m.template = i
+	m.Type = socket.ParseMessageType(m.Templates[i].Type)
+	m.Editor.SetValue(m.Templates[i].Body)
+	m.err = ""
+}
+
+func templateName(body string) string {
+	name := strings.Join(strings.Fields(body), " ")
+	if len(name) > maxTemplateName {
+		name = name[:maxTemplateName-3] + "..."
+	}
+	return name
+}
@@ -0,0 +1,55 @@
+package composer
+
+import (
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/socket"
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+)
+
+func (m Model) View(width int) string {
+	content := m.renderTypes() + "\n" + m.renderTemplates() + "\n" + m.Editor.View()
+
+	if m.err != "" {
+		content += "\n" + style.Error.Render(m.err)
+	}
+
+	footer := style.Unselected.Render("[tab]type [enter]edit [n]ext [a]dd [x]del [p]ing [d]isconnect")
+
+	return style.SectionBox("Message", content+"\n"+footer, m.Focused, width, m.height-4)
+}
+
+func (m Model) renderTypes() string {
+	var tabs []string
+
+	for _, t := range socket.AllTypes {
+		label := string(t)
+		if t == socket.TypeBinary {
+			label = "binary (hex)"
+		}
+		if t == m.Type {
+			tabs = append(tabs, style.Selected.Render("["+label+"]"))
+		} else {
+			tabs = append(tabs, style.Unselected.Render(" "+label+" "))
+		}
+	}
+
+	return strings.Join(tabs, " ")
+}
+
+func (m Model) renderTemplates() string {
+	if len(m.Templates) == 0 {
+		return style.Unselected.Render("No saved messages")
+	}
+
+	var names []string
+	for i, t := range m.Templates {
+		if i == m.template {
+			names = append(names, style.Selected.Render("["+t.Name+"]"))
+		} else {
+			names = append(names, style.Unselected.Render(t.Name))
+		}
+	}
+
+	return style.Unselected.Render("saved: ") + strings.Join(names, style.Unselected.Render(" · "))
+}
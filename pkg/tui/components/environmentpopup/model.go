@@ -0,0 +1,48 @@
+package environmentpopup
+
+import (
+	"github.com/Yalaouf/gostman/pkg/storage"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type Model struct {
+	visible      bool
+	index        int
+	current      string
+	environments []*storage.Environment
+}
+
+func New() Model {
+	return Model{}
+}
+
+func (m *Model) Show(environments []*storage.Environment, current string) tea.Cmd {
+	m.visible = true
+	m.current = current
+	m.environments = environments
+	m.index = 0
+
+	for i, env := range environments {
+		if env.ID == current {
+			m.index = i + 1
+		}
+	}
+
+	return nil
+}
+
+func (m *Model) Hide() {
+	m.visible = false
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+func (m Model) Selected() string {
+	if m.index == 0 || m.index > len(m.environments) {
+		return ""
+	}
+
+	return m.environments[m.index-1].ID
+}
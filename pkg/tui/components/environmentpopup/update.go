@@ -0,0 +1,26 @@
+package environmentpopup
+
+import (
+	"github.com/Yalaouf/gostman/pkg/tui/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	switch keyMsg.String() {
+	case types.KeyJ, types.KeyDown:
+		if m.index < len(m.environments) {
+			m.index++
+		}
+	case types.KeyK, types.KeyUp:
+		if m.index > 0 {
+			m.index--
+		}
+	}
+
+	return nil
+}
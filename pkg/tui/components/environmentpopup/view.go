@@ -0,0 +1,52 @@
+package environmentpopup
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m Model) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(style.ColorOrange)
+	hintStyle := style.Unselected
+
+	title := titleStyle.Render("Environments")
+
+	lines := []string{m.renderLine(0, "No environment", m.current == "")}
+	for i, env := range m.environments {
+		label := fmt.Sprintf("%s (%d)", env.Name, len(env.Variables))
+		lines = append(lines, m.renderLine(i+1, label, env.ID == m.current))
+	}
+
+	var empty string
+	if len(m.environments) == 0 {
+		empty = "\n\n" + hintStyle.Render("Create one with: gostman env NAME KEY=VALUE")
+	}
+
+	hint := hintStyle.Render("[enter]select [j/k]navigate [esc]close")
+
+	content := title + "\n\n" + strings.Join(lines, "\n") + empty + "\n\n" + hint
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(style.ColorPurple).
+		Padding(1, 3).
+		Width(50).
+		Render(content)
+
+	return box
+}
+
+func (m Model) renderLine(index int, label string, active bool) string {
+	if active {
+		label += " (active)"
+	}
+
+	if index == m.index {
+		return style.Selected.Render("▸ " + label)
+	}
+
+	return style.Unselected.Render("  " + label)
+}
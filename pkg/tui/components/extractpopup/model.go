@@ -0,0 +1,73 @@
+package extractpopup
+
+import (
+	"github.com/Yalaouf/gostman/pkg/extraction"
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type Model struct {
+	visible bool
+	path    string
+	value   string
+	scope   string
+	input   textinput.Model
+	err     string
+}
+
+func New() Model {
+	ti := textinput.New()
+	ti.Placeholder = "Variable name"
+	ti.CharLimit = 64
+	ti.Width = 30
+
+	return Model{
+		input: ti,
+		scope: extraction.ScopeEnvironment,
+	}
+}
+
+func (m *Model) Show(path, value string) tea.Cmd {
+	m.visible = true
+	m.path = path
+	m.value = value
+	m.err = ""
+	m.input.SetValue("")
+	m.input.Focus()
+	return textinput.Blink
+}
+
+func (m *Model) Hide() {
+	m.visible = false
+	m.input.Blur()
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+func (m Model) Value() string {
+	return m.value
+}
+
+func (m Model) Extraction() storage.Extraction {
+	return storage.Extraction{
+		Source:     extraction.SourceJSON,
+		Expression: m.path,
+		Variable:   m.input.Value(),
+		Scope:      m.scope,
+	}
+}
+
+func (m *Model) ToggleScope() {
+	if m.scope == extraction.ScopeEnvironment {
+		m.scope = extraction.ScopeCollection
+	} else {
+		m.scope = extraction.ScopeEnvironment
+	}
+}
+
+func (m *Model) SetError(err string) {
+	m.err = err
+}
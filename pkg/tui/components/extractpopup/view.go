@@ -0,0 +1,39 @@
+package extractpopup
+
+import (
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m Model) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(style.ColorOrange)
+	hintStyle := style.Unselected
+
+	title := titleStyle.Render("Extract to Variable")
+
+	value := m.value
+	if len(value) > 40 {
+		value = value[:37] + "..."
+	}
+
+	details := hintStyle.Render("Path:  ") + style.TextInput.Render(m.path) + "\n" +
+		hintStyle.Render("Value: ") + style.TextInput.Render(value) + "\n" +
+		hintStyle.Render("Scope: ") + style.Selected.Render(m.scope)
+
+	var errView string
+	if m.err != "" {
+		errView = "\n" + style.Error.Render(m.err)
+	}
+
+	hint := hintStyle.Render("[enter]save [tab]scope [esc]cancel")
+
+	content := title + "\n\n" + details + "\n\n" + m.input.View() + errView + "\n\n" + hint
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(style.ColorPurple).
+		Padding(1, 3).
+		Render(content)
+
+	return box
+}
@@ -0,0 +1,228 @@
+package form
+
+import (
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	inputKey = iota
+	inputValue
+	inputContentType
+	inputFilename
+)
+
+type Field struct {
+	Key         textinput.Model
+	Value       textinput.Model
+	ContentType textinput.Model
+	Filename    textinput.Model
+	File        bool
+	Enabled     bool
+}
+
+type Model struct {
+	Fields     []Field
+	AllowFiles bool
+	cursor     int
+	fieldFocus int
+	Focused    bool
+	EditMode   bool
+	viewport   viewport.Model
+}
+
+func newTextInput(placeholder string) textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	return ti
+}
+
+func newField(f storage.FormField) Field {
+	k := newTextInput("Key")
+	k.SetValue(f.Key)
+
+	v := newTextInput("Value")
+	if f.IsFile() {
+		v.Placeholder = "Path"
+	}
+	v.SetValue(f.Value)
+
+	ct := newTextInput("Content type")
+	ct.SetValue(f.ContentType)
+
+	fn := newTextInput("Filename")
+	fn.SetValue(f.Filename)
+
+	return Field{Key: k, Value: v, ContentType: ct, Filename: fn, File: f.IsFile(), Enabled: !f.Disabled}
+}
+
+func New() Model {
+	m := Model{
+		Fields:   []Field{},
+		viewport: viewport.New(40, 4),
+	}
+
+	m.updateViewportContent()
+	return m
+}
+
+func (m *Model) Focus() {
+	m.Focused = true
+	m.updateViewportContent()
+}
+
+func (m *Model) Blur() {
+	m.ExitEditMode()
+	m.Focused = false
+	m.updateViewportContent()
+}
+
+func (m *Model) EnterEditMode() tea.Cmd {
+	if len(m.Fields) == 0 {
+		return nil
+	}
+
+	m.EditMode = true
+	return m.focusInput()
+}
+
+func (m *Model) ExitEditMode() {
+	m.EditMode = false
+	if len(m.Fields) > 0 {
+		f := &m.Fields[m.cursor]
+		f.Key.Blur()
+		f.Value.Blur()
+		f.ContentType.Blur()
+		f.Filename.Blur()
+	}
+	m.updateViewportContent()
+}
+
+func (m *Model) IsFocused() bool {
+	return m.EditMode
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.viewport.Width = width
+	m.viewport.Height = height
+	m.updateViewportContent()
+}
+
+func (m *Model) SetFields(form []storage.FormField) {
+	m.Fields = make([]Field, 0, len(form))
+	for _, f := range form {
+		m.Fields = append(m.Fields, newField(f))
+	}
+	m.cursor = 0
+	m.fieldFocus = inputKey
+	m.updateViewportContent()
+}
+
+func (m Model) FormFields() []storage.FormField {
+	var result []storage.FormField
+
+	for _, f := range m.Fields {
+		if f.Key.Value() == "" {
+			continue
+		}
+
+		field := storage.FormField{Key: f.Key.Value(), Value: f.Value.Value(), Disabled: !f.Enabled}
+		if f.File {
+			field.Type = storage.FormFieldFile
+			field.ContentType = f.ContentType.Value()
+			field.Filename = f.Filename.Value()
+		}
+
+		result = append(result, field)
+	}
+
+	return result
+}
+
+func (m *Model) input(index int) *textinput.Model {
+	f := &m.Fields[m.cursor]
+
+	switch index {
+	case inputValue:
+		return &f.Value
+	case inputContentType:
+		return &f.ContentType
+	case inputFilename:
+		return &f.Filename
+	default:
+		return &f.Key
+	}
+}
+
+func (m *Model) focusInput() tea.Cmd {
+	for i := inputKey; i <= inputFilename; i++ {
+		m.input(i).Blur()
+	}
+
+	return m.input(m.fieldFocus).Focus()
+}
+
+func (m *Model) inputCount() int {
+	if m.Fields[m.cursor].File && m.AllowFiles {
+		return 4
+	}
+
+	return 2
+}
+
+func (m *Model) addField() {
+	m.Fields = append(m.Fields, newField(storage.FormField{}))
+	m.cursor = len(m.Fields) - 1
+	m.fieldFocus = inputKey
+}
+
+func (m *Model) deleteField() {
+	if len(m.Fields) == 0 {
+		return
+	}
+
+	m.Fields = append(m.Fields[:m.cursor], m.Fields[m.cursor+1:]...)
+
+	if m.cursor >= len(m.Fields) && m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+func (m *Model) toggleFile() {
+	if len(m.Fields) == 0 || !m.AllowFiles {
+		return
+	}
+
+	f := &m.Fields[m.cursor]
+	f.File = !f.File
+	f.Value.Placeholder = "Value"
+	if f.File {
+		f.Value.Placeholder = "Path"
+	}
+}
+
+func (m *Model) toggleField() {
+	if len(m.Fields) > 0 {
+		m.Fields[m.cursor].Enabled = !m.Fields[m.cursor].Enabled
+	}
+}
+
+func (m *Model) moveField(delta int) {
+	target := m.cursor + delta
+	if len(m.Fields) == 0 || target < 0 || target >= len(m.Fields) {
+		return
+	}
+
+	m.Fields[m.cursor], m.Fields[target] = m.Fields[target], m.Fields[m.cursor]
+	m.cursor = target
+}
+
+func (m *Model) ensureCursorVisible() {
+	if m.cursor < m.viewport.YOffset {
+		m.viewport.SetYOffset(m.cursor)
+	} else if m.cursor >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(m.cursor - m.viewport.Height + 1)
+	}
+}
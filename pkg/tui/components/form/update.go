@@ -0,0 +1,79 @@
+package form
+
+import (
+	"github.com/Yalaouf/gostman/pkg/tui/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	if m.EditMode {
+		return m.updateEdit(msg)
+	}
+
+	return m.updateNav(msg)
+}
+
+func (m *Model) updateNav(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	switch keyMsg.String() {
+	case types.KeyJ, types.KeyDown:
+		if m.cursor < len(m.Fields)-1 {
+			m.cursor++
+			m.fieldFocus = inputKey
+		}
+	case types.KeyK, types.KeyUp:
+		if m.cursor > 0 {
+			m.cursor--
+			m.fieldFocus = inputKey
+		}
+	case types.KeyEnter:
+		return m.EnterEditMode()
+	case types.KeyA:
+		m.addField()
+		m.updateViewportContent()
+		m.ensureCursorVisible()
+		return m.EnterEditMode()
+	case types.KeyD:
+		m.deleteField()
+	case types.KeyF:
+		m.toggleFile()
+	case types.KeySpace:
+		m.toggleField()
+	case types.KeyShiftJ:
+		m.moveField(1)
+	case types.KeyShiftK:
+		m.moveField(-1)
+	}
+
+	m.updateViewportContent()
+	m.ensureCursorVisible()
+	return nil
+}
+
+func (m *Model) updateEdit(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if ok {
+		switch keyMsg.String() {
+		case types.KeyEscape, types.KeyEnter:
+			m.ExitEditMode()
+			return nil
+		case types.KeyTab:
+			m.fieldFocus = (m.fieldFocus + 1) % m.inputCount()
+			cmd := m.focusInput()
+			m.updateViewportContent()
+			return cmd
+		}
+	}
+
+	input := m.input(m.fieldFocus)
+
+	var cmd tea.Cmd
+	*input, cmd = input.Update(msg)
+
+	m.updateViewportContent()
+	return cmd
+}
@@ -0,0 +1,82 @@
+package form
+
+import (
+	"fmt"
+
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m *Model) updateViewportContent() {
+	var content string
+	if len(m.Fields) == 0 {
+		content = style.Unselected.Render("No fields (press 'a' to add)")
+	} else {
+		for i, f := range m.Fields {
+			content += m.renderFieldLine(i, f) + "\n"
+		}
+	}
+	m.viewport.SetContent(content)
+}
+
+func (m Model) renderInput(index, input int, ti textinput.Model) string {
+	if m.EditMode && index == m.cursor && m.fieldFocus == input {
+		return ti.View()
+	}
+
+	return ti.Value()
+}
+
+func (m Model) renderFieldLine(index int, f Field) string {
+	isCursor := index == m.cursor && m.Focused
+
+	check := "[ ]"
+	if f.Enabled {
+		check = "[X]"
+	}
+
+	key := m.renderInput(index, inputKey, f.Key)
+	value := m.renderInput(index, inputValue, f.Value)
+
+	line := fmt.Sprintf("%s %s: %s", check, key, value)
+	if f.File {
+		line = fmt.Sprintf("%s %s: @%s", check, key, value)
+	}
+
+	if !f.Enabled {
+		line = style.Unselected.Render(line)
+	} else if isCursor {
+		line = lipgloss.NewStyle().Background(style.ColorSurface).Foreground(style.ColorText).Render(line)
+	}
+
+	if !f.File {
+		return line
+	}
+
+	if !m.AllowFiles {
+		return line + style.Unselected.Render(" (file, ignored)")
+	}
+
+	editing := m.EditMode && index == m.cursor
+	if contentType := m.renderInput(index, inputContentType, f.ContentType); contentType != "" || editing {
+		line += style.Unselected.Render(" type: ") + contentType
+	}
+	if filename := m.renderInput(index, inputFilename, f.Filename); filename != "" || editing {
+		line += style.Unselected.Render(" as: ") + filename
+	}
+
+	return line + style.Unselected.Render(" (file)")
+}
+
+func (m Model) View() string {
+	footer := style.Unselected.Render("[a]dd [d]el [space]toggle [J/K]move [f]ile/text [enter]edit [tab]type")
+	if !m.AllowFiles {
+		footer = style.Unselected.Render("[a]dd [d]el [space]toggle [J/K]move [enter]edit [tab]type")
+	}
+	if m.EditMode {
+		footer = style.Unselected.Render("[tab]next field [esc/enter]validate")
+	}
+
+	return m.viewport.View() + "\n" + footer
+}
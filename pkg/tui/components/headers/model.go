@@ -1,6 +1,7 @@
 package headers
 
 import (
+	"github.com/Yalaouf/gostman/pkg/storage"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -104,8 +105,10 @@ func (m *Model) SetSize(width, height int) {
 }
 
 func (m *Model) SetContentType(contentType string) {
+	enabled := true
 	for i := len(m.Headers) - 1; i >= 0; i-- {
 		if m.Headers[i].Key.Value() == "Content-Type" {
+			enabled = m.Headers[i].Enabled
 			m.Headers = append(m.Headers[:i], m.Headers[i+1:]...)
 		}
 	}
@@ -116,28 +119,40 @@ func (m *Model) SetContentType(contentType string) {
 
 	if contentType != "" {
 		h := newHeader("Content-Type", contentType, true)
+		h.Enabled = enabled
 		m.Headers = append([]Header{h}, m.Headers...)
 	}
 
 	m.updateViewportContent()
 }
 
-func (m Model) EnabledHeaders() map[string]string {
-	result := make(map[string]string)
+func (m *Model) ReleaseContentType() {
+	for i := range m.Headers {
+		if m.Headers[i].Key.Value() == "Content-Type" {
+			m.Headers[i].Auto = false
+		}
+	}
+
+	m.updateViewportContent()
+}
+
+func (m Model) Entries() storage.Headers {
+	var result storage.Headers
 
 	for _, h := range m.Headers {
-		if h.Enabled && h.Key.Value() != "" {
-			result[h.Key.Value()] = h.Value.Value()
+		if h.Key.Value() != "" {
+			result = append(result, storage.Header{Key: h.Key.Value(), Value: h.Value.Value(), Disabled: !h.Enabled})
 		}
 	}
 
 	return result
 }
 
-func (m *Model) SetHeaders(headers map[string]string) {
+func (m *Model) SetHeaders(headers storage.Headers) {
 	m.Headers = []Header{}
-	for key, value := range headers {
-		h := newHeader(key, value, false)
+	for _, header := range headers {
+		h := newHeader(header.Key, header.Value, false)
+		h.Enabled = !header.Disabled
 		m.Headers = append(m.Headers, h)
 	}
 	m.cursor = 0
@@ -172,6 +187,16 @@ func (m *Model) toggleHeader() {
 	}
 }
 
+func (m *Model) moveHeader(delta int) {
+	target := m.cursor + delta
+	if len(m.Headers) == 0 || target < 0 || target >= len(m.Headers) {
+		return
+	}
+
+	m.Headers[m.cursor], m.Headers[target] = m.Headers[target], m.Headers[m.cursor]
+	m.cursor = target
+}
+
 func (m *Model) ensureCursorVisible() {
 	if m.cursor < m.viewport.YOffset {
 		m.viewport.SetYOffset(m.cursor)
@@ -51,6 +51,12 @@ func (m *Model) updateNav(msg tea.Msg) tea.Cmd {
 	case types.KeySpace:
 		m.toggleHeader()
 		m.updateViewportContent()
+	case types.KeyShiftJ:
+		m.moveHeader(1)
+		m.ensureCursorVisible()
+	case types.KeyShiftK:
+		m.moveHeader(-1)
+		m.ensureCursorVisible()
 	}
 
 	m.updateViewportContent()
@@ -84,7 +84,7 @@ func (m Model) View(width int) string {
 
 	topContent := m.viewport.View()
 	footer := style.Unselected.Render(
-		"[a]dd [d]el [p]resets [space]toggle [tab]key<>value [esc/enter]validate",
+		"[a]dd [d]el [p]resets [space]toggle [J/K]move [tab]key<>value [esc/enter]validate",
 	)
 
 	content := topContent + "\n" + footer
@@ -33,6 +33,19 @@ func GetSections() []Section {
 				{Key: "Esc", Desc: "Exit edit mode"},
 				{Key: "s", Desc: "Save request"},
 				{Key: "l", Desc: "Load request menu"},
+				{Key: "c", Desc: "Generate code snippet"},
+				{Key: "t", Desc: "Edit request tests"},
+				{Key: "v", Desc: "Edit variable extractions"},
+				{Key: "e", Desc: "Select environment"},
+				{Key: "P", Desc: "Edit pre-request/post-response scripts"},
+				{Key: "A", Desc: "Edit retry policy"},
+				{Key: "H", Desc: "Edit HTTP version and connection settings"},
+				{Key: "B", Desc: "Load test current request"},
+				{Key: "i", Desc: "Browse GraphQL schema / pick gRPC method"},
+				{Key: "S", Desc: "Stop event stream or gRPC call"},
+				{Key: "R", Desc: "Reconnect event stream"},
+				{Key: "Z", Desc: "Toggle response decompression"},
+				{Key: "w", Desc: "Switch workspace"},
 				{Key: "?", Desc: "Toggle help"},
 				{Key: "q/Ctrl+C", Desc: "Quit"},
 			},
@@ -44,6 +57,7 @@ func GetSections() []Section {
 				{Key: "d", Desc: "Delete header"},
 				{Key: "p", Desc: "Open presets"},
 				{Key: "Space", Desc: "Toggle header"},
+				{Key: "J/K", Desc: "Move header down/up"},
 				{Key: "Tab", Desc: "Switch key/value"},
 				{Key: "j/k", Desc: "Navigate up/down"},
 			},
@@ -52,6 +66,26 @@ func GetSections() []Section {
 			Title: "Body",
 			Keys: []KeyBinding{
 				{Key: "Tab", Desc: "Cycle body type"},
+				{Key: "a/d", Desc: "Add/delete form field"},
+				{Key: "Space", Desc: "Toggle form field"},
+				{Key: "J/K", Desc: "Move form field down/up"},
+				{Key: "f", Desc: "Toggle form-data file/text"},
+				{Key: "n", Desc: "Switch GraphQL query/variables"},
+				{Key: "o", Desc: "Cycle GraphQL operation"},
+				{Key: "z", Desc: "Cycle body compression"},
+				{Key: "Tab (edit)", Desc: "Complete GraphQL field"},
+			},
+		},
+		{
+			Title: "WebSocket (method WS)",
+			Keys: []KeyBinding{
+				{Key: utils.SendRequestShortcut(), Desc: "Connect, then send message"},
+				{Key: "Tab", Desc: "Cycle text/json/binary"},
+				{Key: "n", Desc: "Load next saved message"},
+				{Key: "a", Desc: "Save message"},
+				{Key: "x", Desc: "Delete saved message"},
+				{Key: "p", Desc: "Send ping"},
+				{Key: "d", Desc: "Disconnect (1000)"},
 			},
 		},
 		{
@@ -63,6 +97,25 @@ func GetSections() []Section {
 				{Key: "f", Desc: "Toggle fullscreen"},
 				{Key: "y", Desc: "Copy response"},
 				{Key: "h/l", Desc: "Collapse/expand (tree)"},
+				{Key: "x", Desc: "Extract node to variable (tree)"},
+			},
+		},
+		{
+			Title: "Code Snippets",
+			Keys: []KeyBinding{
+				{Key: "Tab/h/l", Desc: "Switch language"},
+				{Key: "j/k", Desc: "Scroll snippet"},
+				{Key: "Enter/y", Desc: "Copy to clipboard"},
+				{Key: "Esc", Desc: "Close"},
+			},
+		},
+		{
+			Title: "GraphQL Schema",
+			Keys: []KeyBinding{
+				{Key: "j/k", Desc: "Navigate up/down"},
+				{Key: "Enter/l", Desc: "Open type"},
+				{Key: "h", Desc: "Back"},
+				{Key: "Esc", Desc: "Close"},
 			},
 		},
 		{
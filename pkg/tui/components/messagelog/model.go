@@ -0,0 +1,79 @@
+package messagelog
+
+import (
+	"github.com/Yalaouf/gostman/pkg/socket"
+	"github.com/charmbracelet/bubbles/viewport"
+)
+
+type Model struct {
+	Viewport  viewport.Model
+	Focused   bool
+	Connected bool
+	Status    string
+	events    []socket.Event
+	width     int
+	height    int
+}
+
+func New() Model {
+	return Model{
+		Viewport: viewport.New(40, 10),
+		Status:   "Disconnected",
+	}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.Viewport.Width = width - 6
+	m.Viewport.Height = height - 6
+	m.updateViewportContent()
+}
+
+func (m *Model) SetConnected(connected bool, status string) {
+	m.Connected = connected
+	m.Status = status
+}
+
+func (m *Model) Append(e socket.Event) {
+	follow := m.Viewport.AtBottom()
+	m.events = append(m.events, e)
+	m.updateViewportContent()
+
+	if follow {
+		m.Viewport.GotoBottom()
+	}
+}
+
+func (m *Model) Clear() {
+	m.events = nil
+	m.updateViewportContent()
+}
+
+func (m Model) HasEvents() bool {
+	return len(m.events) > 0
+}
+
+func (m *Model) Focus() {
+	m.Focused = true
+}
+
+func (m *Model) Blur() {
+	m.Focused = false
+}
+
+func (m *Model) ScrollDown(n int) {
+	m.Viewport.ScrollDown(n)
+}
+
+func (m *Model) ScrollUp(n int) {
+	m.Viewport.ScrollUp(n)
+}
+
+func (m *Model) GotoTop() {
+	m.Viewport.GotoTop()
+}
+
+func (m *Model) GotoBottom() {
+	m.Viewport.GotoBottom()
+}
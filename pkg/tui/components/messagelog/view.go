@@ -0,0 +1,65 @@
+package messagelog
+
+import (
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/socket"
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/Yalaouf/gostman/pkg/tui/utils"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/wrap"
+)
+
+const timeFormat = "15:04:05.000"
+
+func (m Model) View(width int) string {
+	status := style.Error.Render("● " + m.Status)
+	if m.Connected {
+		status = style.Selected.Render("● " + m.Status)
+	}
+
+	var content string
+	if len(m.events) == 0 {
+		content = style.Unselected.Render("No messages yet. Press " + utils.SendRequestShortcut() + " to connect.")
+	} else {
+		content = m.Viewport.View()
+	}
+
+	return style.SectionBox("Messages", status+"\n\n"+content, m.Focused, width, m.height-4)
+}
+
+func (m *Model) updateViewportContent() {
+	lines := make([]string, 0, len(m.events))
+	for _, e := range m.events {
+		lines = append(lines, m.renderEvent(e))
+	}
+	m.Viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+func (m Model) renderEvent(e socket.Event) string {
+	arrowStyle := lipgloss.NewStyle().Foreground(style.ColorGreen)
+	arrow := "↑"
+	if e.Direction == socket.DirectionReceived {
+		arrowStyle = lipgloss.NewStyle().Foreground(style.ColorOrange)
+		arrow = "↓"
+	}
+
+	header := style.Unselected.Render(e.Time.Format(timeFormat)) + " " +
+		arrowStyle.Render(arrow+" "+string(e.Kind))
+
+	payload := e.Payload()
+	switch {
+	case e.Kind == socket.KindError:
+		payload = style.Error.Render(payload)
+	case e.Kind == socket.KindText && utils.IsJSON(payload):
+		payload = utils.HighlightJSON(payload)
+	case e.Kind == socket.KindText || e.Kind == socket.KindBinary:
+		payload = wrap.String(payload, max(m.Viewport.Width, 10))
+	}
+
+	if payload == "" {
+		return header
+	}
+
+	return header + "\n" + payload
+}
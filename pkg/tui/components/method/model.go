@@ -2,6 +2,11 @@ package method
 
 import "github.com/Yalaouf/gostman/pkg/request"
 
+const (
+	WebSocket request.HTTPMethod = "WS"
+	GRPC      request.HTTPMethod = "GRPC"
+)
+
 type Model struct {
 	Methods []request.HTTPMethod
 	Index   int
@@ -14,6 +19,7 @@ func New() Model {
 			request.GET, request.POST, request.PUT,
 			request.DELETE, request.PATCH, request.HEAD,
 			request.OPTIONS, request.TRACE, request.CONNECT,
+			WebSocket, GRPC,
 		},
 		Index:   0,
 		Focused: false,
@@ -24,6 +30,14 @@ func (m Model) Selected() request.HTTPMethod {
 	return m.Methods[m.Index]
 }
 
+func (m Model) IsWebSocket() bool {
+	return m.Selected() == WebSocket
+}
+
+func (m Model) IsGRPC() bool {
+	return m.Selected() == GRPC
+}
+
 func (m *Model) Next() {
 	m.Index = (m.Index + 1) % len(m.Methods)
 }
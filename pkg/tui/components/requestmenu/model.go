@@ -69,6 +69,20 @@ func (m *Model) Show() tea.Cmd {
 	return nil
 }
 
+func (m *Model) ShowCollection(id string) error {
+	coll, err := m.storage.GetCollection(id)
+	if err != nil {
+		return err
+	}
+
+	m.Show()
+	m.viewMode = ViewRequests
+	m.selectedCollID = coll.ID
+	m.selectedCollName = coll.Name
+	m.refresh()
+	return nil
+}
+
 func (m *Model) Hide() {
 	m.visible = false
 	m.inputMode = false
@@ -0,0 +1,38 @@
+package response
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+)
+
+func (m *Model) SetAttempts(attempts []request.Attempt) {
+	m.Response.Attempts = attempts
+}
+
+func (m Model) attemptsNotice() string {
+	attempts := m.Response.Attempts
+	if len(attempts) < 2 {
+		return ""
+	}
+
+	lines := []string{fmt.Sprintf("Attempts (%d):", len(attempts))}
+	for i, a := range attempts {
+		result := a.Error
+		if result == "" {
+			result = fmt.Sprintf("%d %s", a.StatusCode, http.StatusText(a.StatusCode))
+		}
+
+		line := fmt.Sprintf("  %d. %s in %dms", i+1, result, a.TimeTaken)
+		if a.Wait > 0 {
+			line += ", retried after " + a.Wait.Round(time.Millisecond).String()
+		}
+		lines = append(lines, line)
+	}
+
+	return style.Unselected.Render(strings.Join(lines, "\n")) + "\n\n"
+}
@@ -3,9 +3,11 @@ package response
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
+	"github.com/Yalaouf/gostman/pkg/jsonpath"
 	"github.com/Yalaouf/gostman/pkg/tui/style"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -23,6 +25,7 @@ const (
 
 type TreeNode struct {
 	Key      string
+	Path     string
 	Value    interface{}
 	Type     NodeType
 	Children []*TreeNode
@@ -45,7 +48,7 @@ func NewJSONTree(jsonStr string) *JSONTree {
 		return nil
 	}
 
-	tree.Root = tree.buildTree("", data, 0)
+	tree.Root = tree.buildTree("", data, nil)
 	if tree.Root != nil {
 		tree.Root.Expanded = true
 	}
@@ -54,9 +57,11 @@ func NewJSONTree(jsonStr string) *JSONTree {
 	return tree
 }
 
-func (t *JSONTree) buildTree(key string, value interface{}, depth int) *TreeNode {
+func (t *JSONTree) buildTree(key string, value interface{}, path []jsonpath.Segment) *TreeNode {
+	depth := len(path)
 	node := &TreeNode{
 		Key:      key,
+		Path:     jsonpath.Format(path),
 		Value:    value,
 		Depth:    depth,
 		Expanded: depth < 1,
@@ -71,13 +76,13 @@ func (t *JSONTree) buildTree(key string, value interface{}, depth int) *TreeNode
 		}
 		sort.Strings(keys)
 		for _, k := range keys {
-			child := t.buildTree(k, v[k], depth+1)
+			child := t.buildTree(k, v[k], append(slices.Clip(path), jsonpath.Segment{Key: k, IsKey: true}))
 			node.Children = append(node.Children, child)
 		}
 	case []interface{}:
 		node.Type = NodeArray
 		for i, item := range v {
-			child := t.buildTree(fmt.Sprintf("%d", i), item, depth+1)
+			child := t.buildTree(fmt.Sprintf("%d", i), item, append(slices.Clip(path), jsonpath.Segment{Index: i}))
 			node.Children = append(node.Children, child)
 		}
 	case string:
@@ -172,6 +177,14 @@ func (t *JSONTree) GetSelectedValue() string {
 	return t.nodeToJSON(node)
 }
 
+func (t *JSONTree) GetSelectedNode() *TreeNode {
+	if t.cursor < 0 || t.cursor >= len(t.flatList) {
+		return nil
+	}
+
+	return t.flatList[t.cursor]
+}
+
 func (t *JSONTree) nodeToJSON(node *TreeNode) string {
 	switch node.Type {
 	case NodeObject, NodeArray:
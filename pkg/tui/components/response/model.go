@@ -2,8 +2,13 @@ package response
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/Yalaouf/gostman/pkg/assertion"
+	"github.com/Yalaouf/gostman/pkg/extraction"
 	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/rpc"
+	"github.com/Yalaouf/gostman/pkg/sse"
 	"github.com/Yalaouf/gostman/pkg/tui/utils"
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/muesli/reflow/wrap"
@@ -15,11 +20,21 @@ type Model struct {
 	Focused    bool
 	Error      string
 	Loading    bool
+	Raw        bool
 	width      int
 	height     int
 	currentTab Tab
 	fullscreen bool
 	jsonTree   *JSONTree
+	tests      []assertion.Result
+	extracted  []extraction.Result
+	console    []string
+	events     []sse.Event
+	stream     bool
+	streaming  bool
+	progress   request.Progress
+	call       *rpc.Result
+	calling    bool
 }
 
 func New() Model {
@@ -32,7 +47,7 @@ func New() Model {
 }
 
 func (m Model) HasResponse() bool {
-	return m.Response.StatusCode != 0
+	return m.Response.StatusCode != 0 || m.call != nil
 }
 
 func (m *Model) SetSize(width, height int) {
@@ -45,6 +60,11 @@ func (m *Model) SetSize(width, height int) {
 func (m *Model) SetResponse(res request.Response) {
 	m.Response = res
 	m.Error = ""
+	m.stream = false
+	m.streaming = false
+	m.events = nil
+	m.call = nil
+	m.calling = false
 
 	if utils.IsJSON(res.Body) {
 		m.jsonTree = NewJSONTree(res.Body)
@@ -59,14 +79,38 @@ func (m *Model) SetResponse(res request.Response) {
 	m.Viewport.GotoTop()
 }
 
+func (m *Model) SetTests(tests []assertion.Result) {
+	m.tests = tests
+	m.updateViewportContent()
+}
+
+func (m *Model) SetExtractions(extracted []extraction.Result) {
+	m.extracted = extracted
+	m.updateViewportContent()
+}
+
+func (m *Model) SetConsole(lines []string) {
+	m.console = lines
+	m.updateViewportContent()
+}
+
 func (m *Model) SetError(err string) {
 	m.Error = err
 	m.Response = request.Response{}
+	m.stream = false
+	m.streaming = false
+	m.events = nil
+	m.call = nil
+	m.calling = false
+	m.tests = nil
+	m.extracted = nil
+	m.console = nil
 	m.Viewport.SetContent("")
 }
 
 func (m *Model) SetLoading(loading bool) {
 	m.Loading = loading
+	m.progress = request.Progress{}
 }
 
 func (m *Model) Focus() {
@@ -148,6 +192,13 @@ func (m *Model) TreeCollapse() {
 	}
 }
 
+func (m *Model) GetSelectedNode() *TreeNode {
+	if m.jsonTree != nil {
+		return m.jsonTree.GetSelectedNode()
+	}
+	return nil
+}
+
 func (m *Model) GetSelectedValue() string {
 	if m.jsonTree != nil {
 		return m.jsonTree.GetSelectedValue()
@@ -167,6 +218,10 @@ func (m *Model) GetContent() string {
 		return ""
 	}
 
+	if m.call != nil {
+		return m.callText()
+	}
+
 	switch m.currentTab {
 	case TabPretty, TabRaw:
 		return m.Response.Body
@@ -180,6 +235,17 @@ func (m *Model) GetContent() string {
 		return content
 	case TabTree:
 		return m.GetSelectedValue()
+	case TabTests:
+		var content string
+		for _, t := range m.tests {
+			content += formatTest(t) + "\n"
+		}
+		for _, e := range m.extracted {
+			content += formatExtraction(e) + "\n"
+		}
+		return content
+	case TabConsole:
+		return strings.Join(m.console, "\n")
 	}
 	return ""
 }
@@ -189,9 +255,19 @@ func (m *Model) updateViewportContent() {
 		return
 	}
 
+	if m.call != nil {
+		m.Viewport.SetContent(m.callStatus() + "\n\n" + m.callContent() + "\n\n")
+		return
+	}
+
 	var content string
 	switch m.currentTab {
 	case TabPretty:
+		if m.stream {
+			content = m.renderStream(true)
+			break
+		}
+
 		body := m.Response.Body
 		if utils.IsJSON(body) {
 			body = utils.HighlightJSON(body)
@@ -203,6 +279,11 @@ func (m *Model) updateViewportContent() {
 
 		content = body
 	case TabRaw:
+		if m.stream {
+			content = m.renderStream(false)
+			break
+		}
+
 		body := m.Response.Body
 
 		if m.Viewport.Width > 0 {
@@ -231,13 +312,30 @@ func (m *Model) updateViewportContent() {
 		} else {
 			content = "Response is not valid JSON"
 		}
+	case TabTests:
+		content = renderTests(m.tests, m.extracted)
+
+		if m.Viewport.Width > 0 {
+			content = wrap.String(content, m.Viewport.Width-2)
+		}
+	case TabConsole:
+		content = renderConsole(m.console)
+
+		if m.Viewport.Width > 0 {
+			content = wrap.String(content, m.Viewport.Width-2)
+		}
 	}
 
 	padding := "\n\n"
 	fullContent := fmt.Sprintf(
-		"%s  •  %s\n\n%s%s",
+		"%s%s  •  %s%s%s\n\n%s%s%s%s",
 		colorStatusCode(m.Response.StatusCode),
+		m.protoStatus(),
 		colorTimeTaken(m.Response.TimeTaken),
+		m.sizeStatus(),
+		m.streamStatus(),
+		m.attemptsNotice(),
+		m.truncatedNotice(),
 		content,
 		padding,
 	)
@@ -0,0 +1,89 @@
+package response
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/Yalaouf/gostman/pkg/tui/utils"
+	"github.com/muesli/reflow/wrap"
+)
+
+func (m *Model) SetProgress(p request.Progress) {
+	if m.Loading {
+		m.progress = p
+	}
+}
+
+func (m Model) renderLoading(width, height int) string {
+	p := m.progress
+	if p.Received == 0 {
+		return style.Unselected.Render("Loading...")
+	}
+
+	received := utils.FormatSize(p.Received)
+	if p.Total > 0 {
+		received += " of " + utils.FormatSize(p.Total)
+	}
+
+	status := style.Unselected.Render(fmt.Sprintf(
+		"Loading... %s  •  %s/s",
+		received,
+		utils.FormatSize(int64(p.Rate())),
+	))
+
+	preview := p.Preview
+	if width > 0 {
+		preview = wrap.String(preview, width-2)
+	}
+
+	lines := strings.Split(preview, "\n")
+	if height > 2 && len(lines) > height-2 {
+		lines = lines[:height-2]
+	}
+
+	return status + "\n\n" + strings.Join(lines, "\n")
+}
+
+func (m Model) protoStatus() string {
+	if m.Response.Proto == "" {
+		return ""
+	}
+
+	proto := "  •  " + m.Response.Proto
+	if m.Response.HTTP3Advertised() {
+		proto += style.Unselected.Render(" (h3 advertised)")
+	}
+
+	return proto
+}
+
+func (m Model) sizeStatus() string {
+	if m.stream || m.Response.Size == 0 {
+		return ""
+	}
+
+	size := "  •  " + utils.FormatSize(m.Response.Size)
+	switch {
+	case m.Response.Decoded:
+		size += fmt.Sprintf(" (%s %s)", utils.FormatSize(m.Response.WireSize), m.Response.Encoding)
+	case m.Response.Encoding != "":
+		size += fmt.Sprintf(" %s, raw", m.Response.Encoding)
+	}
+
+	return size
+}
+
+func (m Model) truncatedNotice() string {
+	if !m.Response.Truncated() {
+		return ""
+	}
+
+	return style.Unselected.Render(fmt.Sprintf(
+		"Showing the first %s of %s, the full body is saved to %s",
+		utils.FormatSize(int64(len(m.Response.Body))),
+		utils.FormatSize(m.Response.Size),
+		m.Response.BodyFile,
+	)) + "\n\n"
+}
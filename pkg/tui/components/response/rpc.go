@@ -0,0 +1,162 @@
+package response
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/rpc"
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/Yalaouf/gostman/pkg/tui/utils"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/wrap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func (m *Model) StartCall() {
+	m.SetError("")
+	m.Loading = false
+	m.call = &rpc.Result{}
+	m.calling = true
+	m.jsonTree = nil
+	m.updateViewportContent()
+	m.Viewport.GotoTop()
+}
+
+func (m *Model) AppendCallResponse(response string) {
+	if !m.calling {
+		return
+	}
+
+	follow := m.Viewport.AtBottom()
+	m.call.Responses = append(m.call.Responses, response)
+	m.updateViewportContent()
+
+	if follow {
+		m.Viewport.GotoBottom()
+	}
+}
+
+func (m *Model) FinishCall(res *rpc.Result) {
+	m.call = res
+	m.calling = false
+	m.jsonTree = nil
+
+	if len(res.Responses) == 1 {
+		m.jsonTree = NewJSONTree(res.Responses[0])
+		if m.jsonTree != nil {
+			m.jsonTree.SetWidth(m.width)
+		}
+	}
+
+	m.updateViewportContent()
+}
+
+func (m Model) IsCall() bool {
+	return m.call != nil
+}
+
+func (m Model) callContent() string {
+	var content string
+
+	switch m.currentTab {
+	case TabPretty, TabRaw:
+		content = renderCallResponses(m.call.Responses, m.currentTab == TabPretty)
+	case TabHeaders:
+		content = renderMetadata("Headers", m.call.Headers) + "\n" + renderMetadata("Trailers", m.call.Trailers)
+	case TabTree:
+		if m.jsonTree != nil {
+			m.jsonTree.SetWidth(m.Viewport.Width)
+			return m.jsonTree.Render()
+		}
+		content = "Tree view needs exactly one response message"
+	default:
+		content = style.Unselected.Render("Not available for gRPC calls")
+	}
+
+	if m.Viewport.Width > 0 {
+		content = wrap.String(content, m.Viewport.Width-2)
+	}
+
+	return content
+}
+
+func (m Model) callStatus() string {
+	status := colorRPCCode(m.call.Code)
+	if m.calling {
+		return status + "  •  " + style.Selected.Render(fmt.Sprintf("● calling, %d messages", len(m.call.Responses)))
+	}
+
+	status += "  •  " + colorTimeTaken(m.call.TimeTaken)
+	status += "  •  " + fmt.Sprintf("%d messages", len(m.call.Responses))
+	if m.call.Message != "" && m.call.Code != codes.OK {
+		status += "\n" + style.Error.Render(m.call.Message)
+	}
+
+	return status
+}
+
+func (m Model) callText() string {
+	switch m.currentTab {
+	case TabPretty, TabRaw:
+		return strings.Join(m.call.Responses, "\n")
+	case TabHeaders:
+		return metadataText(m.call.Headers) + metadataText(m.call.Trailers)
+	case TabTree:
+		return m.GetSelectedValue()
+	}
+	return ""
+}
+
+func colorRPCCode(code codes.Code) string {
+	codeStyle := lipgloss.NewStyle().Foreground(style.ColorRed)
+
+	switch code {
+	case codes.OK:
+		codeStyle = codeStyle.Foreground(style.ColorGreen)
+	case codes.Canceled, codes.DeadlineExceeded, codes.NotFound, codes.InvalidArgument,
+		codes.AlreadyExists, codes.PermissionDenied, codes.Unauthenticated, codes.FailedPrecondition:
+		codeStyle = codeStyle.Foreground(style.ColorOrange)
+	}
+
+	return codeStyle.Render(fmt.Sprintf("%s (%d)", code, code))
+}
+
+func renderCallResponses(responses []string, pretty bool) string {
+	if len(responses) == 0 {
+		return style.Unselected.Render("No response messages")
+	}
+
+	parts := make([]string, len(responses))
+	for i, response := range responses {
+		if pretty {
+			response = utils.HighlightJSON(response)
+		}
+		parts[i] = response
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+func renderMetadata(title string, md metadata.MD) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(style.ColorOrange)
+
+	body := metadataText(md)
+	if body == "" {
+		body = style.Unselected.Render("none") + "\n"
+	}
+
+	return titleStyle.Render(title) + "\n" + body
+}
+
+func metadataText(md metadata.MD) string {
+	var b strings.Builder
+	for _, key := range slices.Sorted(maps.Keys(md)) {
+		for _, value := range md[key] {
+			fmt.Fprintf(&b, "%s: %s\n", key, value)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,117 @@
+package response
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/sse"
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/Yalaouf/gostman/pkg/tui/utils"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/wrap"
+)
+
+const eventTimeFormat = "15:04:05.000"
+
+func (m *Model) StartStream(res request.Response) {
+	m.Response = res
+	m.Error = ""
+	m.Loading = false
+	m.stream = true
+	m.streaming = true
+	m.call = nil
+	m.calling = false
+	m.events = nil
+	m.jsonTree = nil
+	m.tests = nil
+	m.extracted = nil
+	m.console = nil
+	m.updateViewportContent()
+	m.Viewport.GotoTop()
+}
+
+func (m *Model) AppendEvent(e sse.Event) {
+	follow := m.Viewport.AtBottom()
+	m.events = append(m.events, e)
+	m.updateViewportContent()
+
+	if follow {
+		m.Viewport.GotoBottom()
+	}
+}
+
+func (m *Model) FinishStream(res request.Response) {
+	m.Response = res
+	m.streaming = false
+	m.updateViewportContent()
+}
+
+func (m Model) Streaming() bool {
+	return m.streaming
+}
+
+func (m Model) IsStream() bool {
+	return m.stream
+}
+
+func (m Model) LastEventID() string {
+	for i := len(m.events) - 1; i >= 0; i-- {
+		if m.events[i].ID != "" {
+			return m.events[i].ID
+		}
+	}
+	return ""
+}
+
+func (m Model) streamStatus() string {
+	if !m.stream {
+		return ""
+	}
+
+	status := fmt.Sprintf("%d events", len(m.events))
+	if m.streaming {
+		return "  •  " + style.Selected.Render("● streaming "+status)
+	}
+	return "  •  " + style.Unselected.Render("stream closed, "+status)
+}
+
+func (m Model) renderStream(pretty bool) string {
+	content := renderEvents(m.events, pretty)
+	if m.Viewport.Width > 0 {
+		content = wrap.String(content, m.Viewport.Width-2)
+	}
+	return content
+}
+
+func renderEvents(events []sse.Event, pretty bool) string {
+	if len(events) == 0 {
+		return style.Unselected.Render("Waiting for events...")
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(style.ColorOrange)
+
+	var b strings.Builder
+	for i, e := range events {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		header := style.Unselected.Render(e.Time.Format(eventTimeFormat)) + " " + labelStyle.Render(e.Type)
+		if e.ID != "" {
+			header += style.Unselected.Render(" id: ") + e.ID
+		}
+		if e.Retry > 0 {
+			header += style.Unselected.Render(" retry: ") + e.Retry.String()
+		}
+		b.WriteString(header + "\n")
+
+		data := e.Data
+		if pretty && utils.IsJSON(data) {
+			data = utils.HighlightJSON(data)
+		}
+		b.WriteString(data + "\n")
+	}
+
+	return b.String()
+}
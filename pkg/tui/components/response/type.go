@@ -7,9 +7,11 @@ const (
 	TabRaw
 	TabHeaders
 	TabTree
+	TabTests
+	TabConsole
 )
 
-var AllTabs = []Tab{TabPretty, TabRaw, TabHeaders, TabTree}
+var AllTabs = []Tab{TabPretty, TabRaw, TabHeaders, TabTree, TabTests, TabConsole}
 
 func (t Tab) String() string {
 	switch t {
@@ -21,6 +23,10 @@ func (t Tab) String() string {
 		return "headers"
 	case TabTree:
 		return "tree"
+	case TabTests:
+		return "tests"
+	case TabConsole:
+		return "console"
 	default:
 		return "pretty"
 	}
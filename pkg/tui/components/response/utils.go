@@ -2,7 +2,10 @@ package response
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/Yalaouf/gostman/pkg/assertion"
+	"github.com/Yalaouf/gostman/pkg/extraction"
 	"github.com/Yalaouf/gostman/pkg/tui/style"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -24,6 +27,69 @@ func colorStatusCode(statusCode int) string {
 	return statusStyle.Render(fmt.Sprintf("Status %d", statusCode))
 }
 
+func formatTest(t assertion.Result) string {
+	mark := "✓"
+	if !t.Passed {
+		mark = "✗"
+	}
+
+	line := mark + " " + assertion.Format(t.Assertion)
+	if t.Message != "" {
+		line += " — " + t.Message
+	}
+
+	return line
+}
+
+func formatExtraction(e extraction.Result) string {
+	if e.Err != nil {
+		return fmt.Sprintf("✗ %s — %v", e.Extraction.Variable, e.Err)
+	}
+
+	return fmt.Sprintf("→ %s = %s", e.Extraction.Variable, e.Value)
+}
+
+func renderTests(tests []assertion.Result, extracted []extraction.Result) string {
+	passStyle := lipgloss.NewStyle().Foreground(style.ColorGreen)
+	failStyle := lipgloss.NewStyle().Foreground(style.ColorRed)
+
+	var variables string
+	if len(extracted) > 0 {
+		lines := make([]string, 0, len(extracted))
+		for _, e := range extracted {
+			if e.Err != nil {
+				lines = append(lines, failStyle.Render(formatExtraction(e)))
+			} else {
+				lines = append(lines, style.Unselected.Render(formatExtraction(e)))
+			}
+		}
+		variables = "\n\n" + style.SectionTitle.Render("Variables") + "\n\n" + strings.Join(lines, "\n")
+	}
+
+	if len(tests) == 0 {
+		return style.Unselected.Render("No tests defined for this request. Press t to add some.") + variables
+	}
+
+	lines := make([]string, 0, len(tests)+2)
+	for _, t := range tests {
+		if t.Passed {
+			lines = append(lines, passStyle.Render(formatTest(t)))
+		} else {
+			lines = append(lines, failStyle.Render(formatTest(t)))
+		}
+	}
+
+	passed := assertion.Count(tests)
+	summary := fmt.Sprintf("%d/%d passed", passed, len(tests))
+	if passed == len(tests) {
+		summary = passStyle.Render(summary)
+	} else {
+		summary = failStyle.Render(summary)
+	}
+
+	return summary + "\n\n" + strings.Join(lines, "\n") + variables
+}
+
 func colorTimeTaken(timeTaken int64) string {
 	timeTakenStyle := lipgloss.NewStyle()
 
@@ -38,3 +104,11 @@ func colorTimeTaken(timeTaken int64) string {
 
 	return timeTakenStyle.Render(fmt.Sprintf("%dms", timeTaken))
 }
+
+func renderConsole(lines []string) string {
+	if len(lines) == 0 {
+		return style.Unselected.Render("No console output. Scripts can write here with log(...) or print(...).")
+	}
+
+	return strings.Join(lines, "\n")
+}
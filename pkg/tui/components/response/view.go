@@ -1,8 +1,10 @@
 package response
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/Yalaouf/gostman/pkg/assertion"
 	"github.com/Yalaouf/gostman/pkg/tui/style"
 	"github.com/Yalaouf/gostman/pkg/tui/utils"
 	"github.com/charmbracelet/lipgloss"
@@ -12,6 +14,12 @@ func (m *Model) renderTabs() string {
 	var tabs []string
 	for _, t := range AllTabs {
 		label := t.String()
+		if t == TabTests && len(m.tests) > 0 {
+			label = fmt.Sprintf("%s %d/%d", label, assertion.Count(m.tests), len(m.tests))
+		}
+		if t == TabConsole && len(m.console) > 0 {
+			label = fmt.Sprintf("%s %d", label, len(m.console))
+		}
 		if t == m.currentTab {
 			tabs = append(tabs, style.Selected.Render("["+label+"]"))
 		} else {
@@ -32,9 +40,9 @@ func (m Model) View(width int) string {
 
 	var content string
 	if m.Loading {
-		content = style.Unselected.Render("Loading...")
+		content = m.renderLoading(m.Viewport.Width, m.Viewport.Height)
 	} else if m.Error != "" {
-		content = style.Error.Render("Error: " + m.Error)
+		content = m.attemptsNotice() + style.Error.Render("Error: "+m.Error)
 	} else if m.HasResponse() {
 		scrollbarStyle := lipgloss.NewStyle().Foreground(borderColor).MarginLeft(1)
 		scrollbar := scrollbarStyle.Render(RenderScrollbar(m.Viewport))
@@ -45,7 +53,12 @@ func (m Model) View(width int) string {
 
 	fullContent := tabs + "\n\n" + content
 
-	return style.SectionBox("Response", fullContent, m.Focused, width, m.height-4)
+	title := "Response"
+	if m.Raw {
+		title += " (raw)"
+	}
+
+	return style.SectionBox(title, fullContent, m.Focused, width, m.height-4)
 }
 
 func (m *Model) ViewFullscreen(width, height int) string {
@@ -60,9 +73,9 @@ func (m *Model) ViewFullscreen(width, height int) string {
 
 	var content string
 	if m.Loading {
-		content = style.Unselected.Render("Loading...")
+		content = m.renderLoading(m.Viewport.Width, m.Viewport.Height)
 	} else if m.Error != "" {
-		content = style.Error.Render("Error: " + m.Error)
+		content = m.attemptsNotice() + style.Error.Render("Error: "+m.Error)
 	} else if m.HasResponse() {
 		scrollbarStyle := lipgloss.NewStyle().Foreground(style.ColorPurple).MarginLeft(1)
 		scrollbar := scrollbarStyle.Render(RenderScrollbar(m.Viewport))
@@ -0,0 +1,108 @@
+package rpcpopup
+
+import (
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/rpc"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type Model struct {
+	visible bool
+	loading bool
+	err     string
+	files   textinput.Model
+	methods []rpc.Method
+	index   int
+	height  int
+}
+
+func New() Model {
+	files := textinput.New()
+	files.Placeholder = "empty = server reflection"
+	files.Prompt = ""
+	files.Width = 50
+
+	return Model{files: files, height: 15}
+}
+
+func (m *Model) Show(files []string) tea.Cmd {
+	m.visible = true
+	m.loading = true
+	m.err = ""
+	m.methods = nil
+	m.index = 0
+	m.files.SetValue(strings.Join(files, ", "))
+	m.files.Blur()
+	return nil
+}
+
+func (m *Model) Hide() {
+	m.visible = false
+	m.loading = false
+	m.files.Blur()
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.height = max(height-18, 5)
+}
+
+func (m *Model) SetRegistry(registry *rpc.Registry, selected string) {
+	m.loading = false
+	m.methods = registry.Methods()
+	m.index = 0
+
+	for i, method := range m.methods {
+		if method.FullName() == selected {
+			m.index = i
+		}
+	}
+}
+
+func (m *Model) SetError(err string) {
+	m.loading = false
+	m.err = err
+}
+
+func (m *Model) Reload() {
+	m.loading = true
+	m.err = ""
+	m.methods = nil
+	m.index = 0
+}
+
+func (m Model) Selected() (rpc.Method, bool) {
+	if m.index >= len(m.methods) {
+		return rpc.Method{}, false
+	}
+
+	return m.methods[m.index], true
+}
+
+func (m *Model) EditFiles() tea.Cmd {
+	return m.files.Focus()
+}
+
+func (m *Model) StopEditing() {
+	m.files.Blur()
+}
+
+func (m Model) Editing() bool {
+	return m.files.Focused()
+}
+
+func (m Model) Files() []string {
+	var files []string
+	for file := range strings.SplitSeq(m.files.Value(), ",") {
+		if file = strings.TrimSpace(file); file != "" {
+			files = append(files, file)
+		}
+	}
+
+	return files
+}
@@ -0,0 +1,32 @@
+package rpcpopup
+
+import (
+	"github.com/Yalaouf/gostman/pkg/tui/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	if m.Editing() {
+		var cmd tea.Cmd
+		m.files, cmd = m.files.Update(msg)
+		return cmd
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.loading {
+		return nil
+	}
+
+	switch keyMsg.String() {
+	case types.KeyJ, types.KeyDown:
+		if m.index < len(m.methods)-1 {
+			m.index++
+		}
+	case types.KeyK, types.KeyUp:
+		if m.index > 0 {
+			m.index--
+		}
+	}
+
+	return nil
+}
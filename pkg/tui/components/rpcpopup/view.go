@@ -0,0 +1,75 @@
+package rpcpopup
+
+import (
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m Model) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(style.ColorOrange)
+	hintStyle := style.Unselected
+
+	title := titleStyle.Render("gRPC Methods")
+
+	label := hintStyle.Render("Proto files: ")
+	if m.Editing() {
+		label = style.Selected.Render("Proto files: ")
+	}
+	files := label + m.files.View()
+
+	var content string
+	switch {
+	case m.loading:
+		content = hintStyle.Render("Discovering services...")
+	case m.err != "":
+		content = style.Error.Render("Error: " + m.err)
+	default:
+		content = m.renderMethods()
+	}
+
+	hint := hintStyle.Render("[enter]select [j/k]navigate [f]proto files [r]reload [esc]close")
+	if m.Editing() {
+		hint = hintStyle.Render("comma-separated .proto paths  [enter]load [esc]cancel")
+	}
+
+	body := title + "\n\n" + files + "\n\n" + content + "\n\n" + hint
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(style.ColorPurple).
+		Padding(1, 3).
+		Render(body)
+
+	return box
+}
+
+func (m Model) renderMethods() string {
+	if len(m.methods) == 0 {
+		return style.Unselected.Render("No methods")
+	}
+
+	start := 0
+	if m.index >= m.height {
+		start = m.index - m.height + 1
+	}
+	end := min(start+m.height, len(m.methods))
+
+	var b strings.Builder
+	for i := start; i < end; i++ {
+		method := m.methods[i]
+		line := method.FullName() + style.Unselected.Render(" "+string(method.Kind))
+
+		if i == m.index {
+			b.WriteString(style.Selected.Render("▸ ") + line)
+		} else {
+			b.WriteString("  " + line)
+		}
+		if i < end-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,108 @@
+package rulespopup
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type Config struct {
+	Title       string
+	Placeholder string
+	Examples    []string
+	Normalize   func(line string) (string, error)
+}
+
+type Model struct {
+	config  Config
+	visible bool
+	index   int
+	rules   []string
+
+	inputMode bool
+	editIndex int
+	input     textinput.Model
+	err       string
+}
+
+func New(config Config) Model {
+	ti := textinput.New()
+	ti.Placeholder = config.Placeholder
+	ti.CharLimit = 1024
+	ti.Width = 50
+
+	return Model{
+		config: config,
+		input:  ti,
+	}
+}
+
+func (m *Model) Show(rules []string) tea.Cmd {
+	m.visible = true
+	m.index = 0
+	m.err = ""
+	m.inputMode = false
+	m.rules = slices.Clone(rules)
+	return nil
+}
+
+func (m *Model) Hide() {
+	m.visible = false
+	m.inputMode = false
+	m.input.Blur()
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+func (m Model) Rules() []string {
+	return slices.Clone(m.rules)
+}
+
+func (m *Model) startInput(index int) tea.Cmd {
+	m.inputMode = true
+	m.editIndex = index
+	m.err = ""
+
+	if index >= 0 && index < len(m.rules) {
+		m.input.SetValue(m.rules[index])
+	} else {
+		m.input.SetValue("")
+	}
+
+	m.input.Focus()
+	m.input.CursorEnd()
+	return textinput.Blink
+}
+
+func (m *Model) confirmInput() {
+	line, err := m.config.Normalize(m.input.Value())
+	if err != nil {
+		m.err = err.Error()
+		return
+	}
+
+	if m.editIndex >= 0 && m.editIndex < len(m.rules) {
+		m.rules[m.editIndex] = line
+	} else {
+		m.rules = append(m.rules, line)
+		m.index = len(m.rules) - 1
+	}
+
+	m.inputMode = false
+	m.err = ""
+	m.input.Blur()
+}
+
+func (m *Model) deleteSelected() {
+	if m.index < 0 || m.index >= len(m.rules) {
+		return
+	}
+
+	m.rules = slices.Delete(m.rules, m.index, m.index+1)
+	if m.index >= len(m.rules) && m.index > 0 {
+		m.index--
+	}
+}
@@ -0,0 +1,64 @@
+package rulespopup
+
+import (
+	"github.com/Yalaouf/gostman/pkg/tui/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	if m.inputMode {
+		return m.handleInputMode(msg)
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	switch keyMsg.String() {
+	case types.KeyEscape, types.KeyQ:
+		m.Hide()
+	case types.KeyJ, types.KeyDown:
+		if m.index < len(m.rules)-1 {
+			m.index++
+		}
+	case types.KeyK, types.KeyUp:
+		if m.index > 0 {
+			m.index--
+		}
+	case types.KeyA:
+		return m.startInput(-1)
+	case types.KeyEnter:
+		if len(m.rules) > 0 {
+			return m.startInput(m.index)
+		}
+	case types.KeyD:
+		m.deleteSelected()
+	}
+
+	return nil
+}
+
+func (m *Model) handleInputMode(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return cmd
+	}
+
+	switch keyMsg.String() {
+	case types.KeyEscape:
+		m.inputMode = false
+		m.err = ""
+		m.input.Blur()
+		return nil
+	case types.KeyEnter:
+		m.confirmInput()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return cmd
+}
@@ -0,0 +1,56 @@
+package rulespopup
+
+import (
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m Model) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(style.ColorOrange)
+	hintStyle := style.Unselected
+
+	title := titleStyle.Render(m.config.Title)
+
+	var b strings.Builder
+	if len(m.rules) == 0 {
+		b.WriteString(hintStyle.Render("Nothing defined yet. Press a to add a rule."))
+	}
+
+	for i, line := range m.rules {
+		if i == m.index && !m.inputMode {
+			b.WriteString(style.Selected.Render("▸ " + line))
+		} else {
+			b.WriteString(style.Unselected.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	var inputView string
+	if m.inputMode {
+		inputView = "\n\n" + m.input.View()
+	}
+
+	var errView string
+	if m.err != "" {
+		errView = "\n\n" + style.Error.Render(m.err)
+	}
+
+	examples := hintStyle.Render(strings.Join(m.config.Examples, "\n"))
+
+	hint := hintStyle.Render("[a]dd [enter]edit [d]elete [esc]close")
+	if m.inputMode {
+		hint = hintStyle.Render("[enter]confirm [esc]cancel")
+	}
+
+	content := title + "\n\n" + b.String() + inputView + errView + "\n\n" + examples + "\n\n" + hint
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(style.ColorPurple).
+		Padding(1, 3).
+		Render(content)
+
+	return box
+}
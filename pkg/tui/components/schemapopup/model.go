@@ -0,0 +1,120 @@
+package schemapopup
+
+import (
+	"github.com/Yalaouf/gostman/pkg/graphql"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type Model struct {
+	visible bool
+	loading bool
+	err     string
+	schema  *graphql.Schema
+	names   []string
+	history []string
+	cursor  int
+	height  int
+}
+
+func New() Model {
+	return Model{height: 15}
+}
+
+func (m *Model) Show() tea.Cmd {
+	m.visible = true
+	m.loading = true
+	m.err = ""
+	m.history = nil
+	m.cursor = 0
+	return nil
+}
+
+func (m *Model) Hide() {
+	m.visible = false
+	m.loading = false
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.height = max(height-16, 5)
+}
+
+func (m *Model) SetSchema(schema *graphql.Schema) {
+	m.loading = false
+	m.schema = schema
+	m.names = schema.TypeNames()
+	m.history = nil
+	m.cursor = 0
+}
+
+func (m *Model) SetError(err string) {
+	m.loading = false
+	m.err = err
+}
+
+func (m Model) current() *graphql.Type {
+	if m.schema == nil || len(m.history) == 0 {
+		return nil
+	}
+
+	t, _ := m.schema.Type(m.history[len(m.history)-1])
+	return t
+}
+
+func (m Model) entries() int {
+	t := m.current()
+	switch {
+	case m.schema == nil:
+		return 0
+	case t == nil:
+		return len(m.names)
+	case len(t.Fields) > 0:
+		return len(t.Fields)
+	case len(t.InputFields) > 0:
+		return len(t.InputFields)
+	case len(t.PossibleTypes) > 0:
+		return len(t.PossibleTypes)
+	default:
+		return len(t.EnumValues)
+	}
+}
+
+func (m Model) target() string {
+	t := m.current()
+	switch {
+	case m.schema == nil || m.entries() == 0:
+		return ""
+	case t == nil:
+		return m.names[m.cursor]
+	case len(t.Fields) > 0:
+		return t.Fields[m.cursor].Type.Named()
+	case len(t.InputFields) > 0:
+		return t.InputFields[m.cursor].Type.Named()
+	case len(t.PossibleTypes) > 0:
+		return t.PossibleTypes[m.cursor]
+	default:
+		return ""
+	}
+}
+
+func (m *Model) open() {
+	name := m.target()
+	if _, ok := m.schema.Type(name); !ok {
+		return
+	}
+
+	m.history = append(m.history, name)
+	m.cursor = 0
+}
+
+func (m *Model) back() {
+	if len(m.history) == 0 {
+		return
+	}
+
+	m.history = m.history[:len(m.history)-1]
+	m.cursor = 0
+}
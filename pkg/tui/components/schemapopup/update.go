@@ -0,0 +1,30 @@
+package schemapopup
+
+import (
+	"github.com/Yalaouf/gostman/pkg/tui/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.schema == nil || m.loading {
+		return nil
+	}
+
+	switch keyMsg.String() {
+	case types.KeyJ, types.KeyDown:
+		if m.cursor < m.entries()-1 {
+			m.cursor++
+		}
+	case types.KeyK, types.KeyUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case types.KeyEnter, types.KeyL, types.KeyRight:
+		m.open()
+	case types.KeyH, types.KeyLeft, types.KeyBackspace:
+		m.back()
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package schemapopup
+
+import (
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m Model) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(style.ColorOrange)
+	hintStyle := style.Unselected
+
+	title := titleStyle.Render("GraphQL Schema")
+
+	var content string
+	switch {
+	case m.loading:
+		content = hintStyle.Render("Fetching schema...")
+	case m.err != "":
+		content = style.Error.Render("Error: " + m.err)
+	case m.schema == nil:
+		content = hintStyle.Render("No schema")
+	default:
+		content = m.renderPath() + "\n\n" + m.renderEntries()
+	}
+
+	hint := hintStyle.Render("[j/k]navigate [enter/l]open type [h]back [esc]close")
+
+	body := title + "\n\n" + content + "\n\n" + hint
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(style.ColorPurple).
+		Padding(1, 3).
+		Render(body)
+
+	return box
+}
+
+func (m Model) renderPath() string {
+	path := append([]string{"types"}, m.history...)
+	header := style.Unselected.Render(strings.Join(path, " > "))
+
+	t := m.current()
+	if t == nil {
+		return header
+	}
+
+	header += "\n" + style.Selected.Render(t.Name) + style.Unselected.Render(" "+strings.ToLower(t.Kind))
+	if t.Description != "" {
+		header += "\n" + style.Unselected.Render(t.Description)
+	}
+
+	return header
+}
+
+func (m Model) renderEntries() string {
+	lines := m.entryLines()
+	if len(lines) == 0 {
+		return style.Unselected.Render("No fields")
+	}
+
+	start := 0
+	if m.cursor >= m.height {
+		start = m.cursor - m.height + 1
+	}
+	end := min(start+m.height, len(lines))
+
+	var b strings.Builder
+	for i := start; i < end; i++ {
+		if i == m.cursor {
+			b.WriteString(style.Selected.Render("> " + lines[i]))
+		} else {
+			b.WriteString("  " + lines[i])
+		}
+		if i < end-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+func (m Model) entryLines() []string {
+	t := m.current()
+	if t == nil {
+		lines := make([]string, len(m.names))
+		for i, name := range m.names {
+			typ, _ := m.schema.Type(name)
+			lines[i] = name + style.Unselected.Render(" "+strings.ToLower(typ.Kind))
+		}
+		return lines
+	}
+
+	var lines []string
+	switch {
+	case len(t.Fields) > 0:
+		for _, f := range t.Fields {
+			lines = append(lines, fieldLine(f.Signature(), f.Description, f.Deprecated))
+		}
+	case len(t.InputFields) > 0:
+		for _, f := range t.InputFields {
+			lines = append(lines, fieldLine(f.Name+": "+f.Type.String(), f.Description, false))
+		}
+	case len(t.PossibleTypes) > 0:
+		lines = append(lines, t.PossibleTypes...)
+	default:
+		lines = append(lines, t.EnumValues...)
+	}
+
+	return lines
+}
+
+func fieldLine(signature, description string, deprecated bool) string {
+	line := signature
+	if deprecated {
+		line += style.Unselected.Render(" (deprecated)")
+	}
+	if description != "" {
+		line += style.Unselected.Render("  # " + description)
+	}
+	return line
+}
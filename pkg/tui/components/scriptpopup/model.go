@@ -0,0 +1,81 @@
+package scriptpopup
+
+import (
+	"github.com/Yalaouf/gostman/pkg/script"
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var phases = []string{script.PhasePreRequest, script.PhasePostResponse}
+
+var placeholders = []string{
+	`request.set_header("X-Nonce", crypto.random_hex())`,
+	`vars.set("orderId", response.json()["id"])`,
+}
+
+type Model struct {
+	visible bool
+	index   int
+	editors []textarea.Model
+}
+
+func New() Model {
+	editors := make([]textarea.Model, len(phases))
+	for i := range editors {
+		ta := textarea.New()
+		ta.Placeholder = placeholders[i]
+		ta.ShowLineNumbers = true
+		ta.CharLimit = 0
+		ta.SetWidth(60)
+		ta.SetHeight(12)
+		ta.FocusedStyle.CursorLine = style.TextArea
+		ta.BlurredStyle.CursorLine = style.TextArea
+		editors[i] = ta
+	}
+
+	return Model{editors: editors}
+}
+
+func (m *Model) Show(scripts storage.Scripts) tea.Cmd {
+	m.visible = true
+	m.editors[0].SetValue(scripts.PreRequest)
+	m.editors[1].SetValue(scripts.PostResponse)
+	return m.focus(0)
+}
+
+func (m *Model) Hide() {
+	m.visible = false
+	for i := range m.editors {
+		m.editors[i].Blur()
+	}
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+func (m *Model) SetSize(width, height int) {
+	for i := range m.editors {
+		m.editors[i].SetWidth(max(width-20, 30))
+		m.editors[i].SetHeight(max(height-16, 5))
+	}
+}
+
+func (m *Model) NextPhase() tea.Cmd {
+	return m.focus((m.index + 1) % len(m.editors))
+}
+
+func (m *Model) focus(index int) tea.Cmd {
+	m.editors[m.index].Blur()
+	m.index = index
+	return m.editors[index].Focus()
+}
+
+func (m Model) Scripts() storage.Scripts {
+	return storage.Scripts{
+		PreRequest:   m.editors[0].Value(),
+		PostResponse: m.editors[1].Value(),
+	}
+}
@@ -0,0 +1,9 @@
+package scriptpopup
+
+import tea "github.com/charmbracelet/bubbletea"
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.editors[m.index], cmd = m.editors[m.index].Update(msg)
+	return cmd
+}
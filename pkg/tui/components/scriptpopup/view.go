@@ -0,0 +1,42 @@
+package scriptpopup
+
+import (
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m Model) renderTabs() string {
+	var tabs []string
+
+	for i, phase := range phases {
+		if i == m.index {
+			tabs = append(tabs, style.Selected.Render("["+phase+"]"))
+		} else {
+			tabs = append(tabs, style.Unselected.Render(" "+phase+" "))
+		}
+	}
+
+	return strings.Join(tabs, " ")
+}
+
+func (m Model) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(style.ColorOrange)
+	hintStyle := style.Unselected
+
+	title := titleStyle.Render("Scripts")
+
+	help := hintStyle.Render("Starlark with request, response, vars, log, json, time, crypto, base64, uuid")
+	hint := hintStyle.Render("[tab]switch script [esc]close")
+
+	content := title + "\n\n" + m.renderTabs() + "\n\n" + m.editors[m.index].View() + "\n\n" + help + "\n" + hint
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(style.ColorPurple).
+		Padding(1, 3).
+		Render(content)
+
+	return box
+}
@@ -0,0 +1,66 @@
+package workspacepopup
+
+import (
+	"github.com/Yalaouf/gostman/pkg/storage"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type Model struct {
+	visible    bool
+	index      int
+	current    string
+	workspaces []storage.Workspace
+	err        string
+}
+
+func New() Model {
+	return Model{}
+}
+
+func (m *Model) Show(current storage.Workspace) tea.Cmd {
+	m.visible = true
+	m.current = current.Dir
+	m.index = 0
+	m.err = ""
+
+	workspaces, err := storage.ListWorkspaces()
+	if err != nil {
+		m.err = err.Error()
+	}
+
+	found := false
+	for i, ws := range workspaces {
+		if ws.Dir == current.Dir {
+			m.index = i
+			found = true
+		}
+	}
+
+	if !found {
+		workspaces = append(workspaces, current)
+		m.index = len(workspaces) - 1
+	}
+
+	m.workspaces = workspaces
+	return nil
+}
+
+func (m *Model) Hide() {
+	m.visible = false
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+func (m Model) Selected() (storage.Workspace, bool) {
+	if m.index < 0 || m.index >= len(m.workspaces) {
+		return storage.Workspace{}, false
+	}
+
+	return m.workspaces[m.index], true
+}
+
+func (m *Model) SetError(err string) {
+	m.err = err
+}
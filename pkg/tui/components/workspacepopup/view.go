@@ -0,0 +1,49 @@
+package workspacepopup
+
+import (
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m Model) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(style.ColorOrange)
+	hintStyle := style.Unselected
+
+	title := titleStyle.Render("Workspaces")
+
+	var b strings.Builder
+	for i, ws := range m.workspaces {
+		line := ws.Name
+		if ws.Dir == m.current {
+			line += " (active)"
+		}
+
+		if i == m.index {
+			b.WriteString(style.Selected.Render("▸ " + line))
+		} else {
+			b.WriteString(style.Unselected.Render("  " + line))
+		}
+		b.WriteString("\n")
+		b.WriteString(hintStyle.Render("    " + ws.Dir))
+		b.WriteString("\n")
+	}
+
+	var errView string
+	if m.err != "" {
+		errView = "\n" + style.Error.Render(m.err)
+	}
+
+	hint := hintStyle.Render("[enter]switch [j/k]navigate [esc]close")
+
+	content := title + "\n\n" + b.String() + errView + "\n" + hint
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(style.ColorPurple).
+		Padding(1, 3).
+		Render(content)
+
+	return box
+}
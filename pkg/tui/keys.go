@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"github.com/Yalaouf/gostman/pkg/runner"
+	"github.com/Yalaouf/gostman/pkg/tui/components/body"
 	"github.com/Yalaouf/gostman/pkg/tui/types"
 	"github.com/atotto/clipboard"
 	tea "github.com/charmbracelet/bubbletea"
@@ -34,14 +36,70 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleRequestMenu(msg)
 	}
 
+	if m.codePopup.Visible() {
+		return m.handleCodePopup(msg)
+	}
+
+	if m.workspaces.Visible() {
+		return m.handleWorkspacePopup(msg)
+	}
+
+	if m.testsPopup.Visible() {
+		return m.handleTestsPopup(msg)
+	}
+
+	if m.rulesPopup.Visible() {
+		return m.handleRulesPopup(msg)
+	}
+
+	if m.retryPopup.Visible() {
+		return m.handleRetryPopup(msg)
+	}
+
+	if m.connPopup.Visible() {
+		return m.handleConnectionPopup(msg)
+	}
+
+	if m.envPopup.Visible() {
+		return m.handleEnvironmentPopup(msg)
+	}
+
+	if m.extractPopup.Visible() {
+		return m.handleExtractPopup(msg)
+	}
+
+	if m.benchPopup.Visible() {
+		return m.handleBenchPopup(msg)
+	}
+
+	if m.scriptPopup.Visible() {
+		return m.handleScriptPopup(msg)
+	}
+
+	if m.schemaPopup.Visible() {
+		return m.handleSchemaPopup(msg)
+	}
+
+	if m.rpcPopup.Visible() {
+		return m.handleRPCPopup(msg)
+	}
+
 	if m.response.IsFullscreen() {
 		return m.handleResponseFullscreen(msg)
 	}
 
+	if (key == types.KeyAltEnter || key == types.KeyCtrlG) && m.method.IsWebSocket() {
+		return m.handleSocketSend()
+	}
+
+	if (key == types.KeyAltEnter || key == types.KeyCtrlG) && m.method.IsGRPC() {
+		return m.sendRPC()
+	}
+
 	if key == types.KeyAltEnter || key == types.KeyCtrlG {
 		m.response.SetLoading(true)
 		m.response.Error = ""
-		return m, m.sendRequest()
+		return m.sendRequest("")
 	}
 
 	if key == types.KeyEscape {
@@ -49,7 +107,7 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	if key == types.KeyCtrlC {
-		return m, tea.Quit
+		return m.quit()
 	}
 
 	if m.url.IsFocused() {
@@ -60,12 +118,37 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleBodyInput(msg)
 	}
 
+	if m.composer.IsFocused() {
+		return m.handleComposerInput(msg)
+	}
+
 	if m.headers.EditMode {
 		return m.handleHeadersInput(msg)
 	}
 
 	if key == types.KeyQ {
-		return m, tea.Quit
+		return m.quit()
+	}
+
+	if m.focusSection == types.FocusBody && m.method.IsWebSocket() {
+		switch key {
+		case types.KeyTab:
+			m.composer.NextType()
+			return m, nil
+		case types.KeyN:
+			m.composer.NextTemplate()
+			return m, nil
+		case types.KeyA:
+			m.composer.SaveTemplate()
+			return m, nil
+		case types.KeyX:
+			m.composer.DeleteTemplate()
+			return m, nil
+		case types.KeyP:
+			return m.pingSocket()
+		case types.KeyD:
+			return m.closeSocket()
+		}
 	}
 
 	if m.focusSection == types.FocusBody {
@@ -73,6 +156,30 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case types.KeyTab:
 			return m.handleNavigation(key), nil
 		}
+
+		if m.body.BodyType == body.TypeFormData || m.body.BodyType == body.TypeURLEncoded {
+			switch key {
+			case types.KeyJ, types.KeyK, types.KeyUp, types.KeyDown, types.KeyA, types.KeyD, types.KeyF,
+				types.KeySpace, types.KeyShiftJ, types.KeyShiftK:
+				return m.handleBodyInput(msg)
+			}
+		}
+
+		if key == types.KeyZ && !m.method.IsGRPC() {
+			m.body.NextCompression()
+			return m, nil
+		}
+
+		if m.body.BodyType == body.TypeGraphQL {
+			switch key {
+			case types.KeyN:
+				m.body.NextPane()
+				return m, nil
+			case types.KeyO:
+				m.body.NextOperation()
+				return m, nil
+			}
+		}
 	}
 
 	if m.focusSection == types.FocusHeaders {
@@ -82,12 +189,13 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 		if key == types.KeyA || key == types.KeyD || key == types.KeyP || key == types.KeyEnter ||
-			key == types.KeySpace {
+			key == types.KeySpace || key == types.KeyShiftJ || key == types.KeyShiftK {
 			return m.handleHeadersInput(msg)
 		}
 	}
 
-	if m.focusSection == types.FocusResult && m.response.IsTreeTab() && m.response.HasTree() {
+	if m.focusSection == types.FocusResult && !m.method.IsWebSocket() && m.response.IsTreeTab() &&
+		m.response.HasTree() {
 		switch key {
 		case types.KeyJ, types.KeyDown:
 			m.response.TreeDown()
@@ -114,6 +222,8 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			content := m.response.GetContent()
 			clipboard.WriteAll(content)
 			return m, nil
+		case types.KeyX:
+			return m.showExtractPopup()
 		}
 	}
 
@@ -127,7 +237,7 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case types.KeyB:
 		return m.handleFocusChange(types.FocusBody)
 	case types.KeyR:
-		if m.response.HasResponse() {
+		if m.response.HasResponse() || (m.method.IsWebSocket() && m.messages.HasEvents()) {
 			return m.handleFocusChange(types.FocusResult)
 		}
 		return m, nil
@@ -135,6 +245,44 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, m.savePopup.Show()
 	case types.KeyL:
 		return m, m.requestMenu.Show()
+	case types.KeyC:
+		return m, m.codePopup.Show(m.buildRequestModel())
+	case types.KeyT:
+		return m, m.testsPopup.Show(formatAssertions(m.assertions))
+	case types.KeyV:
+		return m, m.rulesPopup.Show(formatExtractions(m.extractions))
+	case types.KeyShiftP:
+		return m, m.scriptPopup.Show(m.scripts)
+	case types.KeyShiftA:
+		if m.method.IsWebSocket() || m.method.IsGRPC() {
+			return m, nil
+		}
+		return m, m.retryPopup.Show(runner.FormatRetry(m.retry))
+	case types.KeyShiftH:
+		if m.method.IsWebSocket() || m.method.IsGRPC() {
+			return m, nil
+		}
+		return m, m.connPopup.Show(runner.FormatConnection(m.connection))
+	case types.KeyShiftB:
+		return m, m.benchPopup.Show()
+	case types.KeyShiftS:
+		return m.stopStream(), nil
+	case types.KeyShiftR:
+		return m.reconnectStream()
+	case types.KeyShiftZ:
+		if !m.method.IsWebSocket() && !m.method.IsGRPC() {
+			m.rawResponse = !m.rawResponse
+		}
+		return m, nil
+	case types.KeyI:
+		if m.method.IsGRPC() {
+			return m.showRPCPopup()
+		}
+		return m.fetchSchema()
+	case types.KeyE:
+		return m, m.envPopup.Show(m.storage.ListEnvironments(), m.environmentID)
+	case types.KeyW:
+		return m, m.workspaces.Show(m.storage.Workspace())
 	}
 
 	switch key {
@@ -147,6 +295,10 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleScroll(key), nil
 	}
 
+	if m.method.IsWebSocket() && m.focusSection == types.FocusResult {
+		return m, nil
+	}
+
 	if key == types.KeyF && m.focusSection == types.FocusResult && m.response.HasResponse() {
 		m.response.ToggleFullscreen()
 		return m, nil
@@ -164,3 +316,8 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	return m, nil
 }
+
+func (m Model) quit() (Model, tea.Cmd) {
+	m.response.Response.Remove()
+	return m, tea.Quit
+}
@@ -10,6 +10,9 @@ func (m Model) handleEnter() (Model, tea.Cmd) {
 	case types.FocusMethod:
 		return m.handleFocusChange(types.FocusURL)
 	case types.FocusBody:
+		if m.method.IsWebSocket() {
+			return m, m.composer.EnterEditMode()
+		}
 		return m, m.body.EnterEditMode()
 	case types.FocusHeaders:
 		return m, m.headers.EnterEditMode()
@@ -23,6 +26,10 @@ func (m Model) handleEscape() (Model, tea.Cmd) {
 	case types.FocusMethod:
 		m.focusSection = types.FocusURL
 	case types.FocusBody:
+		if m.composer.IsFocused() {
+			m.composer.ExitEditMode()
+			return m, nil
+		}
 		if m.body.IsFocused() {
 			m.body.ExitEditMode()
 			return m, nil
@@ -13,6 +13,8 @@ func (m Model) handleFocusChange(section types.FocusSection) (Model, tea.Cmd) {
 	m.headers.Blur()
 	m.body.Blur()
 	m.response.Blur()
+	m.composer.Blur()
+	m.messages.Blur()
 
 	switch section {
 	case types.FocusMethod:
@@ -25,8 +27,15 @@ func (m Model) handleFocusChange(section types.FocusSection) (Model, tea.Cmd) {
 		m.headers.Focus()
 		return m, nil
 	case types.FocusBody:
+		if m.method.IsWebSocket() {
+			return m, m.composer.Focus()
+		}
 		return m, m.body.Focus()
 	case types.FocusResult:
+		if m.method.IsWebSocket() {
+			m.messages.Focus()
+			return m, nil
+		}
 		m.response.Focus()
 		return m, nil
 	}
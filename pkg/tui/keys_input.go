@@ -12,6 +12,11 @@ func (m Model) handleBodyInput(msg tea.Msg) (Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m Model) handleComposerInput(msg tea.Msg) (Model, tea.Cmd) {
+	cmd := m.composer.Update(msg)
+	return m, cmd
+}
+
 func (m Model) handleHeadersInput(msg tea.Msg) (Model, tea.Cmd) {
 	cmd := m.headers.Update(msg)
 	return m, cmd
@@ -10,12 +10,22 @@ func (m Model) handleNavigation(key string) Model {
 		} else {
 			m.method.Previous()
 		}
+		m.syncContentType()
 	case types.FocusBody:
 		if key == types.KeyTab {
 			m.body.NextType()
 			m.syncContentType()
 		}
 	case types.FocusResult:
+		if m.method.IsWebSocket() {
+			if key == types.KeyJ || key == types.KeyDown {
+				m.messages.ScrollDown(1)
+			} else if key == types.KeyK || key == types.KeyUp {
+				m.messages.ScrollUp(1)
+			}
+			return m
+		}
+
 		switch key {
 		case types.KeyJ, types.KeyDown:
 			m.response.ScrollDown(1)
@@ -33,6 +43,15 @@ func (m Model) handleScroll(key string) Model {
 		return m
 	}
 
+	if m.method.IsWebSocket() {
+		if key == types.KeyG {
+			m.messages.GotoTop()
+		} else {
+			m.messages.GotoBottom()
+		}
+		return m
+	}
+
 	if key == types.KeyG {
 		m.response.GotoTop()
 	} else {
@@ -1,8 +1,11 @@
 package tui
 
 import (
+	"slices"
+
+	"github.com/Yalaouf/gostman/pkg/extraction"
+	"github.com/Yalaouf/gostman/pkg/runner"
 	"github.com/Yalaouf/gostman/pkg/storage"
-	"github.com/Yalaouf/gostman/pkg/tui/components/body"
 	"github.com/Yalaouf/gostman/pkg/tui/types"
 	"github.com/atotto/clipboard"
 	tea "github.com/charmbracelet/bubbletea"
@@ -23,24 +26,7 @@ func (m Model) handleSavePopup(msg tea.KeyMsg) (Model, tea.Cmd) {
 			return m, nil
 		}
 
-		req := &storage.Request{
-			Name:    name,
-			Method:  string(m.method.Selected()),
-			URL:     m.url.Value(),
-			Headers: m.headers.EnabledHeaders(),
-			Body:    m.body.Value(),
-		}
-
-		switch m.body.BodyType {
-		case body.TypeJSON:
-			req.BodyType = "json"
-		case body.TypeFormData:
-			req.BodyType = "form-data"
-		case body.TypeURLEncoded:
-			req.BodyType = "urlencoded"
-		default:
-			req.BodyType = "none"
-		}
+		req := m.buildStorageRequest(name)
 
 		if err := m.storage.SaveRequest(req); err != nil {
 			m.savePopup.SetError(err.Error())
@@ -60,6 +46,126 @@ func (m Model) handleRequestMenu(msg tea.KeyMsg) (Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m Model) handleCodePopup(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case types.KeyEscape, types.KeyQ:
+		m.codePopup.Hide()
+		return m, nil
+	case types.KeyEnter, types.KeyY:
+		if code := m.codePopup.Code(); code != "" {
+			m.codePopup.SetCopied(clipboard.WriteAll(code))
+		}
+		return m, nil
+	}
+
+	cmd := m.codePopup.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleWorkspacePopup(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case types.KeyEscape, types.KeyQ:
+		m.workspaces.Hide()
+		return m, nil
+	case types.KeyEnter:
+		ws, ok := m.workspaces.Selected()
+		if !ok {
+			return m, nil
+		}
+
+		s, err := storage.Open(ws.Dir)
+		if err != nil {
+			m.workspaces.SetError(err.Error())
+			return m, nil
+		}
+
+		m.switchStorage(s)
+		m.workspaces.Hide()
+		return m, nil
+	}
+
+	cmd := m.workspaces.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleTestsPopup(msg tea.KeyMsg) (Model, tea.Cmd) {
+	cmd := m.testsPopup.Update(msg)
+	m.assertions = parseAssertions(m.testsPopup.Rules())
+	return m, cmd
+}
+
+func (m Model) handleRulesPopup(msg tea.KeyMsg) (Model, tea.Cmd) {
+	cmd := m.rulesPopup.Update(msg)
+	m.extractions = parseExtractions(m.rulesPopup.Rules())
+	return m, cmd
+}
+
+func (m Model) handleRetryPopup(msg tea.KeyMsg) (Model, tea.Cmd) {
+	cmd := m.retryPopup.Update(msg)
+	m.retry = runner.ParseRetry(m.retryPopup.Rules())
+	return m, cmd
+}
+
+func (m Model) handleConnectionPopup(msg tea.KeyMsg) (Model, tea.Cmd) {
+	cmd := m.connPopup.Update(msg)
+	m.connection = runner.ParseConnection(m.connPopup.Rules())
+	return m, cmd
+}
+
+func (m Model) handleEnvironmentPopup(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case types.KeyEscape, types.KeyQ:
+		m.envPopup.Hide()
+		return m, nil
+	case types.KeyEnter:
+		m.environmentID = m.envPopup.Selected()
+		m.envPopup.Hide()
+		return m, nil
+	}
+
+	cmd := m.envPopup.Update(msg)
+	return m, cmd
+}
+
+func (m Model) showExtractPopup() (Model, tea.Cmd) {
+	node := m.response.GetSelectedNode()
+	if node == nil {
+		return m, nil
+	}
+
+	return m, m.extractPopup.Show(node.Path, m.response.GetSelectedValue())
+}
+
+func (m Model) handleExtractPopup(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case types.KeyEscape:
+		m.extractPopup.Hide()
+		return m, nil
+	case types.KeyTab:
+		m.extractPopup.ToggleScope()
+		return m, nil
+	case types.KeyEnter:
+		rule := m.extractPopup.Extraction()
+		if err := extraction.Validate(rule); err != nil {
+			m.extractPopup.SetError(err.Error())
+			return m, nil
+		}
+
+		vars := map[string]string{rule.Variable: m.extractPopup.Value()}
+		if err := m.setVariables(rule.Scope, vars); err != nil {
+			m.extractPopup.SetError(err.Error())
+			return m, nil
+		}
+
+		m.extractions = append(slices.Clone(m.extractions), rule)
+		m.extractPopup.Hide()
+		return m, nil
+	}
+
+	cmd := m.extractPopup.Update(msg)
+	return m, cmd
+}
+
 func (m Model) handleResponseFullscreen(msg tea.KeyMsg) (Model, tea.Cmd) {
 	key := msg.String()
 
@@ -109,3 +215,86 @@ func (m Model) handleResponseFullscreen(msg tea.KeyMsg) (Model, tea.Cmd) {
 
 	return m, nil
 }
+
+func (m Model) handleBenchPopup(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.benchPopup.Running() {
+		if msg.String() == types.KeyEscape {
+			m.stopBench()
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case types.KeyEscape:
+		m.benchPopup.Hide()
+		return m, nil
+	case types.KeyEnter:
+		return m.startBench()
+	case types.KeyTab, types.KeyDown:
+		return m, m.benchPopup.NextField()
+	case types.KeyShiftTab, types.KeyUp:
+		return m, m.benchPopup.PrevField()
+	}
+
+	cmd := m.benchPopup.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleScriptPopup(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case types.KeyEscape:
+		m.scriptPopup.Hide()
+		return m, nil
+	case types.KeyTab:
+		return m, m.scriptPopup.NextPhase()
+	}
+
+	cmd := m.scriptPopup.Update(msg)
+	m.scripts = m.scriptPopup.Scripts()
+	return m, cmd
+}
+
+func (m Model) handleSchemaPopup(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case types.KeyEscape, types.KeyQ:
+		m.schemaPopup.Hide()
+		return m, nil
+	}
+
+	cmd := m.schemaPopup.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleRPCPopup(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.rpcPopup.Editing() {
+		switch msg.String() {
+		case types.KeyEscape:
+			m.rpcPopup.StopEditing()
+			return m, nil
+		case types.KeyEnter:
+			m.rpcPopup.StopEditing()
+			m.rpcPopup.Reload()
+			m.protoFiles = m.rpcPopup.Files()
+			m.rpcRegistry = nil
+			return m.discoverRPC()
+		}
+
+		return m, m.rpcPopup.Update(msg)
+	}
+
+	switch msg.String() {
+	case types.KeyEscape, types.KeyQ:
+		m.rpcPopup.Hide()
+		return m, nil
+	case types.KeyEnter:
+		return m.selectRPCMethod(), nil
+	case types.KeyF:
+		return m, m.rpcPopup.EditFiles()
+	case types.KeyR:
+		m.rpcPopup.Reload()
+		m.rpcRegistry = nil
+		return m.discoverRPC()
+	}
+
+	return m, m.rpcPopup.Update(msg)
+}
@@ -1,26 +1,51 @@
 package tui
 
 import (
-	"strings"
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
 
+	"github.com/Yalaouf/gostman/pkg/assertion"
+	"github.com/Yalaouf/gostman/pkg/extraction"
 	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/rpc"
+	"github.com/Yalaouf/gostman/pkg/runner"
+	"github.com/Yalaouf/gostman/pkg/script"
+	"github.com/Yalaouf/gostman/pkg/socket"
+	"github.com/Yalaouf/gostman/pkg/sse"
 	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/Yalaouf/gostman/pkg/tui/components/benchpopup"
 	"github.com/Yalaouf/gostman/pkg/tui/components/body"
+	"github.com/Yalaouf/gostman/pkg/tui/components/codepopup"
+	"github.com/Yalaouf/gostman/pkg/tui/components/composer"
+	"github.com/Yalaouf/gostman/pkg/tui/components/environmentpopup"
+	"github.com/Yalaouf/gostman/pkg/tui/components/extractpopup"
 	"github.com/Yalaouf/gostman/pkg/tui/components/headers"
 	"github.com/Yalaouf/gostman/pkg/tui/components/help"
+	"github.com/Yalaouf/gostman/pkg/tui/components/messagelog"
 	"github.com/Yalaouf/gostman/pkg/tui/components/method"
 	"github.com/Yalaouf/gostman/pkg/tui/components/requestmenu"
 	"github.com/Yalaouf/gostman/pkg/tui/components/response"
+	"github.com/Yalaouf/gostman/pkg/tui/components/rpcpopup"
+	"github.com/Yalaouf/gostman/pkg/tui/components/rulespopup"
 	"github.com/Yalaouf/gostman/pkg/tui/components/savepopup"
+	"github.com/Yalaouf/gostman/pkg/tui/components/schemapopup"
+	"github.com/Yalaouf/gostman/pkg/tui/components/scriptpopup"
 	"github.com/Yalaouf/gostman/pkg/tui/components/url"
+	"github.com/Yalaouf/gostman/pkg/tui/components/workspacepopup"
 	"github.com/Yalaouf/gostman/pkg/tui/types"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 type requestMsg struct {
-	response request.Response
-	err      error
+	response  request.Response
+	tests     []assertion.Result
+	extracted []extraction.Result
+	console   []string
+	updates   []script.Variable
+	err       error
 }
 
 type Model struct {
@@ -30,7 +55,16 @@ type Model struct {
 	loading  bool
 	showHelp bool
 
-	focusSection types.FocusSection
+	focusSection  types.FocusSection
+	collectionID  string
+	environmentID string
+	session       map[string]string
+	assertions    []storage.Assertion
+	extractions   []storage.Extraction
+	scripts       storage.Scripts
+	rawResponse   bool
+	retry         storage.RetryPolicy
+	connection    storage.Connection
 
 	method   method.Model
 	url      url.Model
@@ -38,10 +72,32 @@ type Model struct {
 	body     body.Model
 	response response.Model
 	help     help.Model
-
-	storage     *storage.Storage
-	savePopup   savepopup.Model
-	requestMenu requestmenu.Model
+	composer composer.Model
+	messages messagelog.Model
+
+	storage      *storage.Storage
+	savePopup    savepopup.Model
+	requestMenu  requestmenu.Model
+	codePopup    codepopup.Model
+	workspaces   workspacepopup.Model
+	testsPopup   rulespopup.Model
+	rulesPopup   rulespopup.Model
+	retryPopup   rulespopup.Model
+	connPopup    rulespopup.Model
+	envPopup     environmentpopup.Model
+	extractPopup extractpopup.Model
+	benchPopup   benchpopup.Model
+	scriptPopup  scriptpopup.Model
+	schemaPopup  schemapopup.Model
+	benchCancel  context.CancelFunc
+	benchSent    *atomic.Int64
+	socketConn   *socket.Conn
+	rpcPopup     rpcpopup.Model
+	rpcRegistry  *rpc.Registry
+	rpcMethod    string
+	protoFiles   []string
+	streamCancel context.CancelFunc
+	streamEvents chan tea.Msg
 }
 
 func New(s *storage.Storage) Model {
@@ -53,12 +109,35 @@ func New(s *storage.Storage) Model {
 		body:         body.New(),
 		response:     response.New(),
 		help:         help.New(),
+		composer:     composer.New(),
+		messages:     messagelog.New(),
 		storage:      s,
 		savePopup:    savepopup.New(),
 		requestMenu:  requestmenu.New(s),
+		codePopup:    codepopup.New(),
+		workspaces:   workspacepopup.New(),
+		testsPopup:   newTestsPopup(),
+		rulesPopup:   newExtractionsPopup(),
+		retryPopup:   newRetryPopup(),
+		connPopup:    newConnectionPopup(),
+		envPopup:     environmentpopup.New(),
+		extractPopup: extractpopup.New(),
+		benchPopup:   benchpopup.New(),
+		scriptPopup:  scriptpopup.New(),
+		schemaPopup:  schemapopup.New(),
+		rpcPopup:     rpcpopup.New(),
+		session:      map[string]string{},
 	}
 }
 
+func (m *Model) switchStorage(s *storage.Storage) {
+	m.storage = s
+	m.requestMenu = requestmenu.New(s)
+	m.collectionID = ""
+	m.environmentID = ""
+	m.session = map[string]string{}
+}
+
 func (m Model) Init() tea.Cmd {
 	return textinput.Blink
 }
@@ -74,6 +153,48 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case requestmenu.LoadRequestMsg:
 		return m.handleLoadRequest(msg), nil
 
+	case benchTickMsg:
+		return m.handleBenchTick()
+
+	case benchDoneMsg:
+		return m.handleBenchDone(msg), nil
+
+	case schemaMsg:
+		return m.handleSchema(msg), nil
+
+	case streamStartMsg:
+		return m.handleStreamStart(msg)
+
+	case streamEventMsg:
+		return m.handleStreamEvent(msg)
+
+	case progressMsg:
+		return m.handleProgress(msg)
+
+	case streamClosedMsg:
+		return m.handleStreamClosed(msg), nil
+
+	case rpcRegistryMsg:
+		return m.handleRPCRegistry(msg), nil
+
+	case rpcResponseMsg:
+		return m.handleRPCResponse(msg)
+
+	case rpcMsg:
+		return m.handleRPCComplete(msg), nil
+
+	case socketConnectedMsg:
+		return m.handleSocketConnected(msg)
+
+	case socketEventMsg:
+		return m.handleSocketEvent(msg)
+
+	case socketClosedMsg:
+		return m.handleSocketClosed(msg), nil
+
+	case socketSentMsg:
+		return m.handleSocketSent(msg), nil
+
 	case tea.KeyMsg:
 		return m.handleKeyMsg(msg)
 	}
@@ -82,6 +203,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleURLInput(msg)
 	}
 
+	if m.focusSection == types.FocusBody && m.method.IsWebSocket() {
+		return m.handleComposerInput(msg)
+	}
+
 	if m.focusSection == types.FocusBody {
 		return m.handleBodyInput(msg)
 	}
@@ -106,19 +231,43 @@ func (m Model) handleWindowSize(msg tea.WindowSizeMsg) Model {
 
 	m.headers.SetSize(leftWidth, sectionHeight)
 	m.body.SetSize(leftWidth, sectionHeight)
+	m.composer.SetSize(leftWidth, sectionHeight)
 	m.response.SetSize(rightWidth, panelHeight-1)
+	m.messages.SetSize(rightWidth, panelHeight-1)
 	m.help.SetSize(msg.Width, msg.Height)
+	m.codePopup.SetSize(msg.Width, msg.Height)
+	m.scriptPopup.SetSize(msg.Width, msg.Height)
+	m.schemaPopup.SetSize(msg.Width, msg.Height)
+	m.rpcPopup.SetSize(msg.Width, msg.Height)
 	return m
 }
 
 func (m Model) handleRequestComplete(msg requestMsg) Model {
 	m.response.SetLoading(false)
+	m.response.Response.Remove()
+
+	err := m.applyScriptUpdates(msg.updates)
 	if msg.err != nil {
 		m.response.SetError(msg.err.Error())
+		var retryErr *request.RetryError
+		if errors.As(msg.err, &retryErr) {
+			m.response.SetAttempts(retryErr.Attempts)
+		}
 		return m
 	}
 
-	m.response.SetResponse(msg.response)
+	if err != nil {
+		msg.console = append(msg.console, err.Error())
+	}
+
+	if m.response.IsStream() && request.IsEventStream(msg.response.Headers) {
+		m.response.FinishStream(msg.response)
+	} else {
+		m.response.SetResponse(msg.response)
+	}
+	m.response.SetTests(msg.tests)
+	m.response.SetExtractions(m.applyExtractions(msg.extracted))
+	m.response.SetConsole(msg.console)
 	return m
 }
 
@@ -128,10 +277,26 @@ func (m Model) handleLoadRequest(msg requestmenu.LoadRequestMsg) Model {
 		return m
 	}
 
+	m.collectionID = req.CollectionID
+	m.assertions = req.Assertions
+	m.extractions = req.Extractions
+	m.scripts = req.Scripts
+	m.rawResponse = req.DisableDecompression
+	m.retry = req.Retry.Copy()
+	m.connection = req.Connection
 	m.method.SetMethod(request.HTTPMethod(req.Method))
+	if req.IsWebSocket() {
+		m.method.SetMethod(method.WebSocket)
+	}
+	if req.IsGRPC() {
+		m.method.SetMethod(method.GRPC)
+	}
+	m.rpcMethod = req.RPC
+	m.protoFiles = slices.Clone(req.ProtoFiles)
+	m.rpcRegistry = nil
+	m.composer.SetTemplates(slices.Clone(req.Messages))
 	m.url.SetValue(req.URL)
 	m.headers.SetHeaders(req.Headers)
-	m.body.SetValue(req.Body)
 
 	switch req.BodyType {
 	case "json":
@@ -140,25 +305,37 @@ func (m Model) handleLoadRequest(msg requestmenu.LoadRequestMsg) Model {
 		m.body.SetType(body.TypeFormData)
 	case "urlencoded":
 		m.body.SetType(body.TypeURLEncoded)
+	case "graphql":
+		m.body.SetType(body.TypeGraphQL)
+	case "text":
+		m.body.SetType(body.TypeText)
+	case "xml":
+		m.body.SetType(body.TypeXML)
+	case "html":
+		m.body.SetType(body.TypeHTML)
+	case "custom":
+		m.body.SetType(body.TypeCustom)
+	case "binary":
+		m.body.SetType(body.TypeBinary)
 	default:
 		m.body.SetType(body.TypeNone)
 	}
+	m.body.SetValue(req.Body)
+	m.body.SetForm(slices.Clone(req.Form))
+	m.body.Compression = request.ParseCompression(req.Compression)
 
 	m.syncContentType()
 	return m
 }
 
 func (m *Model) syncContentType() {
-	var contentType string
+	if m.body.BodyType == body.TypeCustom && !m.method.IsGRPC() {
+		m.headers.ReleaseContentType()
+		return
+	}
 
-	switch m.body.BodyType {
-	case body.TypeJSON:
-		contentType = "application/json"
-	case body.TypeFormData:
-		contentType = "multipart/form-data"
-	case body.TypeURLEncoded:
-		contentType = "application/x-www-form-urlencoded"
-	case body.TypeNone:
+	contentType := m.body.BodyType.ContentType()
+	if m.method.IsGRPC() {
 		contentType = ""
 	}
 
@@ -166,40 +343,136 @@ func (m *Model) syncContentType() {
 }
 
 func (m Model) buildRequestModel() *request.Model {
-	req := request.NewModel()
+	return runner.Build(m.buildStorageRequest(""), m.variables())
+}
 
-	req.SetURL(strings.TrimSpace(m.url.Value()))
-	req.SetMethod(m.method.Selected())
-	req.SetBody(m.body.Value())
-	req.SetTimeout(request.DefaultTimeout)
+func (m Model) buildStorageRequest(name string) *storage.Request {
+	req := &storage.Request{
+		Name:        name,
+		Method:      string(m.method.Selected()),
+		URL:         m.url.Value(),
+		Headers:     m.headers.Entries(),
+		Body:        m.body.Value(),
+		Form:        m.body.FormFields(),
+		Assertions:  slices.Clone(m.assertions),
+		Extractions: slices.Clone(m.extractions),
+		Scripts:     m.scripts,
+	}
+
+	if m.method.IsWebSocket() {
+		req.Protocol = storage.ProtocolWebSocket
+		req.Method = string(request.GET)
+		req.Body = ""
+		req.Form = nil
+		req.BodyType = "none"
+		req.Messages = slices.Clone(m.composer.Templates)
+		return req
+	}
+
+	if m.method.IsGRPC() {
+		req.Protocol = storage.ProtocolGRPC
+		req.Method = string(request.POST)
+		req.RPC = m.rpcMethod
+		req.ProtoFiles = slices.Clone(m.protoFiles)
+	} else {
+		req.Compression = string(m.body.Compression)
+		req.DisableDecompression = m.rawResponse
+		req.Retry = m.retry.Copy()
+		req.Connection = m.connection
+	}
 
 	switch m.body.BodyType {
 	case body.TypeJSON:
-		req.SetBodyType(request.BodyTypeJSON)
+		req.BodyType = "json"
 	case body.TypeFormData:
-		req.SetBodyType(request.BodyTypeFormData)
+		req.BodyType = "form-data"
 	case body.TypeURLEncoded:
-		req.SetBodyType(request.BodyTypeURLEncoded)
+		req.BodyType = "urlencoded"
+	case body.TypeGraphQL:
+		req.BodyType = "graphql"
+	case body.TypeText:
+		req.BodyType = "text"
+	case body.TypeXML:
+		req.BodyType = "xml"
+	case body.TypeHTML:
+		req.BodyType = "html"
+	case body.TypeCustom:
+		req.BodyType = "custom"
+	case body.TypeBinary:
+		req.BodyType = "binary"
 	default:
-		req.SetBodyType(request.BodyTypeNone)
-	}
-
-	for key, value := range m.headers.EnabledHeaders() {
-		req.AddHeader(strings.TrimSpace(key), strings.TrimSpace(value))
+		req.BodyType = "none"
 	}
 
 	return req
 }
 
-func (m Model) sendRequest() tea.Cmd {
-	return func() tea.Msg {
-		req := m.buildRequestModel()
+func (m Model) sendRequest(lastEventID string) (Model, tea.Cmd) {
+	saved := m.buildStorageRequest("")
+	collection, _ := m.storage.GetCollection(m.collectionID)
+	vars := m.variables()
+
+	m = m.stopStream()
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan tea.Msg, streamBuffer)
+	m.streamCancel = cancel
+	m.streamEvents = events
+
+	send := func() tea.Msg {
+		defer close(events)
+		defer cancel()
+
+		req, sc, err := runner.Prepare(collection, saved, vars)
+		if err != nil {
+			return requestMsg{err: err, updates: sc.Updates}
+		}
+
+		req.SetContext(ctx)
+		req.Stream = streamTo(events)
+		req.SetProgress(progressTo(events))
+		req.SetMaxBodySize(m.storage.MaxBodySize())
+		if lastEventID != "" {
+			req.SetHeader(sse.LastEventID, lastEventID)
+		}
 
 		res, err := request.SendRequest(req)
+		m.addHistory(runner.Resolved(saved, req), res, err)
+		if err != nil {
+			return requestMsg{err: err, updates: sc.Updates}
+		}
+
+		msg := requestMsg{response: *res, tests: assertion.Evaluate(saved.Assertions, res)}
+
+		err = runner.Finish(collection, saved, sc, res)
+		msg.console, msg.updates = sc.Logs, sc.Updates
 		if err != nil {
-			return requestMsg{err: err}
+			msg.console = append(msg.console, err.Error())
+			return msg
 		}
 
-		return requestMsg{response: *res}
+		if res.StatusCode < 400 && assertion.Passed(msg.tests) {
+			msg.extracted = extraction.Extract(saved.Extractions, res)
+		}
+
+		return msg
 	}
+
+	return m, tea.Batch(send, waitForStream(events))
+}
+
+func (m Model) addHistory(req *storage.Request, res *request.Response, err error) {
+	entry := &storage.HistoryEntry{Request: req}
+
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Response = &storage.HistoryResponse{
+			StatusCode: res.StatusCode,
+			Headers:    res.Headers,
+			Body:       res.Body,
+			TimeTaken:  res.TimeTaken,
+		}
+	}
+
+	m.storage.AddHistory(entry)
 }
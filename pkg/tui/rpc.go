@@ -0,0 +1,188 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Yalaouf/gostman/pkg/rpc"
+	"github.com/Yalaouf/gostman/pkg/tui/components/body"
+	tea "github.com/charmbracelet/bubbletea"
+	"google.golang.org/grpc"
+)
+
+const discoverTimeout = 15 * time.Second
+
+var errNoRPCMethod = errors.New("no gRPC method selected, press i to pick one")
+
+type rpcRegistryMsg struct {
+	registry *rpc.Registry
+	err      error
+}
+
+type rpcResponseMsg struct {
+	events   chan tea.Msg
+	response string
+}
+
+type rpcMsg struct {
+	events   chan tea.Msg
+	result   *rpc.Result
+	registry *rpc.Registry
+	err      error
+}
+
+func discover(ctx context.Context, conn grpc.ClientConnInterface, files []string) (*rpc.Registry, error) {
+	ctx, cancel := context.WithTimeout(ctx, discoverTimeout)
+	defer cancel()
+
+	if len(files) > 0 {
+		return rpc.LoadProtos(ctx, files)
+	}
+
+	return rpc.Reflect(ctx, conn)
+}
+
+func (m Model) discoverRPC() (Model, tea.Cmd) {
+	target := m.buildRequestModel().URL
+	files := m.protoFiles
+
+	if target == "" && len(files) == 0 {
+		m.rpcPopup.SetError("URL is empty")
+		return m, nil
+	}
+
+	load := func() tea.Msg {
+		conn, err := rpc.Dial(target)
+		if err != nil {
+			return rpcRegistryMsg{err: err}
+		}
+		defer conn.Close()
+
+		registry, err := discover(context.Background(), conn, files)
+		return rpcRegistryMsg{registry: registry, err: err}
+	}
+
+	return m, load
+}
+
+func (m Model) showRPCPopup() (Model, tea.Cmd) {
+	cmd := m.rpcPopup.Show(m.protoFiles)
+
+	if m.rpcRegistry != nil {
+		m.rpcPopup.SetRegistry(m.rpcRegistry, m.rpcMethod)
+		return m, cmd
+	}
+
+	m, load := m.discoverRPC()
+	return m, tea.Batch(cmd, load)
+}
+
+func (m Model) handleRPCRegistry(msg rpcRegistryMsg) Model {
+	if msg.err != nil {
+		m.rpcPopup.SetError(msg.err.Error())
+		return m
+	}
+
+	m.rpcRegistry = msg.registry
+	m.rpcPopup.SetRegistry(msg.registry, m.rpcMethod)
+	return m
+}
+
+func (m Model) selectRPCMethod() Model {
+	method, ok := m.rpcPopup.Selected()
+	if !ok {
+		return m
+	}
+
+	if method.FullName() != m.rpcMethod {
+		m.rpcMethod = method.FullName()
+		m.body.SetType(body.TypeJSON)
+		m.body.SetValue(rpc.Template(method.Input))
+		m.syncContentType()
+	}
+
+	m.rpcPopup.Hide()
+	return m
+}
+
+func (m Model) sendRPC() (Model, tea.Cmd) {
+	model := m.buildRequestModel()
+	registry := m.rpcRegistry
+	files := m.protoFiles
+	name := m.rpcMethod
+
+	m = m.stopStream()
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan tea.Msg, streamBuffer)
+	m.streamCancel = cancel
+	m.streamEvents = events
+	m.response.StartCall()
+
+	call := func() tea.Msg {
+		defer close(events)
+		defer cancel()
+
+		if name == "" {
+			return rpcMsg{events: events, err: errNoRPCMethod}
+		}
+
+		conn, err := rpc.Dial(model.URL)
+		if err != nil {
+			return rpcMsg{events: events, err: err}
+		}
+		defer conn.Close()
+
+		if registry == nil {
+			registry, err = discover(ctx, conn, files)
+			if err != nil {
+				return rpcMsg{events: events, err: err}
+			}
+		}
+
+		method, err := registry.Find(name)
+		if err != nil {
+			return rpcMsg{events: events, registry: registry, err: err}
+		}
+
+		result, err := rpc.Invoke(ctx, conn, method, model.HTTPHeader(), model.Body, func(response string) {
+			events <- rpcResponseMsg{events: events, response: response}
+		})
+
+		return rpcMsg{events: events, result: result, registry: registry, err: err}
+	}
+
+	return m, tea.Batch(call, waitForStream(events))
+}
+
+func (m Model) rpcTitle() string {
+	if m.rpcMethod == "" {
+		return "Message · [i] pick a method"
+	}
+	return "Message · " + m.rpcMethod
+}
+
+func (m Model) handleRPCResponse(msg rpcResponseMsg) (Model, tea.Cmd) {
+	if msg.events == m.streamEvents {
+		m.response.AppendCallResponse(msg.response)
+	}
+	return m, waitForStream(msg.events)
+}
+
+func (m Model) handleRPCComplete(msg rpcMsg) Model {
+	if msg.registry != nil {
+		m.rpcRegistry = msg.registry
+	}
+
+	if msg.events != m.streamEvents && m.streamEvents != nil {
+		return m
+	}
+
+	if msg.err != nil {
+		m.response.SetError(msg.err.Error())
+		return m
+	}
+
+	m.response.FinishCall(msg.result)
+	return m
+}
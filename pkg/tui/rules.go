@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/Yalaouf/gostman/pkg/assertion"
+	"github.com/Yalaouf/gostman/pkg/extraction"
+	"github.com/Yalaouf/gostman/pkg/runner"
+	"github.com/Yalaouf/gostman/pkg/storage"
+	"github.com/Yalaouf/gostman/pkg/tui/components/rulespopup"
+)
+
+func newTestsPopup() rulespopup.Model {
+	return rulespopup.New(rulespopup.Config{
+		Title:       "Tests",
+		Placeholder: "status == 200",
+		Examples: []string{
+			"status == 200 · status in 2xx",
+			"header Content-Type matches ^application/json",
+			"json $.id exists · json $.name == \"bob\" · json $.tags contains admin",
+			"time < 500ms · schema {\"type\": \"object\"}",
+		},
+		Normalize: func(line string) (string, error) {
+			a, err := assertion.Parse(line)
+			if err != nil {
+				return "", err
+			}
+			return assertion.Format(a), nil
+		},
+	})
+}
+
+func newExtractionsPopup() rulespopup.Model {
+	return rulespopup.New(rulespopup.Config{
+		Title:       "Extract Variables",
+		Placeholder: "json $.token -> token",
+		Examples: []string{
+			"json $.data.token -> token",
+			"header X-Request-Id -> collection:requestId",
+			"regex order-(\\d+) -> orderId",
+			"cookie session -> sessionId",
+		},
+		Normalize: func(line string) (string, error) {
+			e, err := extraction.Parse(line)
+			if err != nil {
+				return "", err
+			}
+			return extraction.Format(e), nil
+		},
+	})
+}
+
+func newRetryPopup() rulespopup.Model {
+	return rulespopup.New(rulespopup.Config{
+		Title:       "Retry",
+		Placeholder: "attempts 3",
+		Examples: []string{
+			"attempts 3 · backoff 500ms · max-backoff 10s",
+			"status 429 502 503 504",
+			"error reset refused eof timeout",
+			"only GET, HEAD, PUT, DELETE, OPTIONS or an Idempotency-Key are retried",
+		},
+		Normalize: func(line string) (string, error) {
+			var p storage.RetryPolicy
+			if err := runner.ParseRetryRule(&p, line); err != nil {
+				return "", err
+			}
+			return runner.FormatRetry(p)[0], nil
+		},
+	})
+}
+
+func newConnectionPopup() rulespopup.Model {
+	return rulespopup.New(rulespopup.Config{
+		Title:       "Connection",
+		Placeholder: "version h2",
+		Examples: []string{
+			"version auto · version http/1.1",
+			"version h2 (https) · version h2c (prior knowledge)",
+			"keep-alive off",
+			"max-idle 10",
+		},
+		Normalize: func(line string) (string, error) {
+			var c storage.Connection
+			if err := runner.ParseConnectionRule(&c, line); err != nil {
+				return "", err
+			}
+			if lines := runner.FormatConnection(c); len(lines) > 0 {
+				return lines[0], nil
+			}
+			return strings.TrimSpace(line), nil
+		},
+	})
+}
+
+func formatAssertions(assertions []storage.Assertion) []string {
+	lines := make([]string, len(assertions))
+	for i, a := range assertions {
+		lines[i] = assertion.Format(a)
+	}
+
+	return lines
+}
+
+func parseAssertions(lines []string) []storage.Assertion {
+	var assertions []storage.Assertion
+	for _, line := range lines {
+		if a, err := assertion.Parse(line); err == nil {
+			assertions = append(assertions, a)
+		}
+	}
+
+	return assertions
+}
+
+func formatExtractions(extractions []storage.Extraction) []string {
+	lines := make([]string, len(extractions))
+	for i, e := range extractions {
+		lines[i] = extraction.Format(e)
+	}
+
+	return lines
+}
+
+func parseExtractions(lines []string) []storage.Extraction {
+	var extractions []storage.Extraction
+	for _, line := range lines {
+		if e, err := extraction.Parse(line); err == nil {
+			extractions = append(extractions, e)
+		}
+	}
+
+	return extractions
+}
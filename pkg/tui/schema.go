@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"github.com/Yalaouf/gostman/pkg/graphql"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type schemaMsg struct {
+	schema *graphql.Schema
+	err    error
+}
+
+func (m Model) fetchSchema() (Model, tea.Cmd) {
+	cmd := m.schemaPopup.Show()
+
+	model := m.buildRequestModel()
+	if model.URL == "" {
+		m.schemaPopup.SetError("URL is empty")
+		return m, cmd
+	}
+
+	introspect := func() tea.Msg {
+		schema, err := graphql.Introspect(model)
+		return schemaMsg{schema: schema, err: err}
+	}
+
+	return m, tea.Batch(cmd, introspect)
+}
+
+func (m Model) handleSchema(msg schemaMsg) Model {
+	if msg.err != nil {
+		m.schemaPopup.SetError(msg.err.Error())
+		return m
+	}
+
+	m.body.SetSchema(msg.schema)
+	m.schemaPopup.SetSchema(msg.schema)
+	return m
+}
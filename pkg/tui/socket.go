@@ -0,0 +1,143 @@
+package tui
+
+import (
+	"context"
+
+	"github.com/Yalaouf/gostman/pkg/socket"
+	"github.com/Yalaouf/gostman/pkg/variables"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gorilla/websocket"
+)
+
+type socketConnectedMsg struct {
+	conn *socket.Conn
+	url  string
+	err  error
+}
+
+type socketEventMsg struct {
+	conn  *socket.Conn
+	event socket.Event
+}
+
+type socketClosedMsg struct {
+	conn *socket.Conn
+}
+
+type socketSentMsg struct {
+	event socket.Event
+	err   error
+}
+
+func (m Model) handleSocketSend() (Model, tea.Cmd) {
+	if m.socketConn == nil {
+		return m.connectSocket()
+	}
+
+	conn := m.socketConn
+	typ := m.composer.Type
+	body := variables.Resolve(m.composer.Value(), m.variables())
+
+	return m, func() tea.Msg {
+		event, err := conn.Send(typ, body)
+		return socketSentMsg{event: event, err: err}
+	}
+}
+
+func (m Model) connectSocket() (Model, tea.Cmd) {
+	model := m.buildRequestModel()
+	if model.URL == "" {
+		m.composer.SetError("URL is empty")
+		return m, nil
+	}
+
+	m.messages.Clear()
+	m.messages.SetConnected(false, "Connecting to "+model.URL+"...")
+
+	return m, func() tea.Msg {
+		conn, err := socket.Dial(context.Background(), model.URL, model.HTTPHeader())
+		return socketConnectedMsg{conn: conn, url: model.URL, err: err}
+	}
+}
+
+func (m Model) pingSocket() (Model, tea.Cmd) {
+	conn := m.socketConn
+	if conn == nil {
+		return m, nil
+	}
+
+	return m, func() tea.Msg {
+		event, err := conn.Ping("")
+		return socketSentMsg{event: event, err: err}
+	}
+}
+
+func (m Model) closeSocket() (Model, tea.Cmd) {
+	conn := m.socketConn
+	if conn == nil {
+		return m, nil
+	}
+
+	m.messages.SetConnected(false, "Closing...")
+
+	return m, func() tea.Msg {
+		event, err := conn.Close(websocket.CloseNormalClosure, "")
+		return socketSentMsg{event: event, err: err}
+	}
+}
+
+func waitForSocketEvent(conn *socket.Conn) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-conn.Events()
+		if !ok {
+			return socketClosedMsg{conn: conn}
+		}
+		return socketEventMsg{conn: conn, event: event}
+	}
+}
+
+func (m Model) handleSocketConnected(msg socketConnectedMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		m.messages.SetConnected(false, "Connection failed: "+msg.err.Error())
+		return m, nil
+	}
+
+	status := "Connected to " + msg.url
+	if protocol := msg.conn.Protocol(); protocol != "" {
+		status += " (" + protocol + ")"
+	}
+
+	m.socketConn = msg.conn
+	m.messages.SetConnected(true, status)
+	return m, waitForSocketEvent(msg.conn)
+}
+
+func (m Model) handleSocketEvent(msg socketEventMsg) (Model, tea.Cmd) {
+	if msg.conn != m.socketConn {
+		return m, nil
+	}
+
+	m.messages.Append(msg.event)
+	return m, waitForSocketEvent(msg.conn)
+}
+
+func (m Model) handleSocketClosed(msg socketClosedMsg) Model {
+	if msg.conn != m.socketConn {
+		return m
+	}
+
+	m.socketConn = nil
+	m.messages.SetConnected(false, "Disconnected")
+	return m
+}
+
+func (m Model) handleSocketSent(msg socketSentMsg) Model {
+	if msg.err != nil {
+		m.composer.SetError(msg.err.Error())
+		return m
+	}
+
+	m.composer.SetError("")
+	m.messages.Append(msg.event)
+	return m
+}
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"io"
+
+	"github.com/Yalaouf/gostman/pkg/request"
+	"github.com/Yalaouf/gostman/pkg/sse"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const streamBuffer = 256
+
+type streamStartMsg struct {
+	events   chan tea.Msg
+	response request.Response
+}
+
+type streamEventMsg struct {
+	events chan tea.Msg
+	event  sse.Event
+}
+
+type progressMsg struct {
+	events   chan tea.Msg
+	progress request.Progress
+}
+
+type streamClosedMsg struct {
+	events chan tea.Msg
+}
+
+func streamTo(events chan tea.Msg) request.StreamFunc {
+	return func(res *request.Response, body io.Reader) error {
+		events <- streamStartMsg{events: events, response: *res}
+		return sse.Read(body, func(e sse.Event) {
+			events <- streamEventMsg{events: events, event: e}
+		})
+	}
+}
+
+func progressTo(events chan tea.Msg) request.ProgressFunc {
+	return func(p request.Progress) {
+		select {
+		case events <- progressMsg{events: events, progress: p}:
+		default:
+		}
+	}
+}
+
+func waitForStream(events chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-events
+		if !ok {
+			return streamClosedMsg{events: events}
+		}
+		return msg
+	}
+}
+
+func (m Model) handleStreamStart(msg streamStartMsg) (Model, tea.Cmd) {
+	if msg.events == m.streamEvents {
+		m.response.StartStream(msg.response)
+	}
+	return m, waitForStream(msg.events)
+}
+
+func (m Model) handleStreamEvent(msg streamEventMsg) (Model, tea.Cmd) {
+	if msg.events == m.streamEvents {
+		m.response.AppendEvent(msg.event)
+	}
+	return m, waitForStream(msg.events)
+}
+
+func (m Model) handleProgress(msg progressMsg) (Model, tea.Cmd) {
+	if msg.events == m.streamEvents {
+		m.response.SetProgress(msg.progress)
+	}
+	return m, waitForStream(msg.events)
+}
+
+func (m Model) handleStreamClosed(msg streamClosedMsg) Model {
+	if msg.events == m.streamEvents {
+		m.streamEvents = nil
+		m.streamCancel = nil
+	}
+	return m
+}
+
+func (m Model) stopStream() Model {
+	if m.streamCancel != nil {
+		m.streamCancel()
+	}
+	return m
+}
+
+func (m Model) reconnectStream() (Model, tea.Cmd) {
+	if !m.response.IsStream() || m.response.Streaming() {
+		return m, nil
+	}
+
+	m.response.SetLoading(true)
+	m.response.Error = ""
+	return m.sendRequest(m.response.LastEventID())
+}
@@ -5,10 +5,11 @@ const (
 	KeyCtrlG    = "ctrl+g"
 	KeyCtrlC    = "ctrl+c"
 
-	KeyEnter  = "enter"
-	KeyEscape = "esc"
-	KeyTab    = "tab"
-	KeySpace  = " "
+	KeyEnter     = "enter"
+	KeyEscape    = "esc"
+	KeyTab       = "tab"
+	KeySpace     = " "
+	KeyBackspace = "backspace"
 
 	KeyDown  = "down"
 	KeyLeft  = "left"
@@ -17,22 +18,42 @@ const (
 
 	KeyA = "a"
 	KeyB = "b"
+	KeyC = "c"
 	KeyD = "d"
+	KeyE = "e"
 	KeyF = "f"
 	KeyG = "g"
 	KeyH = "h"
+	KeyI = "i"
 	KeyJ = "j"
 	KeyK = "k"
 	KeyL = "l"
 	KeyM = "m"
+	KeyN = "n"
+	KeyO = "o"
 	KeyP = "p"
 	KeyQ = "q"
 	KeyR = "r"
 	KeyS = "s"
+	KeyT = "t"
 	KeyU = "u"
+	KeyV = "v"
+	KeyW = "w"
+	KeyX = "x"
 	KeyY = "y"
+	KeyZ = "z"
 
-	KeyShiftG = "G"
+	KeyShiftA   = "A"
+	KeyShiftB   = "B"
+	KeyShiftG   = "G"
+	KeyShiftH   = "H"
+	KeyShiftJ   = "J"
+	KeyShiftK   = "K"
+	KeyShiftP   = "P"
+	KeyShiftR   = "R"
+	KeyShiftS   = "S"
+	KeyShiftZ   = "Z"
+	KeyShiftTab = "shift+tab"
 
 	KeyQuestion = "?"
 )
@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"bytes"
+
+	"github.com/Yalaouf/gostman/pkg/tui/style"
+	"github.com/alecthomas/chroma/v2/quick"
+)
+
+func HighlightCode(code, lexer string) string {
+	var buf bytes.Buffer
+	err := quick.Highlight(&buf, code, lexer, "terminal256", style.ChromaStyle)
+	if err != nil {
+		return code
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"maps"
+
+	"github.com/Yalaouf/gostman/pkg/extraction"
+	"github.com/Yalaouf/gostman/pkg/runner"
+	"github.com/Yalaouf/gostman/pkg/script"
+	"github.com/Yalaouf/gostman/pkg/variables"
+)
+
+func (m Model) variables() map[string]string {
+	collection, _ := m.storage.GetCollection(m.collectionID)
+	environment, _ := m.storage.GetEnvironment(m.environmentID)
+
+	return variables.Merge(m.session, runner.Variables(collection, environment, nil))
+}
+
+func (m Model) environmentName() string {
+	environment, err := m.storage.GetEnvironment(m.environmentID)
+	if err != nil {
+		return "no environment"
+	}
+
+	return environment.Name
+}
+
+func (m Model) setVariables(scope string, vars map[string]string) error {
+	collection, _ := m.storage.GetCollection(m.collectionID)
+	environment, _ := m.storage.GetEnvironment(m.environmentID)
+
+	saved, err := runner.SaveVariables(m.storage, collection, environment, scope, vars)
+	if !saved {
+		maps.Copy(m.session, vars)
+	}
+
+	return err
+}
+
+func (m Model) applyExtractions(results []extraction.Result) []extraction.Result {
+	for _, scope := range runner.Scopes {
+		err := m.setVariables(scope, extraction.Variables(results, scope))
+		if err == nil {
+			continue
+		}
+
+		for i := range results {
+			if results[i].Err == nil && results[i].Extraction.Scope == scope {
+				results[i].Err = err
+			}
+		}
+	}
+
+	return results
+}
+
+func (m Model) applyScriptUpdates(updates []script.Variable) error {
+	for _, scope := range runner.Scopes {
+		if err := m.setVariables(scope, runner.ScriptVariables(updates, scope)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -34,6 +34,126 @@ func (m Model) View() string {
 		)
 	}
 
+	if m.codePopup.Visible() {
+		return lipgloss.Place(
+			m.width,
+			m.height,
+			lipgloss.Center,
+			lipgloss.Center,
+			m.codePopup.View(),
+		)
+	}
+
+	if m.testsPopup.Visible() {
+		return lipgloss.Place(
+			m.width,
+			m.height,
+			lipgloss.Center,
+			lipgloss.Center,
+			m.testsPopup.View(),
+		)
+	}
+
+	if m.workspaces.Visible() {
+		return lipgloss.Place(
+			m.width,
+			m.height,
+			lipgloss.Center,
+			lipgloss.Center,
+			m.workspaces.View(),
+		)
+	}
+
+	if m.rulesPopup.Visible() {
+		return lipgloss.Place(
+			m.width,
+			m.height,
+			lipgloss.Center,
+			lipgloss.Center,
+			m.rulesPopup.View(),
+		)
+	}
+
+	if m.retryPopup.Visible() {
+		return lipgloss.Place(
+			m.width,
+			m.height,
+			lipgloss.Center,
+			lipgloss.Center,
+			m.retryPopup.View(),
+		)
+	}
+
+	if m.connPopup.Visible() {
+		return lipgloss.Place(
+			m.width,
+			m.height,
+			lipgloss.Center,
+			lipgloss.Center,
+			m.connPopup.View(),
+		)
+	}
+
+	if m.envPopup.Visible() {
+		return lipgloss.Place(
+			m.width,
+			m.height,
+			lipgloss.Center,
+			lipgloss.Center,
+			m.envPopup.View(),
+		)
+	}
+
+	if m.extractPopup.Visible() {
+		return lipgloss.Place(
+			m.width,
+			m.height,
+			lipgloss.Center,
+			lipgloss.Center,
+			m.extractPopup.View(),
+		)
+	}
+
+	if m.benchPopup.Visible() {
+		return lipgloss.Place(
+			m.width,
+			m.height,
+			lipgloss.Center,
+			lipgloss.Center,
+			m.benchPopup.View(),
+		)
+	}
+
+	if m.scriptPopup.Visible() {
+		return lipgloss.Place(
+			m.width,
+			m.height,
+			lipgloss.Center,
+			lipgloss.Center,
+			m.scriptPopup.View(),
+		)
+	}
+
+	if m.schemaPopup.Visible() {
+		return lipgloss.Place(
+			m.width,
+			m.height,
+			lipgloss.Center,
+			lipgloss.Center,
+			m.schemaPopup.View(),
+		)
+	}
+
+	if m.rpcPopup.Visible() {
+		return lipgloss.Place(
+			m.width,
+			m.height,
+			lipgloss.Center,
+			lipgloss.Center,
+			m.rpcPopup.View(),
+		)
+	}
+
 	if m.response.IsFullscreen() {
 		return lipgloss.Place(
 			m.width,
@@ -56,10 +176,20 @@ func (m Model) View() string {
 
 	headersView := m.headers.View(leftWidth)
 	bodyView := m.body.View(leftWidth)
-	leftPanel := lipgloss.JoinVertical(lipgloss.Left, headersView, bodyView)
-
+	m.response.Raw = m.rawResponse && !m.method.IsWebSocket() && !m.method.IsGRPC()
 	responseView := m.response.View(rightWidth)
 
+	if m.method.IsGRPC() {
+		bodyView = m.body.ViewWithTitle(m.rpcTitle(), leftWidth)
+	}
+
+	if m.method.IsWebSocket() {
+		bodyView = m.composer.View(leftWidth)
+		responseView = m.messages.View(rightWidth)
+	}
+
+	leftPanel := lipgloss.JoinVertical(lipgloss.Left, headersView, bodyView)
+
 	middle := lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, responseView)
 
 	statusBar := m.statusBar()
@@ -83,7 +213,21 @@ func (m Model) View() string {
 
 func (m Model) displayTitle() string {
 	title := style.Title.Render("GOSTMAN")
-	return lipgloss.PlaceHorizontal(m.width-2, lipgloss.Center, title)
+	workspace := style.Unselected.Render(
+		"[e] " + m.environmentName() + "  [w] " + m.storage.Workspace().Name,
+	)
+
+	totalWidth := m.width - 2
+	titleWidth := lipgloss.Width(title)
+	workspaceWidth := lipgloss.Width(workspace)
+
+	leftPad := max((totalWidth-titleWidth)/2, 0)
+	rightPad := totalWidth - leftPad - titleWidth - workspaceWidth
+	if rightPad < 1 {
+		return lipgloss.PlaceHorizontal(totalWidth, lipgloss.Center, title)
+	}
+
+	return strings.Repeat(" ", leftPad) + title + strings.Repeat(" ", rightPad) + workspace
 }
 
 func (m Model) statusBar() string {
@@ -97,6 +241,9 @@ func (m Model) statusBar() string {
 		keyStyle.Render("[r]") + sepStyle.Render("esponse ") +
 		keyStyle.Render("[s]") + sepStyle.Render("ave ") +
 		keyStyle.Render("[l]") + sepStyle.Render("oad ") +
+		keyStyle.Render("[c]") + sepStyle.Render("ode ") +
+		keyStyle.Render("[t]") + sepStyle.Render("ests ") +
+		keyStyle.Render("[v]") + sepStyle.Render("ars ") +
 		keyStyle.Render("["+utils.SendRequestShortcut()+"]") + sepStyle.Render("send ") +
 		keyStyle.Render("[q]") + sepStyle.Render("uit")
 
@@ -0,0 +1,95 @@
+package variables
+
+import (
+	"maps"
+	"math/rand/v2"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxDepth = 10
+
+var placeholder = regexp.MustCompile(`{{\s*([^{}]+?)\s*}}`)
+
+func Resolve(s string, vars map[string]string) string {
+	for range maxDepth {
+		resolved := placeholder.ReplaceAllStringFunc(s, func(match string) string {
+			name := placeholder.FindStringSubmatch(match)[1]
+
+			if value, ok := vars[name]; ok {
+				return value
+			}
+
+			if value, ok := dynamic(name); ok {
+				return value
+			}
+
+			return match
+		})
+
+		if resolved == s {
+			return resolved
+		}
+		s = resolved
+	}
+
+	return s
+}
+
+func Merge(layers ...map[string]string) map[string]string {
+	result := make(map[string]string)
+
+	for _, layer := range layers {
+		maps.Copy(result, layer)
+	}
+
+	return result
+}
+
+func Names(s string) []string {
+	var names []string
+
+	for _, match := range placeholder.FindAllStringSubmatch(s, -1) {
+		names = append(names, match[1])
+	}
+
+	return names
+}
+
+func dynamic(name string) (string, bool) {
+	fields := strings.Fields(name)
+	if len(fields) == 0 {
+		return "", false
+	}
+
+	switch fields[0] {
+	case "$guid", "$uuid", "$random.uuid":
+		return uuid.NewString(), true
+	case "$timestamp":
+		return strconv.FormatInt(time.Now().Unix(), 10), true
+	case "$isoTimestamp":
+		return time.Now().UTC().Format(time.RFC3339), true
+	case "$randomInt":
+		low, high := 0, 1000
+		if len(fields) == 3 {
+			l, errLow := strconv.Atoi(fields[1])
+			h, errHigh := strconv.Atoi(fields[2])
+			if errLow == nil && errHigh == nil && h > l {
+				low, high = l, h
+			}
+		}
+		return strconv.Itoa(low + rand.IntN(high-low)), true
+	case "$processEnv":
+		if len(fields) != 2 {
+			return "", false
+		}
+		return os.Getenv(fields[1]), true
+	}
+
+	return "", false
+}
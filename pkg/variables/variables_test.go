@@ -0,0 +1,86 @@
+package variables
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolve(t *testing.T) {
+	t.Run("should replace known variables", func(t *testing.T) {
+		vars := map[string]string{"host": "localhost", "port": "3000"}
+
+		res := Resolve("http://{{host}}:{{ port }}/", vars)
+
+		assert.Equal(t, "http://localhost:3000/", res)
+	})
+
+	t.Run("should leave unknown variables untouched", func(t *testing.T) {
+		res := Resolve("Bearer {{token}}", nil)
+
+		assert.Equal(t, "Bearer {{token}}", res)
+	})
+
+	t.Run("should resolve nested variables", func(t *testing.T) {
+		vars := map[string]string{"base": "{{host}}/api", "host": "http://localhost"}
+
+		res := Resolve("{{base}}/users", vars)
+
+		assert.Equal(t, "http://localhost/api/users", res)
+	})
+
+	t.Run("should stop on self-referencing variables", func(t *testing.T) {
+		vars := map[string]string{"loop": "{{loop}}"}
+
+		res := Resolve("{{loop}}", vars)
+
+		assert.Equal(t, "{{loop}}", res)
+	})
+
+	t.Run("should resolve dynamic variables", func(t *testing.T) {
+		t.Setenv("GOSTMAN_TEST_VAR", "from-env")
+
+		_, err := uuid.Parse(Resolve("{{$guid}}", nil))
+		assert.NoError(t, err)
+
+		_, err = strconv.ParseInt(Resolve("{{$timestamp}}", nil), 10, 64)
+		assert.NoError(t, err)
+
+		n, err := strconv.Atoi(Resolve("{{$randomInt 5 6}}", nil))
+		require.NoError(t, err)
+		assert.Equal(t, 5, n)
+
+		assert.Equal(t, "from-env", Resolve("{{$processEnv GOSTMAN_TEST_VAR}}", nil))
+	})
+
+	t.Run("should prefer defined variables over dynamic ones", func(t *testing.T) {
+		res := Resolve("{{$guid}}", map[string]string{"$guid": "fixed"})
+
+		assert.Equal(t, "fixed", res)
+	})
+}
+
+func TestMerge(t *testing.T) {
+	t.Run("should let later layers override earlier ones", func(t *testing.T) {
+		res := Merge(
+			map[string]string{"a": "1", "b": "1"},
+			nil,
+			map[string]string{"b": "2"},
+		)
+
+		assert.Equal(t, map[string]string{"a": "1", "b": "2"}, res)
+	})
+}
+
+func TestNames(t *testing.T) {
+	t.Run("should list referenced variable names", func(t *testing.T) {
+		assert.Equal(t, []string{"host", "id"}, Names("{{host}}/users/{{ id }}"))
+	})
+
+	t.Run("should return nil without placeholders", func(t *testing.T) {
+		assert.Nil(t, Names("plain"))
+	})
+}